@@ -0,0 +1,18 @@
+package account
+
+import "time"
+
+type Account struct {
+	UserId          string     `json:"userId" gorm:"column:user_id;primary_key"`
+	Username        string     `json:"username,omitempty" gorm:"column:username"`
+	Status          string     `json:"status,omitempty" gorm:"column:status"`
+	FailCount       *int       `json:"failCount,omitempty" gorm:"column:fail_count"`
+	FailTime        *time.Time `json:"failTime,omitempty" gorm:"column:fail_time"`
+	LockedUntilTime *time.Time `json:"lockedUntilTime,omitempty" gorm:"column:locked_until_time"`
+}
+
+// StatusRequest has the reason of the change, which is kept in the audit log
+type StatusRequest struct {
+	Reason string     `json:"reason" validate:"required,max=200"`
+	Until  *time.Time `json:"until,omitempty"`
+}
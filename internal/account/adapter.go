@@ -0,0 +1,67 @@
+package account
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"time"
+
+	s "github.com/core-go/sql"
+)
+
+func NewAccountAdapter(db *sql.DB) (*AccountAdapter, error) {
+	parameters, err := s.CreateParameters(reflect.TypeOf(Account{}), db)
+	if err != nil {
+		return nil, err
+	}
+	return &AccountAdapter{DB: db, Parameters: parameters}, nil
+}
+
+type AccountAdapter struct {
+	DB *sql.DB
+	*s.Parameters
+}
+
+func (r *AccountAdapter) Load(ctx context.Context, userId string) (*Account, error) {
+	var accounts []Account
+	query := fmt.Sprintf(`select u.user_id, u.username, u.status, p.fail_count, p.fail_time, p.locked_until_time
+		from users u left join passwords p on u.user_id = p.user_id where u.user_id = %s limit 1`, r.BuildParam(1))
+	err := s.Query(ctx, r.DB, r.Map, &accounts, query, userId)
+	if err != nil {
+		return nil, err
+	}
+	if len(accounts) > 0 {
+		return &accounts[0], nil
+	}
+	return nil, nil
+}
+
+func (r *AccountAdapter) UpdateStatus(ctx context.Context, userId string, status string, updatedBy string, updatedAt time.Time) (int64, error) {
+	query := fmt.Sprintf("update users set status = %s, updated_by = %s, updated_at = %s where user_id = %s", r.BuildParam(1), r.BuildParam(2), r.BuildParam(3), r.BuildParam(4))
+	res, err := r.DB.ExecContext(ctx, query, status, updatedBy, updatedAt, userId)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *AccountAdapter) Lock(ctx context.Context, userId string, until time.Time) (int64, error) {
+	query := fmt.Sprintf(`insert into passwords (user_id, locked_until_time) values (%s, %s)
+		on conflict (user_id) do update set locked_until_time = excluded.locked_until_time`, r.BuildParam(1), r.BuildParam(2))
+	res, err := r.DB.ExecContext(ctx, query, userId, until)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+// Unlock clears the lock and the failed logins, so the next wrong password does not lock the user again
+func (r *AccountAdapter) Unlock(ctx context.Context, userId string) (int64, error) {
+	query := fmt.Sprintf("update passwords set locked_until_time = null, fail_count = 0, fail_time = null where user_id = %s", r.BuildParam(1))
+	res, err := r.DB.ExecContext(ctx, query, userId)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
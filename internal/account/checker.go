@@ -0,0 +1,71 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	auth "github.com/core-go/authentication"
+	"github.com/core-go/core"
+)
+
+// NewUserRepository sets Suspended and Disable of the user by the value of the status, so that the authenticator refuses the login
+func NewUserRepository(repository auth.UserRepository, status auth.UserStatusConfig) *UserRepository {
+	return &UserRepository{UserRepository: repository, status: status}
+}
+
+type UserRepository struct {
+	auth.UserRepository
+	status auth.UserStatusConfig
+}
+
+func (r *UserRepository) GetUser(ctx context.Context, username string) (*auth.UserInfo, error) {
+	user, err := r.UserRepository.GetUser(ctx, username)
+	if err != nil || user == nil || user.Status == nil {
+		return user, err
+	}
+	switch *user.Status {
+	case r.status.Suspended:
+		user.Suspended = true
+	case r.status.Disable:
+		user.Disable = true
+	case r.status.Deactivated:
+		deactivated := true
+		user.Deactivated = &deactivated
+	}
+	return user, nil
+}
+
+func NewTokenChecker(repository AccountRepository, status auth.UserStatusConfig, logError core.Log) *TokenChecker {
+	return &TokenChecker{repository: repository, status: status, logError: logError}
+}
+
+// TokenChecker refuses the tokens of suspended or disabled users, so that they stop working before they expire
+type TokenChecker struct {
+	repository AccountRepository
+	status     auth.UserStatusConfig
+	logError   core.Log
+}
+
+// Check returns the reason if the token is not valid anymore; the token is refused when the status cannot be loaded
+func (c *TokenChecker) Check(id string, token string, issuedAt time.Time) string {
+	if len(id) == 0 {
+		return ""
+	}
+	ctx := context.Background()
+	account, err := c.repository.Load(ctx, id)
+	if err != nil {
+		c.logError(ctx, fmt.Sprintf("Error to check the status of '%s': %s", id, err.Error()))
+		return "status not checked"
+	}
+	if account == nil {
+		return "not found"
+	}
+	switch account.Status {
+	case c.status.Suspended:
+		return "suspended"
+	case c.status.Disable:
+		return "disabled"
+	}
+	return ""
+}
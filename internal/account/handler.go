@@ -0,0 +1,97 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/core-go/core"
+)
+
+func NewAccountHandler(service AccountService, logError core.Log, validate core.Validate[*StatusRequest], writeLog core.WriteLog, action *core.ActionConfig) *AccountHandler {
+	attributes := core.CreateAttributes(reflect.TypeOf(Account{}), logError, writeLog, action)
+	return &AccountHandler{service: service, Validate: validate, Attributes: attributes}
+}
+
+type AccountHandler struct {
+	service  AccountService
+	Validate core.Validate[*StatusRequest]
+	*core.Attributes
+}
+
+func (h *AccountHandler) Load(w http.ResponseWriter, r *http.Request) {
+	id, err := core.GetRequiredString(w, r, 1)
+	if err == nil {
+		account, err := h.service.Load(r.Context(), id)
+		if err != nil {
+			h.Error(r.Context(), fmt.Sprintf("Error to get account '%s': %s", id, err.Error()))
+			http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+			return
+		}
+		core.JSON(w, core.IsFound(account), account)
+	}
+}
+
+func (h *AccountHandler) Lock(w http.ResponseWriter, r *http.Request) {
+	h.change(w, r, "lock", func(ctx context.Context, id string, req *StatusRequest) (*Account, int64, error) {
+		return h.service.Lock(ctx, id, *req.Until)
+	})
+}
+func (h *AccountHandler) Unlock(w http.ResponseWriter, r *http.Request) {
+	h.change(w, r, "unlock", func(ctx context.Context, id string, req *StatusRequest) (*Account, int64, error) {
+		return h.service.Unlock(ctx, id)
+	})
+}
+func (h *AccountHandler) Suspend(w http.ResponseWriter, r *http.Request) {
+	h.change(w, r, "suspend", func(ctx context.Context, id string, req *StatusRequest) (*Account, int64, error) {
+		return h.service.Suspend(ctx, id)
+	})
+}
+func (h *AccountHandler) Reactivate(w http.ResponseWriter, r *http.Request) {
+	h.change(w, r, "reactivate", func(ctx context.Context, id string, req *StatusRequest) (*Account, int64, error) {
+		return h.service.Reactivate(ctx, id)
+	})
+}
+func (h *AccountHandler) Disable(w http.ResponseWriter, r *http.Request) {
+	h.change(w, r, "disable", func(ctx context.Context, id string, req *StatusRequest) (*Account, int64, error) {
+		return h.service.Disable(ctx, id)
+	})
+}
+
+func (h *AccountHandler) change(w http.ResponseWriter, r *http.Request, action string, change func(context.Context, string, *StatusRequest) (*Account, int64, error)) {
+	id, err := core.GetRequiredString(w, r, 1)
+	if err == nil {
+		req, er1 := core.Decode[StatusRequest](w, r)
+		if er1 == nil {
+			errors, er2 := h.Validate(r.Context(), &req)
+			if er2 == nil && action == "lock" {
+				if req.Until == nil {
+					errors = append(errors, core.ErrorMessage{Field: "until", Code: "required"})
+				} else if !req.Until.After(time.Now()) {
+					errors = append(errors, core.ErrorMessage{Field: "until", Code: "min"})
+				}
+			}
+			if !core.HasError(w, r, errors, er2, h.Error, &req, h.Log, h.Resource, action) {
+				account, res, er3 := change(r.Context(), id, &req)
+				if er3 != nil {
+					h.Error(r.Context(), fmt.Sprintf("Error to %s '%s': %s", action, id, er3.Error()))
+					h.Log(r.Context(), h.Resource, action, false, er3.Error())
+					http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+					return
+				}
+				if res > 0 {
+					h.Log(r.Context(), h.Resource, action, true, fmt.Sprintf("%s '%s': %s", action, id, req.Reason))
+					core.JSON(w, http.StatusOK, account)
+				} else if res == 0 {
+					h.Log(r.Context(), h.Resource, action, false, fmt.Sprintf("not found '%s'", id))
+					core.JSON(w, http.StatusNotFound, res)
+				} else {
+					h.Log(r.Context(), h.Resource, action, false, fmt.Sprintf("conflict '%s' with status '%s'", id, account.Status))
+					core.JSON(w, http.StatusConflict, account)
+				}
+			}
+		}
+	}
+}
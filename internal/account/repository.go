@@ -0,0 +1,13 @@
+package account
+
+import (
+	"context"
+	"time"
+)
+
+type AccountRepository interface {
+	Load(ctx context.Context, userId string) (*Account, error)
+	UpdateStatus(ctx context.Context, userId string, status string, updatedBy string, updatedAt time.Time) (int64, error)
+	Lock(ctx context.Context, userId string, until time.Time) (int64, error)
+	Unlock(ctx context.Context, userId string) (int64, error)
+}
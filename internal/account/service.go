@@ -0,0 +1,94 @@
+package account
+
+import (
+	"context"
+	"time"
+
+	auth "github.com/core-go/authentication"
+)
+
+type AccountService interface {
+	Load(ctx context.Context, userId string) (*Account, error)
+	Lock(ctx context.Context, userId string, until time.Time) (*Account, int64, error)
+	Unlock(ctx context.Context, userId string) (*Account, int64, error)
+	Suspend(ctx context.Context, userId string) (*Account, int64, error)
+	Reactivate(ctx context.Context, userId string) (*Account, int64, error)
+	Disable(ctx context.Context, userId string) (*Account, int64, error)
+}
+
+func NewAccountService(repository AccountRepository, status auth.UserStatusConfig, userKey string) *AccountUseCase {
+	return &AccountUseCase{repository: repository, status: status, userKey: userKey}
+}
+
+type AccountUseCase struct {
+	repository AccountRepository
+	status     auth.UserStatusConfig
+	userKey    string
+}
+
+func (s *AccountUseCase) Load(ctx context.Context, userId string) (*Account, error) {
+	return s.repository.Load(ctx, userId)
+}
+
+// Lock returns -1 if the user is locked until a later time already
+func (s *AccountUseCase) Lock(ctx context.Context, userId string, until time.Time) (*Account, int64, error) {
+	account, err := s.repository.Load(ctx, userId)
+	if err != nil || account == nil {
+		return account, 0, err
+	}
+	if account.LockedUntilTime != nil && !account.LockedUntilTime.Before(until) {
+		return account, -1, nil
+	}
+	if _, err = s.repository.Lock(ctx, userId, until); err != nil {
+		return account, -1, err
+	}
+	account.LockedUntilTime = &until
+	return account, 1, nil
+}
+
+func (s *AccountUseCase) Unlock(ctx context.Context, userId string) (*Account, int64, error) {
+	account, err := s.repository.Load(ctx, userId)
+	if err != nil || account == nil {
+		return account, 0, err
+	}
+	if (account.LockedUntilTime == nil || account.LockedUntilTime.Before(time.Now())) && (account.FailCount == nil || *account.FailCount == 0) {
+		return account, -1, nil
+	}
+	if _, err = s.repository.Unlock(ctx, userId); err != nil {
+		return account, -1, err
+	}
+	zero := 0
+	account.LockedUntilTime, account.FailTime, account.FailCount = nil, nil, &zero
+	return account, 1, nil
+}
+
+// Suspend is temporary, the user can be reactivated. A disabled user cannot be suspended.
+func (s *AccountUseCase) Suspend(ctx context.Context, userId string) (*Account, int64, error) {
+	return s.setStatus(ctx, userId, s.status.Suspended, s.status.Suspended, s.status.Disable)
+}
+func (s *AccountUseCase) Reactivate(ctx context.Context, userId string) (*Account, int64, error) {
+	return s.setStatus(ctx, userId, s.status.Activated, s.status.Activated)
+}
+func (s *AccountUseCase) Disable(ctx context.Context, userId string) (*Account, int64, error) {
+	return s.setStatus(ctx, userId, s.status.Disable, s.status.Disable)
+}
+
+// setStatus returns -1 if the current status of the user is one of the conflicts
+func (s *AccountUseCase) setStatus(ctx context.Context, userId string, status string, conflicts ...string) (*Account, int64, error) {
+	account, err := s.repository.Load(ctx, userId)
+	if err != nil || account == nil {
+		return account, 0, err
+	}
+	for _, conflict := range conflicts {
+		if account.Status == conflict {
+			return account, -1, nil
+		}
+	}
+	updatedBy, _ := ctx.Value(s.userKey).(string)
+	res, err := s.repository.UpdateStatus(ctx, userId, status, updatedBy, time.Now())
+	if err != nil || res <= 0 {
+		return account, res, err
+	}
+	account.Status = status
+	return account, res, nil
+}
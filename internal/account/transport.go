@@ -0,0 +1,33 @@
+package account
+
+import (
+	"database/sql"
+	"net/http"
+
+	auth "github.com/core-go/authentication"
+	"github.com/core-go/core"
+	v "github.com/core-go/core/validator"
+)
+
+type AccountTransport interface {
+	Load(w http.ResponseWriter, r *http.Request)
+	Lock(w http.ResponseWriter, r *http.Request)
+	Unlock(w http.ResponseWriter, r *http.Request)
+	Suspend(w http.ResponseWriter, r *http.Request)
+	Reactivate(w http.ResponseWriter, r *http.Request)
+	Disable(w http.ResponseWriter, r *http.Request)
+}
+
+func NewAccountTransport(db *sql.DB, status auth.UserStatusConfig, userKey string, logError core.Log, writeLog core.WriteLog, action *core.ActionConfig) (AccountTransport, *TokenChecker, error) {
+	validator, err := v.NewValidator[*StatusRequest]()
+	if err != nil {
+		return nil, nil, err
+	}
+	accountRepository, err := NewAccountAdapter(db)
+	if err != nil {
+		return nil, nil, err
+	}
+	accountService := NewAccountService(accountRepository, status, userKey)
+	accountHandler := NewAccountHandler(accountService, logError, validator.Validate, writeLog, action)
+	return accountHandler, NewTokenChecker(accountRepository, status, logError), nil
+}
@@ -0,0 +1,179 @@
+package apikey
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"time"
+
+	s "github.com/core-go/sql"
+)
+
+func NewApiKeyAdapter(db *sql.DB, toArray s.Array) (*ApiKeyAdapter, error) {
+	parameters, err := s.CreateParameters(reflect.TypeOf(ServiceAccount{}), db)
+	if err != nil {
+		return nil, err
+	}
+	keyParameters, err := s.CreateParameters(reflect.TypeOf(ApiKey{}), db)
+	if err != nil {
+		return nil, err
+	}
+	moduleMap, err := s.GetColumnIndexes(reflect.TypeOf(keyModule{}))
+	if err != nil {
+		return nil, err
+	}
+	return &ApiKeyAdapter{DB: db, Parameters: parameters, Key: keyParameters, ModuleMap: moduleMap, Array: toArray}, nil
+}
+
+type ApiKeyAdapter struct {
+	DB *sql.DB
+	*s.Parameters
+	Key       *s.Parameters
+	ModuleMap map[string]int
+	Array     s.Array
+}
+
+func (r *ApiKeyAdapter) All(ctx context.Context) ([]ServiceAccount, error) {
+	var accounts []ServiceAccount
+	query := fmt.Sprintf("select %s from service_accounts order by name", r.Fields)
+	err := s.Query(ctx, r.DB, r.Map, &accounts, query)
+	return accounts, err
+}
+
+func (r *ApiKeyAdapter) Load(ctx context.Context, id string) (*ServiceAccount, error) {
+	var accounts []ServiceAccount
+	query := fmt.Sprintf("select %s from service_accounts where id = %s limit 1", r.Fields, r.BuildParam(1))
+	err := s.Query(ctx, r.DB, r.Map, &accounts, query, id)
+	if err != nil || len(accounts) == 0 {
+		return nil, err
+	}
+	return &accounts[0], nil
+}
+
+func (r *ApiKeyAdapter) Create(ctx context.Context, account *ServiceAccount) (int64, error) {
+	query := fmt.Sprintf(`insert into service_accounts (id, name, description, status, created_by, created_at, updated_by, updated_at)
+		values (%s, %s, %s, %s, %s, %s, %s, %s) on conflict (id) do nothing`,
+		r.BuildParam(1), r.BuildParam(2), r.BuildParam(3), r.BuildParam(4), r.BuildParam(5), r.BuildParam(6), r.BuildParam(7), r.BuildParam(8))
+	res, err := r.DB.ExecContext(ctx, query, account.Id, account.Name, account.Description, account.Status, account.CreatedBy, account.CreatedAt, account.UpdatedBy, account.UpdatedAt)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *ApiKeyAdapter) Update(ctx context.Context, account *ServiceAccount) (int64, error) {
+	query := fmt.Sprintf("update service_accounts set name = %s, description = %s, status = %s, updated_by = %s, updated_at = %s where id = %s",
+		r.BuildParam(1), r.BuildParam(2), r.BuildParam(3), r.BuildParam(4), r.BuildParam(5), r.BuildParam(6))
+	res, err := r.DB.ExecContext(ctx, query, account.Name, account.Description, account.Status, account.UpdatedBy, account.UpdatedAt, account.Id)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+// Delete removes the service account with its keys; the key ids are still in the audit logs
+func (r *ApiKeyAdapter) Delete(ctx context.Context, id string) (int64, error) {
+	tx := s.GetTx(ctx, r.DB)
+	query := fmt.Sprintf("delete from api_key_modules where key_id in (select id from api_keys where account_id = %s)", r.BuildParam(1))
+	if _, err := tx.ExecContext(ctx, query, id); err != nil {
+		return -1, err
+	}
+	query = fmt.Sprintf("delete from api_keys where account_id = %s", r.BuildParam(1))
+	if _, err := tx.ExecContext(ctx, query, id); err != nil {
+		return -1, err
+	}
+	query = fmt.Sprintf("delete from service_accounts where id = %s", r.BuildParam(1))
+	res, err := tx.ExecContext(ctx, query, id)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *ApiKeyAdapter) LoadKeys(ctx context.Context, accountId string) ([]ApiKey, error) {
+	var keys []ApiKey
+	query := fmt.Sprintf("select %s from api_keys where account_id = %s order by created_at desc", r.Key.Fields, r.BuildParam(1))
+	err := s.QueryWithArray(ctx, r.DB, r.Key.Map, &keys, r.Array, query, accountId)
+	if err != nil || len(keys) == 0 {
+		return keys, err
+	}
+	var modules []keyModule
+	query = fmt.Sprintf("select key_id, module_id, permissions from api_key_modules where key_id in (select id from api_keys where account_id = %s)", r.BuildParam(1))
+	err = s.Query(ctx, r.DB, r.ModuleMap, &modules, query, accountId)
+	if err != nil {
+		return nil, err
+	}
+	byKey := make(map[string][]keyModule)
+	for _, m := range modules {
+		byKey[m.KeyId] = append(byKey[m.KeyId], m)
+	}
+	for i := range keys {
+		keys[i].Privileges = toPrivileges(byKey[keys[i].Id])
+	}
+	return keys, nil
+}
+
+// LoadKey loads the key by its hash, with its privileges; the keys of the inactive service accounts are not loaded
+func (r *ApiKeyAdapter) LoadKey(ctx context.Context, hash string) (*ApiKey, error) {
+	var keys []ApiKey
+	query := fmt.Sprintf("select %s from api_keys where hash = %s and account_id in (select id from service_accounts where status = 'A') limit 1", r.Key.Fields, r.BuildParam(1))
+	err := s.QueryWithArray(ctx, r.DB, r.Key.Map, &keys, r.Array, query, hash)
+	if err != nil || len(keys) == 0 {
+		return nil, err
+	}
+	key := keys[0]
+	var modules []keyModule
+	query = fmt.Sprintf("select key_id, module_id, permissions from api_key_modules where key_id = %s", r.BuildParam(1))
+	err = s.Query(ctx, r.DB, r.ModuleMap, &modules, query, key.Id)
+	if err != nil {
+		return nil, err
+	}
+	key.Privileges = toPrivileges(modules)
+	return &key, nil
+}
+
+func (r *ApiKeyAdapter) CreateKey(ctx context.Context, key *ApiKey) (int64, error) {
+	modules, err := toModules(key.Id, key.Privileges)
+	if err != nil {
+		return -1, err
+	}
+	tx := s.GetTx(ctx, r.DB)
+	query := fmt.Sprintf(`insert into api_keys (id, account_id, name, prefix, hash, ips, expires_at, created_by, created_at)
+		select %s, %s, %s, %s, %s, %s, %s, %s, %s where exists (select 1 from service_accounts where id = %s)`,
+		r.BuildParam(1), r.BuildParam(2), r.BuildParam(3), r.BuildParam(4), r.BuildParam(5), r.BuildParam(6), r.BuildParam(7), r.BuildParam(8), r.BuildParam(9), r.BuildParam(10))
+	res, err := tx.ExecContext(ctx, query, key.Id, key.AccountId, key.Name, key.Prefix, key.Hash, r.Array(key.Ips), key.ExpiresAt, key.CreatedBy, key.CreatedAt, key.AccountId)
+	if err != nil {
+		return -1, err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil || rows <= 0 {
+		return rows, err
+	}
+	query = fmt.Sprintf("insert into api_key_modules (key_id, module_id, permissions) values (%s, %s, %s)", r.BuildParam(1), r.BuildParam(2), r.BuildParam(3))
+	for _, m := range modules {
+		if _, err = tx.ExecContext(ctx, query, m.KeyId, m.ModuleId, m.Permissions); err != nil {
+			return -1, err
+		}
+	}
+	return rows, nil
+}
+
+func (r *ApiKeyAdapter) RevokeKey(ctx context.Context, accountId string, id string, revokedBy string, revokedAt time.Time) (int64, error) {
+	query := fmt.Sprintf("update api_keys set revoked_by = %s, revoked_at = %s where id = %s and account_id = %s and revoked_at is null",
+		r.BuildParam(1), r.BuildParam(2), r.BuildParam(3), r.BuildParam(4))
+	res, err := r.DB.ExecContext(ctx, query, revokedBy, revokedAt, id, accountId)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *ApiKeyAdapter) Touch(ctx context.Context, id string, lastUsedAt time.Time) (int64, error) {
+	query := fmt.Sprintf("update api_keys set last_used_at = %s where id = %s", r.BuildParam(1), r.BuildParam(2))
+	res, err := r.DB.ExecContext(ctx, query, lastUsedAt, id)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
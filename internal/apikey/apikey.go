@@ -0,0 +1,130 @@
+package apikey
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// Header carries the API key of a service account, instead of the 'Authorization' header of the users
+	Header = "X-Api-Key"
+	// Key is the context key of the id of the API key; it is also the column of audit_logs written by audit_log.schema.ext
+	Key = "api_key_id"
+	// Permissions is the context key of the permissions of the API key, by module
+	Permissions = "apiKeyPermissions"
+)
+
+type ServiceAccount struct {
+	Id          string     `json:"id,omitempty" gorm:"column:id;primary_key" bson:"_id,omitempty" dynamodbav:"id,omitempty" firestore:"-" validate:"max=40"`
+	Name        string     `json:"name,omitempty" gorm:"column:name" bson:"name,omitempty" dynamodbav:"name,omitempty" firestore:"name,omitempty" validate:"required,max=255"`
+	Description *string    `json:"description,omitempty" gorm:"column:description" bson:"description,omitempty" dynamodbav:"description,omitempty" firestore:"description,omitempty" validate:"omitempty,max=400"`
+	Status      string     `json:"status,omitempty" gorm:"column:status" bson:"status,omitempty" dynamodbav:"status,omitempty" firestore:"status,omitempty" validate:"required,max=1,code"`
+	CreatedBy   *string    `json:"createdBy,omitempty" gorm:"column:created_by" bson:"createdBy,omitempty" dynamodbav:"createdBy,omitempty" firestore:"createdBy,omitempty"`
+	CreatedAt   *time.Time `json:"createdAt,omitempty" gorm:"column:created_at" bson:"createdAt,omitempty" dynamodbav:"createdAt,omitempty" firestore:"createdAt,omitempty"`
+	UpdatedBy   *string    `json:"updatedBy,omitempty" gorm:"column:updated_by" bson:"updatedBy,omitempty" dynamodbav:"updatedBy,omitempty" firestore:"updatedBy,omitempty"`
+	UpdatedAt   *time.Time `json:"updatedAt,omitempty" gorm:"column:updated_at" bson:"updatedAt,omitempty" dynamodbav:"updatedAt,omitempty" firestore:"updatedAt,omitempty"`
+}
+
+// ApiKey is a key of a service account; only the hash of the key is stored, the prefix helps to recognize it
+type ApiKey struct {
+	Id         string     `json:"id,omitempty" gorm:"column:id;primary_key"`
+	AccountId  string     `json:"accountId,omitempty" gorm:"column:account_id"`
+	Name       string     `json:"name,omitempty" gorm:"column:name"`
+	Prefix     string     `json:"prefix,omitempty" gorm:"column:prefix"`
+	Hash       string     `json:"-" gorm:"column:hash"`
+	Ips        []string   `json:"ips,omitempty" gorm:"column:ips"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty" gorm:"column:expires_at"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty" gorm:"column:last_used_at"`
+	CreatedBy  *string    `json:"createdBy,omitempty" gorm:"column:created_by"`
+	CreatedAt  *time.Time `json:"createdAt,omitempty" gorm:"column:created_at"`
+	RevokedBy  *string    `json:"revokedBy,omitempty" gorm:"column:revoked_by"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty" gorm:"column:revoked_at"`
+	Privileges []string   `json:"privileges,omitempty" gorm:"-"`
+}
+
+type keyModule struct {
+	KeyId       string `json:"keyId,omitempty" gorm:"column:key_id"`
+	ModuleId    string `json:"moduleId,omitempty" gorm:"column:module_id"`
+	Permissions int32  `json:"permissions,omitempty" gorm:"column:permissions"`
+}
+
+// KeyRequest has the privileges of the key in the format of the roles, the module and the hex permissions: "article 7"
+type KeyRequest struct {
+	Name       string     `json:"name"`
+	Privileges []string   `json:"privileges"`
+	Ips        []string   `json:"ips"`
+	ExpiresAt  *time.Time `json:"expiresAt"`
+}
+
+// CreatedKey is returned once, when the key is created
+type CreatedKey struct {
+	ApiKey
+	Key string `json:"key"`
+}
+
+func toModules(keyId string, privileges []string) ([]keyModule, error) {
+	modules := make([]keyModule, 0)
+	for _, p := range privileges {
+		s := strings.Fields(p)
+		if len(s) != 2 {
+			return nil, fmt.Errorf("invalid privilege '%s'", p)
+		}
+		permissions, err := strconv.ParseInt(s[1], 16, 32)
+		if err != nil || permissions <= 0 {
+			return nil, fmt.Errorf("invalid privilege '%s'", p)
+		}
+		modules = append(modules, keyModule{KeyId: keyId, ModuleId: s[0], Permissions: int32(permissions)})
+	}
+	return modules, nil
+}
+
+func toPrivileges(modules []keyModule) []string {
+	privileges := make([]string, 0)
+	for _, m := range modules {
+		privileges = append(privileges, fmt.Sprintf("%s %X", m.ModuleId, m.Permissions))
+	}
+	return privileges
+}
+
+// ToPermissions returns the permission bits of the privileges, by module
+func ToPermissions(privileges []string) map[string]int32 {
+	permissions := make(map[string]int32)
+	for _, privilege := range privileges {
+		if modules, err := toModules("", []string{privilege}); err == nil {
+			permissions[modules[0].ModuleId] = modules[0].Permissions
+		}
+	}
+	return permissions
+}
+
+// IsAllowed checks the ip with the allowlist of the key, which has ip addresses or CIDR ranges; an empty allowlist allows all
+func IsAllowed(ips []string, ip string) bool {
+	if len(ips) == 0 {
+		return true
+	}
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return false
+	}
+	for _, allowed := range ips {
+		if strings.Contains(allowed, "/") {
+			if _, network, err := net.ParseCIDR(allowed); err == nil && network.Contains(addr) {
+				return true
+			}
+		} else if a := net.ParseIP(allowed); a != nil && a.Equal(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func isIp(ip string) bool {
+	if strings.Contains(ip, "/") {
+		_, _, err := net.ParseCIDR(ip)
+		return err == nil
+	}
+	return net.ParseIP(ip) != nil
+}
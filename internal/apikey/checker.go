@@ -0,0 +1,100 @@
+package apikey
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/core-go/core"
+)
+
+const resource = "api_key"
+
+func NewApiKeyChecker(service ApiKeyService, check func(http.Handler) http.Handler, userKey string, logError core.Log, writeLog core.WriteLog) *ApiKeyChecker {
+	return &ApiKeyChecker{service: service, check: check, userKey: userKey, logError: logError, writeLog: writeLog}
+}
+
+// ApiKeyChecker authenticates the requests with an API key in the header as the service account of the key;
+// the other requests are checked by the token checker. Every call with an API key is written to the audit log.
+type ApiKeyChecker struct {
+	service  ApiKeyService
+	check    func(http.Handler) http.Handler
+	userKey  string
+	logError core.Log
+	writeLog core.WriteLog
+}
+
+func (c *ApiKeyChecker) Check(next http.Handler) http.Handler {
+	checkToken := c.check(next)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(Header)
+		if len(key) == 0 {
+			checkToken.ServeHTTP(w, r)
+			return
+		}
+		ip := core.GetRemoteIp(r)
+		ctx := context.WithValue(r.Context(), "ip", ip)
+		apiKey, reason, err := c.service.Verify(ctx, key, ip)
+		if err != nil {
+			c.logError(ctx, fmt.Sprintf("Error to verify the API key: %s", err.Error()))
+			http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+			return
+		}
+		if apiKey == nil {
+			c.log(ctx, r, false, "invalid key")
+			http.Error(w, "invalid API key", http.StatusUnauthorized)
+			return
+		}
+		ctx = context.WithValue(ctx, c.userKey, apiKey.AccountId)
+		ctx = context.WithValue(ctx, Key, apiKey.Id)
+		if len(reason) > 0 {
+			c.log(ctx, r, false, reason)
+			http.Error(w, "API key is not valid", http.StatusUnauthorized)
+			return
+		}
+		ctx = context.WithValue(ctx, Permissions, ToPermissions(apiKey.Privileges))
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(sw, r.WithContext(ctx))
+		c.log(ctx, r, sw.status < http.StatusBadRequest, fmt.Sprintf("%d", sw.status))
+	})
+}
+
+func (c *ApiKeyChecker) log(ctx context.Context, r *http.Request, success bool, desc string) {
+	if c.writeLog != nil {
+		c.writeLog(ctx, resource, r.Method, success, fmt.Sprintf("%s %s", r.URL.Path, desc))
+	}
+}
+
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Flush keeps the streaming of the exports, which flush the rows while they are written
+func (w *statusWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func NewPrivilegeLoader(privilege func(context.Context, string, string) int32) *PrivilegeLoader {
+	return &PrivilegeLoader{privilege: privilege}
+}
+
+// PrivilegeLoader returns the permissions of the API key of the request on the module, so that the keys are limited
+// to their own modules and permissions whatever the service account is; the permissions of the users are loaded as before
+type PrivilegeLoader struct {
+	privilege func(context.Context, string, string) int32
+}
+
+func (l *PrivilegeLoader) Privilege(ctx context.Context, userId string, privilegeId string) int32 {
+	if permissions, ok := ctx.Value(Permissions).(map[string]int32); ok {
+		return permissions[privilegeId]
+	}
+	return l.privilege(ctx, userId, privilegeId)
+}
@@ -0,0 +1,210 @@
+package apikey
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/core-go/core"
+)
+
+// NewApiKeyHandler loads the permissions of the caller with privilege, so that a key never has more than its creator
+func NewApiKeyHandler(service ApiKeyService, validate core.Validate[*ServiceAccount], privilege func(context.Context, string, string) int32, userKey string, logError core.Log, writeLog core.WriteLog, action *core.ActionConfig) *ApiKeyHandler {
+	attributes := core.CreateAttributes(reflect.TypeOf(ServiceAccount{}), logError, writeLog, action)
+	return &ApiKeyHandler{service: service, validate: validate, privilege: privilege, userKey: userKey, Attributes: attributes}
+}
+
+type ApiKeyHandler struct {
+	service   ApiKeyService
+	validate  core.Validate[*ServiceAccount]
+	privilege func(context.Context, string, string) int32
+	userKey   string
+	*core.Attributes
+}
+
+func (h *ApiKeyHandler) All(w http.ResponseWriter, r *http.Request) {
+	accounts, err := h.service.All(r.Context())
+	if err != nil {
+		h.Error(r.Context(), fmt.Sprintf("Error to get service accounts: %s", err.Error()))
+		http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+		return
+	}
+	core.JSON(w, http.StatusOK, accounts)
+}
+
+func (h *ApiKeyHandler) Load(w http.ResponseWriter, r *http.Request) {
+	id, err := core.GetRequiredString(w, r)
+	if err == nil {
+		account, err := h.service.Load(r.Context(), id)
+		if err != nil {
+			h.Error(r.Context(), fmt.Sprintf("Error to get service account '%s': %s", id, err.Error()))
+			http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+			return
+		}
+		core.JSON(w, core.IsFound(account), account)
+	}
+}
+
+func (h *ApiKeyHandler) Create(w http.ResponseWriter, r *http.Request) {
+	account, er1 := core.Decode[ServiceAccount](w, r)
+	if er1 == nil {
+		account.Id = strings.TrimSpace(account.Id)
+		errors, er2 := h.validate(r.Context(), &account)
+		if !core.HasError(w, r, errors, er2, h.Error, &account, h.Log, h.Resource, h.Action.Create) {
+			res, er3 := h.service.Create(r.Context(), &account)
+			if er3 != nil {
+				h.Error(r.Context(), er3.Error())
+				h.Log(r.Context(), h.Resource, h.Action.Create, false, er3.Error())
+				http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+				return
+			}
+			if res > 0 {
+				h.Log(r.Context(), h.Resource, h.Action.Create, true, fmt.Sprintf("create '%s'", account.Id))
+				core.JSON(w, http.StatusCreated, account)
+			} else {
+				h.Log(r.Context(), h.Resource, h.Action.Create, false, fmt.Sprintf("conflict '%s'", account.Id))
+				core.JSON(w, http.StatusConflict, res)
+			}
+		}
+	}
+}
+
+func (h *ApiKeyHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, er1 := core.GetRequiredString(w, r)
+	if er1 == nil {
+		account, er2 := core.Decode[ServiceAccount](w, r)
+		if er2 == nil {
+			account.Id = id
+			errors, er3 := h.validate(r.Context(), &account)
+			if !core.HasError(w, r, errors, er3, h.Error, &account, h.Log, h.Resource, h.Action.Update) {
+				res, err := h.service.Update(r.Context(), &account)
+				h.respond(w, r, h.Action.Update, fmt.Sprintf("%s '%s'", h.Action.Update, id), id, res, err)
+			}
+		}
+	}
+}
+
+func (h *ApiKeyHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := core.GetRequiredString(w, r)
+	if err == nil {
+		res, err := h.service.Delete(r.Context(), id)
+		h.respond(w, r, h.Action.Delete, fmt.Sprintf("%s '%s'", h.Action.Delete, id), id, res, err)
+	}
+}
+
+func (h *ApiKeyHandler) LoadKeys(w http.ResponseWriter, r *http.Request) {
+	accountId, err := core.GetRequiredString(w, r, 1)
+	if err == nil {
+		keys, err := h.service.LoadKeys(r.Context(), accountId)
+		if err != nil {
+			h.Error(r.Context(), fmt.Sprintf("Error to get the keys of '%s': %s", accountId, err.Error()))
+			http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+			return
+		}
+		core.JSON(w, http.StatusOK, keys)
+	}
+}
+
+func (h *ApiKeyHandler) CreateKey(w http.ResponseWriter, r *http.Request) {
+	accountId, er1 := core.GetRequiredString(w, r, 1)
+	if er1 == nil {
+		req, er2 := core.Decode[KeyRequest](w, r)
+		if er2 == nil {
+			req.Name = strings.TrimSpace(req.Name)
+			errors := validateKey(req)
+			if len(errors) > 0 {
+				core.JSON(w, http.StatusUnprocessableEntity, errors)
+				return
+			}
+			if privilege := h.exceed(r.Context(), req.Privileges); len(privilege) > 0 {
+				h.Log(r.Context(), h.Resource, "create_key", false, fmt.Sprintf("privilege '%s' is not granted to the caller", privilege))
+				core.JSON(w, http.StatusForbidden, []core.ErrorMessage{{Field: "privileges", Code: "forbidden", Param: privilege}})
+				return
+			}
+			key, err := h.service.CreateKey(r.Context(), accountId, req)
+			if err != nil {
+				h.Error(r.Context(), fmt.Sprintf("Error to create a key for '%s': %s", accountId, err.Error()))
+				h.Log(r.Context(), h.Resource, "create_key", false, err.Error())
+				http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+				return
+			}
+			if key == nil {
+				h.Log(r.Context(), h.Resource, "create_key", false, fmt.Sprintf("not found '%s'", accountId))
+				core.JSON(w, http.StatusNotFound, 0)
+				return
+			}
+			h.Log(r.Context(), h.Resource, "create_key", true, fmt.Sprintf("create key '%s' for '%s'", key.Id, accountId))
+			core.JSON(w, http.StatusCreated, key)
+		}
+	}
+}
+
+func (h *ApiKeyHandler) RevokeKey(w http.ResponseWriter, r *http.Request) {
+	id, err := core.GetRequiredString(w, r)
+	if err == nil {
+		accountId, err := core.GetRequiredString(w, r, 2)
+		if err == nil {
+			res, err := h.service.RevokeKey(r.Context(), accountId, id)
+			h.respond(w, r, "revoke_key", fmt.Sprintf("revoke key '%s' of '%s'", id, accountId), id, res, err)
+		}
+	}
+}
+
+// exceed returns the first privilege with a permission which the caller does not have
+func (h *ApiKeyHandler) exceed(ctx context.Context, privileges []string) string {
+	userId, _ := ctx.Value(h.userKey).(string)
+	for _, privilege := range privileges {
+		modules, err := toModules("", []string{privilege})
+		if err != nil || len(userId) == 0 || modules[0].Permissions&^h.privilege(ctx, userId, modules[0].ModuleId) != 0 {
+			return privilege
+		}
+	}
+	return ""
+}
+
+func validateKey(req KeyRequest) []core.ErrorMessage {
+	var errors []core.ErrorMessage
+	if len(req.Name) == 0 {
+		errors = append(errors, core.ErrorMessage{Field: "name", Code: "required"})
+	} else if len(req.Name) > 255 {
+		errors = append(errors, core.ErrorMessage{Field: "name", Code: "maxlength", Param: "255"})
+	}
+	if len(req.Privileges) == 0 {
+		errors = append(errors, core.ErrorMessage{Field: "privileges", Code: "required"})
+	}
+	for _, privilege := range req.Privileges {
+		if _, err := toModules("", []string{privilege}); err != nil {
+			errors = append(errors, core.ErrorMessage{Field: "privileges", Code: "invalid", Param: privilege})
+			break
+		}
+	}
+	for _, ip := range req.Ips {
+		if !isIp(ip) {
+			errors = append(errors, core.ErrorMessage{Field: "ips", Code: "invalid", Param: ip})
+			break
+		}
+	}
+	if req.ExpiresAt != nil && req.ExpiresAt.Before(time.Now()) {
+		errors = append(errors, core.ErrorMessage{Field: "expiresAt", Code: "min"})
+	}
+	return errors
+}
+
+func (h *ApiKeyHandler) respond(w http.ResponseWriter, r *http.Request, action string, success string, id string, res int64, err error) {
+	if err != nil {
+		h.Error(r.Context(), err.Error())
+		h.Log(r.Context(), h.Resource, action, false, err.Error())
+		http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+		return
+	}
+	if res > 0 {
+		h.Log(r.Context(), h.Resource, action, true, success)
+		core.JSON(w, http.StatusOK, res)
+	} else {
+		h.Log(r.Context(), h.Resource, action, false, fmt.Sprintf("not found '%s'", id))
+		core.JSON(w, http.StatusNotFound, res)
+	}
+}
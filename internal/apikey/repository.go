@@ -0,0 +1,19 @@
+package apikey
+
+import (
+	"context"
+	"time"
+)
+
+type ApiKeyRepository interface {
+	All(ctx context.Context) ([]ServiceAccount, error)
+	Load(ctx context.Context, id string) (*ServiceAccount, error)
+	Create(ctx context.Context, account *ServiceAccount) (int64, error)
+	Update(ctx context.Context, account *ServiceAccount) (int64, error)
+	Delete(ctx context.Context, id string) (int64, error)
+	LoadKeys(ctx context.Context, accountId string) ([]ApiKey, error)
+	LoadKey(ctx context.Context, hash string) (*ApiKey, error)
+	CreateKey(ctx context.Context, key *ApiKey) (int64, error)
+	RevokeKey(ctx context.Context, accountId string, id string, revokedBy string, revokedAt time.Time) (int64, error)
+	Touch(ctx context.Context, id string, lastUsedAt time.Time) (int64, error)
+}
@@ -0,0 +1,141 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/core-go/core/tx"
+)
+
+type ApiKeyService interface {
+	All(ctx context.Context) ([]ServiceAccount, error)
+	Load(ctx context.Context, id string) (*ServiceAccount, error)
+	Create(ctx context.Context, account *ServiceAccount) (int64, error)
+	Update(ctx context.Context, account *ServiceAccount) (int64, error)
+	Delete(ctx context.Context, id string) (int64, error)
+	LoadKeys(ctx context.Context, accountId string) ([]ApiKey, error)
+	CreateKey(ctx context.Context, accountId string, req KeyRequest) (*CreatedKey, error)
+	RevokeKey(ctx context.Context, accountId string, id string) (int64, error)
+	Verify(ctx context.Context, key string, ip string) (*ApiKey, string, error)
+}
+
+func NewApiKeyService(db *sql.DB, repository ApiKeyRepository, generateId func(context.Context) (string, error), userKey string) *ApiKeyUseCase {
+	return &ApiKeyUseCase{db: db, repository: repository, generateId: generateId, userKey: userKey}
+}
+
+type ApiKeyUseCase struct {
+	db         *sql.DB
+	repository ApiKeyRepository
+	generateId func(context.Context) (string, error)
+	userKey    string
+}
+
+func (s *ApiKeyUseCase) All(ctx context.Context) ([]ServiceAccount, error) {
+	return s.repository.All(ctx)
+}
+
+func (s *ApiKeyUseCase) Load(ctx context.Context, id string) (*ServiceAccount, error) {
+	return s.repository.Load(ctx, id)
+}
+
+func (s *ApiKeyUseCase) Create(ctx context.Context, account *ServiceAccount) (int64, error) {
+	if len(account.Id) == 0 {
+		id, err := s.generateId(ctx)
+		if err != nil {
+			return -1, err
+		}
+		account.Id = id
+	}
+	now := time.Now()
+	account.CreatedAt = &now
+	account.UpdatedAt = &now
+	if userId, ok := ctx.Value(s.userKey).(string); ok && len(userId) > 0 {
+		account.CreatedBy = &userId
+		account.UpdatedBy = &userId
+	}
+	return s.repository.Create(ctx, account)
+}
+
+func (s *ApiKeyUseCase) Update(ctx context.Context, account *ServiceAccount) (int64, error) {
+	now := time.Now()
+	account.UpdatedAt = &now
+	if userId, ok := ctx.Value(s.userKey).(string); ok && len(userId) > 0 {
+		account.UpdatedBy = &userId
+	}
+	return s.repository.Update(ctx, account)
+}
+
+func (s *ApiKeyUseCase) Delete(ctx context.Context, id string) (int64, error) {
+	return tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
+		return s.repository.Delete(ctx, id)
+	})
+}
+
+func (s *ApiKeyUseCase) LoadKeys(ctx context.Context, accountId string) ([]ApiKey, error) {
+	return s.repository.LoadKeys(ctx, accountId)
+}
+
+// CreateKey generates a key for the service account, only its hash is stored so the key is returned once; nil if the account is not found
+func (s *ApiKeyUseCase) CreateKey(ctx context.Context, accountId string, req KeyRequest) (*CreatedKey, error) {
+	id, err := s.generateId(ctx)
+	if err != nil {
+		return nil, err
+	}
+	b := make([]byte, 32)
+	if _, err = rand.Read(b); err != nil {
+		return nil, err
+	}
+	key := base64.RawURLEncoding.EncodeToString(b)
+	now := time.Now()
+	apiKey := ApiKey{Id: id, AccountId: accountId, Name: req.Name, Prefix: key[:8], Hash: Hash(key), Ips: req.Ips, ExpiresAt: req.ExpiresAt, CreatedAt: &now, Privileges: req.Privileges}
+	if userId, ok := ctx.Value(s.userKey).(string); ok && len(userId) > 0 {
+		apiKey.CreatedBy = &userId
+	}
+	res, err := tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
+		return s.repository.CreateKey(ctx, &apiKey)
+	})
+	if err != nil || res <= 0 {
+		return nil, err
+	}
+	return &CreatedKey{ApiKey: apiKey, Key: key}, nil
+}
+
+func (s *ApiKeyUseCase) RevokeKey(ctx context.Context, accountId string, id string) (int64, error) {
+	revokedBy, _ := ctx.Value(s.userKey).(string)
+	return s.repository.RevokeKey(ctx, accountId, id, revokedBy, time.Now())
+}
+
+// Verify loads the key of the request; the reason is not empty if the key is revoked, expired or not allowed from the ip.
+// The last used time is updated at most once a minute.
+func (s *ApiKeyUseCase) Verify(ctx context.Context, key string, ip string) (*ApiKey, string, error) {
+	apiKey, err := s.repository.LoadKey(ctx, Hash(key))
+	if err != nil || apiKey == nil {
+		return nil, "", err
+	}
+	now := time.Now()
+	if apiKey.RevokedAt != nil {
+		return apiKey, "revoked", nil
+	}
+	if apiKey.ExpiresAt != nil && apiKey.ExpiresAt.Before(now) {
+		return apiKey, "expired", nil
+	}
+	if !IsAllowed(apiKey.Ips, ip) {
+		return apiKey, "ip not allowed", nil
+	}
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) > time.Minute {
+		if _, err = s.repository.Touch(ctx, apiKey.Id, now); err != nil {
+			return nil, "", err
+		}
+	}
+	return apiKey, "", nil
+}
+
+func Hash(key string) string {
+	h := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,36 @@
+package apikey
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/core-go/core"
+	v "github.com/core-go/core/validator"
+	"github.com/lib/pq"
+)
+
+type ApiKeyTransport interface {
+	All(w http.ResponseWriter, r *http.Request)
+	Load(w http.ResponseWriter, r *http.Request)
+	Create(w http.ResponseWriter, r *http.Request)
+	Update(w http.ResponseWriter, r *http.Request)
+	Delete(w http.ResponseWriter, r *http.Request)
+	LoadKeys(w http.ResponseWriter, r *http.Request)
+	CreateKey(w http.ResponseWriter, r *http.Request)
+	RevokeKey(w http.ResponseWriter, r *http.Request)
+}
+
+func NewApiKeyTransport(db *sql.DB, generateId func(context.Context) (string, error), privilege func(context.Context, string, string) int32, userKey string, logError core.Log, writeLog core.WriteLog, action *core.ActionConfig) (ApiKeyTransport, ApiKeyService, error) {
+	validator, err := v.NewValidator[*ServiceAccount]()
+	if err != nil {
+		return nil, nil, err
+	}
+	apiKeyRepository, err := NewApiKeyAdapter(db, pq.Array)
+	if err != nil {
+		return nil, nil, err
+	}
+	apiKeyService := NewApiKeyService(db, apiKeyRepository, generateId, userKey)
+	apiKeyHandler := NewApiKeyHandler(apiKeyService, validator.Validate, privilege, userKey, logError, writeLog, action)
+	return apiKeyHandler, apiKeyService, nil
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/core-go/core/shortid"
 	ur "github.com/core-go/core/user"
 	log "github.com/core-go/log/zap"
+	"github.com/core-go/search"
 	sec "github.com/core-go/security"
 	"github.com/core-go/security/jwt"
 	ss "github.com/core-go/security/sql"
@@ -23,16 +24,34 @@ import (
 	sa "github.com/core-go/sql/action"
 	"github.com/core-go/sql/template"
 	"github.com/core-go/sql/template/xml"
+	"github.com/lib/pq"
 
+	acc "go-service/internal/account"
+	"go-service/internal/apikey"
+	ap "go-service/internal/application"
 	a "go-service/internal/article"
 	"go-service/internal/audit-log"
 	ca "go-service/internal/category"
+	cm "go-service/internal/company"
 	c "go-service/internal/contact"
 	co "go-service/internal/content"
 	j "go-service/internal/job"
+	"go-service/internal/ldap"
+	me "go-service/internal/media"
+	pw "go-service/internal/password"
+	pub "go-service/internal/public"
 	r "go-service/internal/role"
+	"go-service/internal/scheduler"
+	fts "go-service/internal/search"
+	"go-service/internal/session"
+	tg "go-service/internal/tag"
+	tk "go-service/internal/token"
+	tf "go-service/internal/twofactor"
 	u "go-service/internal/user"
+	"go-service/pkg/export"
 	p "go-service/pkg/privilege"
+	"go-service/pkg/sanitizer"
+	"go-service/pkg/sender"
 )
 
 type ApplicationContext struct {
@@ -40,21 +59,33 @@ type ApplicationContext struct {
 	Health               *health.Handler
 	Authorization        *authorization.Handler
 	AuthorizationChecker *sec.AuthorizationChecker
+	ApiKeyChecker        *apikey.ApiKeyChecker
 	Authorizer           *sec.Authorizer
-	Authentication       *ah.AuthenticationHandler
+	Token                tk.TokenTransport
+	TwoFactor            tf.TwoFactorTransport
+	Session              session.SessionTransport
+	ApiKey               apikey.ApiKeyTransport
 	Privileges           *ah.PrivilegesHandler
 	Privilege            *p.PrivilegesHandler
 	Code                 *code.Handler
 	Roles                *code.Handler
 	Role                 r.RoleTransport
 	User                 u.UserTransport
+	Password             pw.PasswordTransport
+	Account              acc.AccountTransport
 	AuditLog             *audit.AuditLogHandler
 	Settings             *se.Handler
 	Category             ca.CategoryTransport
 	Content              co.ContentTransport
 	Article              a.ArticleTransport
 	Job                  j.JobTransport
+	Application          ap.ApplicationTransport
 	Contact              c.ContactTransport
+	Company              cm.CompanyTransport
+	Public               pub.PublicTransport
+	Media                me.MediaTransport
+	Tag                  tg.TagTransport
+	Search               fts.SearchTransport
 }
 
 func NewApp(ctx context.Context, cfg Config) (*ApplicationContext, error) {
@@ -87,8 +118,12 @@ func NewApp(ctx context.Context, cfg Config) (*ApplicationContext, error) {
 	userId := cfg.Tracking.User
 	tokenPort := jwt.NewTokenAdapter()
 	authorizationHandler := authorization.NewHandler(tokenPort.GetAndVerifyToken, cfg.Auth.Token.Secret)
-	authorizationChecker := sec.NewAuthorizationChecker(tokenPort.GetAndVerifyToken, cfg.Auth.Token.Secret, userId)
-	authorizer := sec.NewAuthorizer(sqlPrivilegeLoader.Privilege, true, userId)
+	accountHandler, tokenChecker, er2 := acc.NewAccountTransport(db, cfg.Auth.UserStatus, userId, logError, writeLog, cfg.Action)
+	if er2 != nil {
+		return nil, er2
+	}
+	apiKeyPrivilegeLoader := apikey.NewPrivilegeLoader(sqlPrivilegeLoader.Privilege)
+	authorizer := sec.NewAuthorizer(apiKeyPrivilegeLoader.Privilege, true, userId)
 
 	authStatus := auth.InitStatus(cfg.Auth.Status)
 	userPort, er3 := as.NewUserAdapter(db, cfg.Auth.Query, cfg.Auth.DB, cfg.Auth.UserStatus)
@@ -100,8 +135,41 @@ func NewApp(ctx context.Context, cfg Config) (*ApplicationContext, error) {
 	if er4 != nil {
 		return nil, er4
 	}
-	authenticator := auth.NewAuthenticator(authStatus, userPort, bcryptComparator, tokenPort.GenerateToken, cfg.Auth.Token, cfg.Auth.Payload, privilegePort.Load)
-	authenticationHandler := ah.NewAuthenticationHandler(authenticator.Authenticate, authStatus.Error, authStatus.Timeout, logError, writeLog)
+	userRepository := acc.NewUserRepository(userPort, cfg.Auth.UserStatus)
+	authenticator := auth.NewAuthenticator(authStatus, userRepository, bcryptComparator, tokenPort.GenerateToken, cfg.Auth.Token, cfg.Auth.Payload, privilegePort.Load)
+	authenticate := authenticator.Authenticate
+	if len(cfg.Ldap.Server) > 0 {
+		ldapAuthenticator := ldap.NewLDAPAuthenticator(db, cfg.Ldap, authStatus, cfg.Auth.UserStatus, userRepository, tokenPort.GenerateToken, cfg.Auth.Token, cfg.Auth.Payload, privilegePort.Load, authenticator.Authenticate, generateId, logError)
+		authenticate = ldapAuthenticator.Authenticate
+	}
+	twoFactorHandler, twoFactorService, err := tf.NewTwoFactorTransport(db, cfg.TwoFactor, userId, logError, writeLog, cfg.Action)
+	if err != nil {
+		return nil, err
+	}
+	twoFactorAuthenticator := tf.NewAuthenticator(authenticate, twoFactorService, authStatus)
+	sessionHandler, sessionService, err := session.NewSessionTransport(db, generateId, userId, logError, writeLog, cfg.Action)
+	if err != nil {
+		return nil, err
+	}
+	tokenHandler, tokenService, er6 := tk.NewTokenTransport(db, twoFactorAuthenticator.Authenticate, sessionService, generateId, tokenPort.GenerateToken, cfg.Auth, cfg.Token, userId, logError, writeLog)
+	if er6 != nil {
+		return nil, er6
+	}
+	revocationChecker := tk.NewRevocationChecker(tokenService, logError)
+	sessionChecker := session.NewSessionChecker(tokenPort.VerifyToken, cfg.Auth.Token.Secret, sessionService, logError)
+	authorizationChecker := sec.NewAuthorizationCheckerWithBlacklist(tokenPort.GetAndVerifyToken, cfg.Auth.Token.Secret, tk.Chain(tokenChecker.Check, revocationChecker.Check, sessionChecker.Check), userId)
+	apiKeyHandler, apiKeyService, err := apikey.NewApiKeyTransport(db, generateId, apiKeyPrivilegeLoader.Privilege, userId, logError, writeLog, cfg.Action)
+	if err != nil {
+		return nil, err
+	}
+	apiKeyChecker := apikey.NewApiKeyChecker(apiKeyService, authorizationChecker.Check, userId, logError, writeLog)
+	var revokeOnPasswordChange, revokeOnRoleChange func(context.Context, string) (int64, error)
+	if cfg.Token.RevokeOnPasswordChange {
+		revokeOnPasswordChange = tokenService.RevokeAll
+	}
+	if cfg.Token.RevokeOnRoleChange {
+		revokeOnRoleChange = tokenService.RevokeAll
+	}
 
 	privilegeReader, er5 := as.NewPrivilegesReader(db, cfg.Sql.Privileges)
 	if er5 != nil {
@@ -129,12 +197,18 @@ func NewApp(ctx context.Context, cfg Config) (*ApplicationContext, error) {
 	}
 	rolesHandler := code.NewCodeHandlerByConfig(rolesLoader.Load, cfg.Role.Handler, logError)
 
-	roleHandler, err := r.NewRoleTransport(db, logError, templates, cfg.Tracking, writeLog, cfg.Action)
+	roleHandler, err := r.NewRoleTransport(db, logError, templates, cfg.Tracking, revokeOnRoleChange, writeLog, cfg.Action)
 	if err != nil {
 		return nil, err
 	}
 
-	userHandler, err := u.NewUserTransport(db, logError, templates, cfg.Tracking, writeLog, cfg.Action)
+	userHandler, err := u.NewUserTransport(db, logError, templates, cfg.Tracking, revokeOnRoleChange, writeLog, cfg.Action)
+	if err != nil {
+		return nil, err
+	}
+
+	send := sender.NewSender(cfg.Password.Sender, log.LogInfo)
+	passwordHandler, err := pw.NewPasswordTransport(db, bcryptComparator, send, cfg.Password, cfg.Auth.UserStatus, revokeOnPasswordChange, logError, writeLog, cfg.Action)
 	if err != nil {
 		return nil, err
 	}
@@ -144,25 +218,67 @@ func NewApp(ctx context.Context, cfg Config) (*ApplicationContext, error) {
 		return nil, err
 	}
 
-	contentHandler, err := co.NewContentTransport(db, logError, writeLog, cfg.Action)
+	tagRepository, err := tg.NewTagAdapter(db, pq.Array)
+	if err != nil {
+		return nil, err
+	}
+	var checkTags tg.Check
+	if cfg.Tag.Restrict {
+		checkTags = tagRepository.Unregistered
+	}
+	tagHandler, err := tg.NewTagTransport(db, tagRepository, logError, cfg.Tracking, writeLog, cfg.Action)
+	if err != nil {
+		return nil, err
+	}
+
+	htmlSanitizer := sanitizer.NewSanitizer(cfg.Sanitizer)
+	contentHandler, err := co.NewContentTransport(db, logError, cfg.Tracking, cfg.Content, htmlSanitizer, checkTags, writeLog, cfg.Action)
+	if err != nil {
+		return nil, err
+	}
+
+	articleHandler, err := a.NewArticleTransport(db, logError, htmlSanitizer, checkTags, writeLog, cfg.Action)
+	if err != nil {
+		return nil, err
+	}
+	jobHandler, err := j.NewJobTransport(db, logError, htmlSanitizer, writeLog, cfg.Action)
+	if err != nil {
+		return nil, err
+	}
+
+	resumeStorage := me.NewLocalStorage(cfg.Application.Directory, "")
+	applicationHandler, err := ap.NewApplicationTransport(db, logError, resumeStorage, cfg.Application, cfg.Tracking, generateId, writeLog, cfg.Action)
 	if err != nil {
 		return nil, err
 	}
 
-	articleHandler, err := a.NewArticleTransport(db, logError, writeLog, cfg.Action)
+	contactHandler, err := c.NewContactTransport(db, logError, cfg.Contact, cfg.Tracking, generateId, writeLog, cfg.Action)
 	if err != nil {
 		return nil, err
 	}
-	jobHandler, err := j.NewJobTransport(db, logError, writeLog, cfg.Action)
+	companyHandler, err := cm.NewCompanyTransport(db, logError, writeLog, cfg.Action)
 	if err != nil {
 		return nil, err
 	}
 
-	contactHandler, err := c.NewContactTransport(db, logError, writeLog, cfg.Action)
+	mediaStorage := me.NewLocalStorage(cfg.Media.Directory, cfg.Media.Url)
+	mediaHandler, err := me.NewMediaTransport(db, logError, mediaStorage, cfg.Media, cfg.Tracking, generateId, writeLog, cfg.Action)
 	if err != nil {
 		return nil, err
 	}
 
+	resolveSlug, err := a.NewSlugResolver(db)
+	if err != nil {
+		return nil, err
+	}
+	publicHandler := pub.NewPublicTransport(db, cfg.Public, resolveSlug, logError)
+
+	var privilege func(ctx context.Context, userId string, privilegeId string) int32
+	if !cfg.SecuritySkip {
+		privilege = sqlPrivilegeLoader.Privilege
+	}
+	searchHandler := fts.NewSearchTransport(db, privilege, userId, logError)
+
 	reportDB, er8 := sql.Open(cfg.AuditLog.DB.Driver, cfg.AuditLog.DB.DataSourceName)
 	if er8 != nil {
 		return nil, er8
@@ -173,7 +289,13 @@ func NewApp(ctx context.Context, cfg Config) (*ApplicationContext, error) {
 	if er9 != nil {
 		return nil, er9
 	}
-	auditLogHandler := audit.NewAuditLogHandler(auditLogQuery, logError)
+	auditLogExporter := export.NewExporter[audit.AuditLog, *audit.AuditLogFilter](reportDB, "audit_log", func() *audit.AuditLogFilter {
+		return &audit.AuditLogFilter{Filter: &search.Filter{}}
+	}, auditLogQuery.BuildQuery, logError, writeLog)
+	auditLogHandler := audit.NewAuditLogHandler(auditLogQuery, auditLogExporter, logError)
+
+	publishScheduler := scheduler.NewScheduler(db, cfg.Scheduler, scheduler.NewPublishTasks(), cfg.AuditLog.Config.User, logError, writeLog)
+	publishScheduler.Start(ctx)
 
 	settingsHandler := se.NewSettingsHandler(logError, writeLog, db, "users", buildParam, "userId", "user_id", "dateformat", "language")
 
@@ -182,21 +304,33 @@ func NewApp(ctx context.Context, cfg Config) (*ApplicationContext, error) {
 		SkipSecurity:         cfg.SecuritySkip,
 		Authorization:        authorizationHandler,
 		AuthorizationChecker: authorizationChecker,
+		ApiKeyChecker:        apiKeyChecker,
 		Authorizer:           authorizer,
-		Authentication:       authenticationHandler,
+		Token:                tokenHandler,
+		TwoFactor:            twoFactorHandler,
+		Session:              sessionHandler,
+		ApiKey:               apiKeyHandler,
 		Privileges:           privilegesHandler,
 		Privilege:            privilegeHandler,
 		Code:                 codeHandler,
 		Roles:                rolesHandler,
 		Role:                 roleHandler,
 		User:                 userHandler,
+		Password:             passwordHandler,
+		Account:              accountHandler,
 		AuditLog:             auditLogHandler,
 		Settings:             settingsHandler,
 		Category:             categoryHandler,
 		Content:              contentHandler,
 		Article:              articleHandler,
 		Job:                  jobHandler,
+		Application:          applicationHandler,
 		Contact:              contactHandler,
+		Company:              companyHandler,
+		Public:               publicHandler,
+		Media:                mediaHandler,
+		Tag:                  tagHandler,
+		Search:               searchHandler,
 	}
 	return app, nil
 }
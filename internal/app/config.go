@@ -1,6 +1,7 @@
 package app
 
 import (
+	"github.com/core-go/authentication/ldap"
 	q "github.com/core-go/authentication/sql"
 	"github.com/core-go/core"
 	"github.com/core-go/core/audit"
@@ -11,6 +12,18 @@ import (
 	mid "github.com/core-go/log/middleware"
 	"github.com/core-go/log/zap"
 	sa "github.com/core-go/sql/action"
+
+	ap "go-service/internal/application"
+	c "go-service/internal/contact"
+	co "go-service/internal/content"
+	me "go-service/internal/media"
+	pw "go-service/internal/password"
+	pub "go-service/internal/public"
+	"go-service/internal/scheduler"
+	tg "go-service/internal/tag"
+	tk "go-service/internal/token"
+	tf "go-service/internal/twofactor"
+	"go-service/pkg/sanitizer"
 )
 
 type Config struct {
@@ -18,6 +31,7 @@ type Config struct {
 	Allow        cors.AllowConfig       `mapstructure:"allow"`
 	SecuritySkip bool                   `mapstructure:"security_skip"`
 	Template     bool                   `mapstructure:"template"`
+	Ldap         ldap.LDAPConfig        `mapstructure:"ldap"`
 	Auth         q.SqlAuthConfig        `mapstructure:"auth"`
 	DB           DBConfig               `mapstructure:"db"`
 	Log          log.Config             `mapstructure:"log"`
@@ -31,6 +45,17 @@ type Config struct {
 	Action       *core.ActionConfig     `mapstructure:"action"`
 	Tracking     builder.TrackingConfig `mapstructure:"tracking"`
 	Sql          SqlStatement           `mapstructure:"sql"`
+	Scheduler    scheduler.Config       `mapstructure:"scheduler"`
+	Content      co.LanguageConfig      `mapstructure:"content"`
+	Public       pub.Config             `mapstructure:"public"`
+	Sanitizer    sanitizer.Config       `mapstructure:"sanitizer"`
+	Media        me.Config              `mapstructure:"media"`
+	Tag          tg.Config              `mapstructure:"tag"`
+	Application  ap.Config              `mapstructure:"application"`
+	Contact      c.Config               `mapstructure:"contact"`
+	Password     pw.Config              `mapstructure:"password"`
+	Token        tk.Config              `mapstructure:"token"`
+	TwoFactor    tf.Config              `mapstructure:"two_factor"`
 }
 type DBConfig struct {
 	DataSourceName string `yaml:"data_source_name" mapstructure:"data_source_name" json:"dataSourceName,omitempty" gorm:"column:datasourcename" bson:"dataSourceName,omitempty" dynamodbav:"dataSourceName,omitempty" firestore:"dataSourceName,omitempty"`
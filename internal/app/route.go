@@ -3,22 +3,31 @@ package app
 import (
 	"context"
 	"net/http"
+	"strings"
 
 	c "github.com/core-go/core/constants"
 	m "github.com/core-go/core/mux"
 	s "github.com/core-go/core/security"
 	"github.com/gorilla/mux"
+
+	me "go-service/internal/media"
+	"go-service/pkg/export"
 )
 
 const (
-	role      = "role"
-	user      = "user"
-	audit_log = "audit_log"
-	category  = "category"
-	content   = "content"
-	article   = "article"
-	job       = "job"
-	contact   = "contact"
+	role            = "role"
+	user            = "user"
+	audit_log       = "audit_log"
+	category        = "category"
+	content         = "content"
+	article         = "article"
+	job             = "job"
+	contact         = "contact"
+	company         = "company"
+	media           = "media"
+	tag             = "tag"
+	applicant       = "application"
+	service_account = "service_account"
 )
 
 func Route(r *mux.Router, ctx context.Context, conf Config) error {
@@ -27,19 +36,44 @@ func Route(r *mux.Router, ctx context.Context, conf Config) error {
 		return err
 	}
 	r.Use(app.Authorization.HandleAuthorization)
-	sec := &s.SecurityConfig{SecuritySkip: conf.SecuritySkip, Check: app.AuthorizationChecker.Check, Authorize: app.Authorizer.Authorize}
+	sec := &s.SecurityConfig{SecuritySkip: conf.SecuritySkip, Check: app.ApiKeyChecker.Check, Authorize: app.Authorizer.Authorize}
 
 	Handle(r, "/health", app.Health.Check, c.GET)
-	Handle(r, "/authenticate", app.Authentication.Authenticate, c.POST)
+	Handle(r, "/authenticate", app.Token.Authenticate, c.POST)
+	Handle(r, "/refresh", app.Token.Refresh, c.POST)
+	Handle(r, "/password/change", app.Password.Change, c.POST)
+	Handle(r, "/password/forgot", app.Password.Forgot, c.POST)
+	Handle(r, "/password/reset", app.Password.Reset, c.POST)
 
 	r.Handle("/code/{code}", app.AuthorizationChecker.Check(http.HandlerFunc(app.Code.Load))).Methods(c.GET)
+	r.Handle("/logout", app.AuthorizationChecker.Check(http.HandlerFunc(app.Token.Logout))).Methods(c.POST)
+	r.Handle("/logout-all", app.AuthorizationChecker.Check(http.HandlerFunc(app.Token.LogoutAll))).Methods(c.POST)
+	r.Handle("/my-2fa", app.AuthorizationChecker.Check(http.HandlerFunc(app.TwoFactor.Load))).Methods(c.GET)
+	r.Handle("/my-2fa/enrol", app.AuthorizationChecker.Check(http.HandlerFunc(app.TwoFactor.Enrol))).Methods(c.POST)
+	r.Handle("/my-2fa/verify", app.AuthorizationChecker.Check(http.HandlerFunc(app.TwoFactor.Verify))).Methods(c.POST)
+	r.Handle("/my-2fa/recovery-codes", app.AuthorizationChecker.Check(http.HandlerFunc(app.TwoFactor.Regenerate))).Methods(c.POST)
+	r.Handle("/my-2fa/disable", app.AuthorizationChecker.Check(http.HandlerFunc(app.TwoFactor.Disable))).Methods(c.POST)
+	r.Handle("/my-sessions", app.AuthorizationChecker.Check(http.HandlerFunc(app.Session.Mine))).Methods(c.GET)
+	r.Handle("/my-sessions/{sessionId}", app.AuthorizationChecker.Check(http.HandlerFunc(app.Session.RevokeMine))).Methods(c.DELETE)
 	r.Handle("/settings", app.AuthorizationChecker.Check(http.HandlerFunc(app.Settings.Save))).Methods(c.PATCH)
+	r.Handle("/search", app.AuthorizationChecker.Check(http.HandlerFunc(app.Search.Search))).Methods(c.GET)
+
+	public := r.PathPrefix("/public").Subrouter()
+	Handle(public, "/categories", app.Public.GetCategories, c.GET)
+	Handle(public, "/contents", app.Public.GetContent, c.GET)
+	Handle(public, "/articles", app.Public.GetArticles, c.GET)
+	Handle(public, "/articles/{id}", app.Public.GetArticle, c.GET)
+	Handle(public, "/articles/slugs/{slug}", app.Public.GetArticleBySlug, c.GET)
+	Handle(public, "/jobs", app.Public.GetJobs, c.GET)
+	Handle(public, "/jobs/{id}/applications", app.Application.Apply, c.POST)
+	Handle(public, "/contacts", app.Contact.Submit, c.POST)
 
 	Handle(r, "/my-privileges", app.Privilege.GetPrivileges, c.GET)
 
 	HandleWithSecurity(sec, r, "/privileges", app.Privileges.All, role, c.ActionRead, c.GET)
 	roles := r.PathPrefix("/roles").Subrouter()
-	HandleWithSecurity(sec, roles, "/search", app.Role.Search, role, c.ActionRead, c.POST, c.GET)
+	HandleWithSecurity(sec, roles, "/search", export.Or(app.Role.Search, app.Role.Export), role, c.ActionRead, c.POST, c.GET)
+	HandleWithSecurity(sec, roles, "/export", app.Role.Export, role, c.ActionRead, c.GET, c.POST)
 	HandleWithSecurity(sec, roles, "/{roleId}", app.Role.Load, role, c.ActionRead, c.GET)
 	HandleWithSecurity(sec, roles, "", app.Role.Create, role, c.ActionWrite, c.POST)
 	HandleWithSecurity(sec, roles, "/{roleId}", app.Role.Update, role, c.ActionWrite, c.PUT)
@@ -51,55 +85,149 @@ func Route(r *mux.Router, ctx context.Context, conf Config) error {
 	users := r.PathPrefix("/users").Subrouter()
 	HandleWithSecurity(sec, users, "", app.User.GetUserByRole, role, c.ActionRead, c.GET)
 
-	m.HandleWithSecurity(users, "/search", app.User.Search, sec.Check, sec.Authorize, user, c.ActionRead, c.GET, c.POST)
+	m.HandleWithSecurity(users, "/search", export.Or(app.User.Search, app.User.Export), sec.Check, sec.Authorize, user, c.ActionRead, c.GET, c.POST)
+	m.HandleWithSecurity(users, "/export", app.User.Export, sec.Check, sec.Authorize, user, c.ActionRead, c.GET, c.POST)
 	HandleWithSecurity(sec, users, "/{userId}", app.User.Load, user, c.ActionRead, c.GET)
 	HandleWithSecurity(sec, users, "", app.User.Create, user, c.ActionWrite, c.POST)
 	HandleWithSecurity(sec, users, "/{userId}", app.User.Update, user, c.ActionWrite, c.PUT)
 	HandleWithSecurity(sec, users, "/{userId}", app.User.Patch, user, c.ActionWrite, c.PATCH)
 	HandleWithSecurity(sec, users, "/{userId}", app.User.Delete, user, c.ActionWrite, c.DELETE)
+	HandleWithSecurity(sec, users, "/{userId}/password", app.Password.Set, user, c.ActionWrite, c.PUT)
+	HandleWithSecurity(sec, users, "/{userId}/account", app.Account.Load, user, c.ActionRead, c.GET)
+	HandleWithSecurity(sec, users, "/{userId}/2fa", app.TwoFactor.Reset, user, c.ActionWrite, c.DELETE)
+	HandleWithSecurity(sec, users, "/{userId}/sessions", app.Session.List, user, c.ActionRead, c.GET)
+	HandleWithSecurity(sec, users, "/{userId}/sessions", app.Session.RevokeAll, user, c.ActionWrite, c.DELETE)
+	HandleWithSecurity(sec, users, "/{userId}/sessions/{sessionId}", app.Session.Revoke, user, c.ActionWrite, c.DELETE)
+	HandleWithSecurity(sec, users, "/{userId}/lock", app.Account.Lock, user, c.ActionWrite, c.POST)
+	HandleWithSecurity(sec, users, "/{userId}/unlock", app.Account.Unlock, user, c.ActionWrite, c.POST)
+	HandleWithSecurity(sec, users, "/{userId}/suspend", app.Account.Suspend, user, c.ActionWrite, c.POST)
+	HandleWithSecurity(sec, users, "/{userId}/reactivate", app.Account.Reactivate, user, c.ActionWrite, c.POST)
+	HandleWithSecurity(sec, users, "/{userId}/disable", app.Account.Disable, user, c.ActionWrite, c.POST)
+
+	serviceAccounts := r.PathPrefix("/service-accounts").Subrouter()
+	HandleWithSecurity(sec, serviceAccounts, "", app.ApiKey.All, service_account, c.ActionRead, c.GET)
+	HandleWithSecurity(sec, serviceAccounts, "/{id}", app.ApiKey.Load, service_account, c.ActionRead, c.GET)
+	HandleWithSecurity(sec, serviceAccounts, "", app.ApiKey.Create, service_account, c.ActionWrite, c.POST)
+	HandleWithSecurity(sec, serviceAccounts, "/{id}", app.ApiKey.Update, service_account, c.ActionWrite, c.PUT)
+	HandleWithSecurity(sec, serviceAccounts, "/{id}", app.ApiKey.Delete, service_account, c.ActionWrite, c.DELETE)
+	HandleWithSecurity(sec, serviceAccounts, "/{id}/keys", app.ApiKey.LoadKeys, service_account, c.ActionRead, c.GET)
+	HandleWithSecurity(sec, serviceAccounts, "/{id}/keys", app.ApiKey.CreateKey, service_account, c.ActionWrite, c.POST)
+	HandleWithSecurity(sec, serviceAccounts, "/{id}/keys/{keyId}", app.ApiKey.RevokeKey, service_account, c.ActionWrite, c.DELETE)
 
 	categories := r.PathPrefix("/categories").Subrouter()
-	HandleWithSecurity(sec, categories, "/search", app.Category.Search, category, c.ActionRead, c.GET, c.POST)
+	HandleWithSecurity(sec, categories, "/search", export.Or(app.Category.Search, app.Category.Export), category, c.ActionRead, c.GET, c.POST)
+	HandleWithSecurity(sec, categories, "/export", app.Category.Export, category, c.ActionRead, c.GET, c.POST)
+	HandleWithSecurity(sec, categories, "/reorder", app.Category.Reorder, category, c.ActionWrite, c.PUT)
+	HandleWithSecurity(sec, categories, "/tree", app.Category.GetTree, category, c.ActionRead, c.GET)
 	HandleWithSecurity(sec, categories, "/{id}", app.Category.Load, category, c.ActionRead, c.GET)
+	HandleWithSecurity(sec, categories, "/{id}/breadcrumb", app.Category.GetBreadcrumb, category, c.ActionRead, c.GET)
 	HandleWithSecurity(sec, categories, "", app.Category.Create, category, c.ActionWrite, c.POST)
 	HandleWithSecurity(sec, categories, "/{id}", app.Category.Update, category, c.ActionWrite, c.PUT)
 	HandleWithSecurity(sec, categories, "/{id}", app.Category.Patch, category, c.ActionWrite, c.PATCH)
 	HandleWithSecurity(sec, categories, "/{id}", app.Category.Delete, category, c.ActionWrite, c.DELETE)
+	HandleWithSecurity(sec, categories, "/{id}/move", app.Category.Move, category, c.ActionWrite, c.POST)
 
 	contents := r.PathPrefix("/contents").Subrouter()
-	HandleWithSecurity(sec, contents, "/search", app.Content.Search, content, c.ActionRead, c.GET, c.POST)
+	HandleWithSecurity(sec, contents, "/search", export.Or(app.Content.Search, app.Content.Export), content, c.ActionRead, c.GET, c.POST)
+	HandleWithSecurity(sec, contents, "/export", app.Content.Export, content, c.ActionRead, c.GET, c.POST)
+	HandleWithSecurity(sec, contents, "/translations/missing", app.Content.GetMissingTranslations, content, c.ActionRead, c.GET)
+	HandleWithSecurity(sec, contents, "/translations/stale", app.Content.GetStaleTranslations, content, c.ActionRead, c.GET)
+	HandleWithSecurity(sec, contents, "/{id}", app.Content.GetLanguages, content, c.ActionRead, c.GET)
 	HandleWithSecurity(sec, contents, "/{id}/{lang}", app.Content.Load, content, c.ActionRead, c.GET)
 	HandleWithSecurity(sec, contents, "", app.Content.Create, content, c.ActionWrite, c.POST)
 	HandleWithSecurity(sec, contents, "/{id}/{lang}", app.Content.Update, content, c.ActionWrite, c.PUT)
 	HandleWithSecurity(sec, contents, "/{id}/{lang}", app.Content.Patch, content, c.ActionWrite, c.PATCH)
 	HandleWithSecurity(sec, contents, "/{id}/{lang}", app.Content.Delete, content, c.ActionWrite, c.DELETE)
+	HandleWithSecurity(sec, contents, "/{id}/{lang}/clone/{target}", app.Content.Clone, content, c.ActionWrite, c.POST)
+	HandleWithSecurity(sec, contents, "/{id}/{lang}/revisions", app.Content.GetRevisions, content, c.ActionRead, c.GET)
+	HandleWithSecurity(sec, contents, "/{id}/{lang}/revisions/{version}", app.Content.GetRevision, content, c.ActionRead, c.GET)
+	HandleWithSecurity(sec, contents, "/{id}/{lang}/revisions/{version}/diff/{other}", app.Content.Diff, content, c.ActionRead, c.GET)
+	HandleWithSecurity(sec, contents, "/{id}/{lang}/revisions/{version}/restore", app.Content.Restore, content, c.ActionWrite, c.POST)
 
 	articles := r.PathPrefix("/articles").Subrouter()
-	HandleWithSecurity(sec, articles, "/search", app.Article.Search, article, c.ActionRead, c.GET, c.POST)
+	HandleWithSecurity(sec, articles, "/search", export.Or(app.Article.Search, app.Article.Export), article, c.ActionRead, c.GET, c.POST)
+	HandleWithSecurity(sec, articles, "/export", app.Article.Export, article, c.ActionRead, c.GET, c.POST)
+	HandleWithSecurity(sec, articles, "/slugs/{slug}", app.Article.ResolveSlug, article, c.ActionRead, c.GET)
 	HandleWithSecurity(sec, articles, "/{id}", app.Article.Load, article, c.ActionRead, c.GET)
 	HandleWithSecurity(sec, articles, "", app.Article.Create, article, c.ActionWrite, c.POST)
 	HandleWithSecurity(sec, articles, "/{id}", app.Article.Update, article, c.ActionWrite, c.PUT)
 	HandleWithSecurity(sec, articles, "/{id}", app.Article.Patch, article, c.ActionWrite, c.PATCH)
 	HandleWithSecurity(sec, articles, "/{id}", app.Article.Delete, article, c.ActionWrite, c.DELETE)
+	HandleWithSecurity(sec, articles, "/{id}/submit", app.Article.Submit, article, c.ActionWrite, c.POST)
+	HandleWithSecurity(sec, articles, "/{id}/approve", app.Article.Approve, article, c.ActionApprove, c.POST)
+	HandleWithSecurity(sec, articles, "/{id}/reject", app.Article.Reject, article, c.ActionApprove, c.POST)
+	HandleWithSecurity(sec, articles, "/{id}/publish", app.Article.Publish, article, c.ActionApprove, c.POST)
+	HandleWithSecurity(sec, articles, "/{id}/archive", app.Article.Archive, article, c.ActionWrite, c.POST)
 
 	jobs := r.PathPrefix("/jobs").Subrouter()
-	HandleWithSecurity(sec, jobs, "/search", app.Job.Search, job, c.ActionRead, c.GET, c.POST)
+	HandleWithSecurity(sec, jobs, "/search", export.Or(app.Job.Search, app.Job.Export), job, c.ActionRead, c.GET, c.POST)
+	HandleWithSecurity(sec, jobs, "/export", app.Job.Export, job, c.ActionRead, c.GET, c.POST)
 	HandleWithSecurity(sec, jobs, "/{id}", app.Job.Load, job, c.ActionRead, c.GET)
 	HandleWithSecurity(sec, jobs, "", app.Job.Create, job, c.ActionWrite, c.POST)
 	HandleWithSecurity(sec, jobs, "/{id}", app.Job.Update, job, c.ActionWrite, c.PUT)
 	HandleWithSecurity(sec, jobs, "/{id}", app.Job.Patch, job, c.ActionWrite, c.PATCH)
 	HandleWithSecurity(sec, jobs, "/{id}", app.Job.Delete, job, c.ActionWrite, c.DELETE)
+	HandleWithSecurity(sec, jobs, "/{id}/applications", app.Application.SearchByJob, applicant, c.ActionRead, c.GET)
+
+	applications := r.PathPrefix("/applications").Subrouter()
+	HandleWithSecurity(sec, applications, "", export.Or(app.Application.Search, app.Application.Export), applicant, c.ActionRead, c.GET)
+	HandleWithSecurity(sec, applications, "/search", export.Or(app.Application.Search, app.Application.Export), applicant, c.ActionRead, c.GET, c.POST)
+	HandleWithSecurity(sec, applications, "/export", app.Application.Export, applicant, c.ActionRead, c.GET, c.POST)
+	HandleWithSecurity(sec, applications, "/{id}", app.Application.Load, applicant, c.ActionRead, c.GET)
+	HandleWithSecurity(sec, applications, "/{id}/resume", app.Application.DownloadResume, applicant, c.ActionRead, c.GET)
+	HandleWithSecurity(sec, applications, "/{id}/stage", app.Application.ChangeStage, applicant, c.ActionWrite, c.POST)
+	HandleWithSecurity(sec, applications, "/{id}", app.Application.Delete, applicant, c.ActionWrite, c.DELETE)
 
 	contacts := r.PathPrefix("/contacts").Subrouter()
-	HandleWithSecurity(sec, contacts, "/search", app.Contact.Search, contact, c.ActionRead, c.GET, c.POST)
+	HandleWithSecurity(sec, contacts, "/search", export.Or(app.Contact.Search, app.Contact.Export), contact, c.ActionRead, c.GET, c.POST)
+	HandleWithSecurity(sec, contacts, "/export", app.Contact.Export, contact, c.ActionRead, c.GET, c.POST)
+	HandleWithSecurity(sec, contacts, "/overdue", app.Contact.Overdue, contact, c.ActionRead, c.GET)
+	HandleWithSecurity(sec, contacts, "/duplicates", app.Contact.GetDuplicates, contact, c.ActionRead, c.GET)
+	HandleWithSecurity(sec, contacts, "/merges/{id}/undo", app.Contact.UndoMerge, contact, c.ActionWrite, c.POST)
 	HandleWithSecurity(sec, contacts, "/{contactId}", app.Contact.Load, contact, c.ActionRead, c.GET)
 	HandleWithSecurity(sec, contacts, "", app.Contact.Create, contact, c.ActionWrite, c.POST)
-	HandleWithSecurity(sec, contacts, "/{contactId}", app.Contact.Update, contact, c.ActionWrite, c.PUT)
-	HandleWithSecurity(sec, contacts, "/{contactId}", app.Contact.Patch, contact, c.ActionWrite, c.PATCH)
 	HandleWithSecurity(sec, contacts, "/{contactId}", app.Contact.Delete, contact, c.ActionWrite, c.DELETE)
+	HandleWithSecurity(sec, contacts, "/{contactId}/assign", app.Contact.Assign, contact, c.ActionWrite, c.POST)
+	HandleWithSecurity(sec, contacts, "/{contactId}/contacted", app.Contact.MarkContacted, contact, c.ActionWrite, c.POST)
+	HandleWithSecurity(sec, contacts, "/{contactId}/notes", app.Contact.GetNotes, contact, c.ActionRead, c.GET)
+	HandleWithSecurity(sec, contacts, "/{contactId}/notes", app.Contact.AddNote, contact, c.ActionWrite, c.POST)
+	HandleWithSecurity(sec, contacts, "/{contactId}/merges", app.Contact.GetMerges, contact, c.ActionRead, c.GET)
+	HandleWithSecurity(sec, contacts, "/{contactId}/merge", app.Contact.Merge, contact, c.ActionWrite, c.POST)
+
+	companies := r.PathPrefix("/companies").Subrouter()
+	HandleWithSecurity(sec, companies, "/search", export.Or(app.Company.Search, app.Company.Export), company, c.ActionRead, c.GET, c.POST)
+	HandleWithSecurity(sec, companies, "/export", app.Company.Export, company, c.ActionRead, c.GET, c.POST)
+	HandleWithSecurity(sec, companies, "/{id}", app.Company.Load, company, c.ActionRead, c.GET)
+	HandleWithSecurity(sec, companies, "", app.Company.Create, company, c.ActionWrite, c.POST)
+	HandleWithSecurity(sec, companies, "/{id}", app.Company.Update, company, c.ActionWrite, c.PUT)
+	HandleWithSecurity(sec, companies, "/{id}", app.Company.Patch, company, c.ActionWrite, c.PATCH)
+	HandleWithSecurity(sec, companies, "/{id}", app.Company.Delete, company, c.ActionWrite, c.DELETE)
+
+	mediaRouter := r.PathPrefix("/media").Subrouter()
+	HandleWithSecurity(sec, mediaRouter, "", export.Or(app.Media.Search, app.Media.Export), media, c.ActionRead, c.GET)
+	HandleWithSecurity(sec, mediaRouter, "/search", export.Or(app.Media.Search, app.Media.Export), media, c.ActionRead, c.GET, c.POST)
+	HandleWithSecurity(sec, mediaRouter, "/export", app.Media.Export, media, c.ActionRead, c.GET, c.POST)
+	HandleWithSecurity(sec, mediaRouter, "/{id}", app.Media.Load, media, c.ActionRead, c.GET)
+	HandleWithSecurity(sec, mediaRouter, "", app.Media.Upload, media, c.ActionWrite, c.POST)
+	HandleWithSecurity(sec, mediaRouter, "/{id}", app.Media.Patch, media, c.ActionWrite, c.PATCH)
+	HandleWithSecurity(sec, mediaRouter, "/{id}", app.Media.Delete, media, c.ActionWrite, c.DELETE)
+	if strings.HasPrefix(conf.Media.Url, "/") {
+		files := strings.TrimSuffix(conf.Media.Url, "/") + "/"
+		r.PathPrefix(files).Handler(http.StripPrefix(files, http.FileServer(me.Files{Dir: http.Dir(conf.Media.Directory)}))).Methods(c.GET)
+	}
 
-	HandleWithSecurity(sec, r, "/audit-logs", app.AuditLog.Search, audit_log, c.ActionRead, c.GET, c.POST)
-	HandleWithSecurity(sec, r, "/audit-logs/search", app.AuditLog.Search, audit_log, c.ActionRead, c.GET, c.POST)
+	tags := r.PathPrefix("/tags").Subrouter()
+	HandleWithSecurity(sec, tags, "", app.Tag.GetUsages, tag, c.ActionRead, c.GET)
+	HandleWithSecurity(sec, tags, "/{id}", app.Tag.Load, tag, c.ActionRead, c.GET)
+	HandleWithSecurity(sec, tags, "", app.Tag.Create, tag, c.ActionWrite, c.POST)
+	HandleWithSecurity(sec, tags, "/merge", app.Tag.Merge, tag, c.ActionWrite, c.POST)
+	HandleWithSecurity(sec, tags, "/{id}/rename", app.Tag.Rename, tag, c.ActionWrite, c.POST)
+	HandleWithSecurity(sec, tags, "", app.Tag.DeleteUnused, tag, c.ActionWrite, c.DELETE)
+	HandleWithSecurity(sec, tags, "/{id}", app.Tag.Delete, tag, c.ActionWrite, c.DELETE)
+
+	HandleWithSecurity(sec, r, "/audit-logs", export.Or(app.AuditLog.Search, app.AuditLog.Export), audit_log, c.ActionRead, c.GET, c.POST)
+	HandleWithSecurity(sec, r, "/audit-logs/search", export.Or(app.AuditLog.Search, app.AuditLog.Export), audit_log, c.ActionRead, c.GET, c.POST)
+	HandleWithSecurity(sec, r, "/audit-logs/export", app.AuditLog.Export, audit_log, c.ActionRead, c.GET, c.POST)
 	return nil
 }
 
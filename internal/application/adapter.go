@@ -0,0 +1,141 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"time"
+
+	s "github.com/core-go/sql"
+)
+
+func NewApplicationAdapter(db *sql.DB, buildQuery func(*ApplicationFilter) (string, []interface{})) (*ApplicationAdapter, error) {
+	parameters, err := s.CreateParameters(reflect.TypeOf(Application{}), db)
+	if err != nil {
+		return nil, err
+	}
+	changeParameters, err := s.CreateParameters(reflect.TypeOf(StageChange{}), db)
+	if err != nil {
+		return nil, err
+	}
+	return &ApplicationAdapter{DB: db, Parameters: parameters, ChangeParameters: changeParameters, BuildQuery: buildQuery}, nil
+}
+
+type ApplicationAdapter struct {
+	DB         *sql.DB
+	BuildQuery func(*ApplicationFilter) (string, []interface{})
+	*s.Parameters
+	ChangeParameters *s.Parameters
+}
+
+func (r *ApplicationAdapter) Load(ctx context.Context, id string) (*Application, error) {
+	var applications []Application
+	query := fmt.Sprintf("select %s from job_applications where id = %s limit 1", r.Fields, r.BuildParam(1))
+	err := s.Query(ctx, r.DB, r.Map, &applications, query, id)
+	if err != nil || len(applications) == 0 {
+		return nil, err
+	}
+	return &applications[0], nil
+}
+
+func (r *ApplicationAdapter) Create(ctx context.Context, application *Application) (int64, error) {
+	query, args := s.BuildToInsert("job_applications", application, r.BuildParam, r.Schema)
+	tx := s.GetTx(ctx, r.DB)
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *ApplicationAdapter) Delete(ctx context.Context, id string) (int64, error) {
+	query := fmt.Sprintf("delete from job_applications where id = %s", r.BuildParam(1))
+	tx := s.GetTx(ctx, r.DB)
+	res, err := tx.ExecContext(ctx, query, id)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *ApplicationAdapter) Search(ctx context.Context, filter *ApplicationFilter, limit int64, offset int64) ([]Application, int64, error) {
+	var applications []Application
+	if limit <= 0 {
+		return applications, 0, nil
+	}
+	query, params := r.BuildQuery(filter)
+	pagingQuery := s.BuildPagingQuery(query, limit, offset)
+	countQuery := s.BuildCountQuery(query)
+
+	row := r.DB.QueryRowContext(ctx, countQuery, params...)
+	if row.Err() != nil {
+		return applications, 0, row.Err()
+	}
+	var total int64
+	err := row.Scan(&total)
+	if err != nil || total == 0 {
+		return applications, total, err
+	}
+
+	err = s.Query(ctx, r.DB, r.Map, &applications, pagingQuery, params...)
+	return applications, total, err
+}
+
+// IsOpen checks if the job is published and not expired, so that it accepts applications.
+// The job is locked until the end of the transaction, so that concurrent applications are counted one after another.
+func (r *ApplicationAdapter) IsOpen(ctx context.Context, jobId string, now time.Time) (bool, error) {
+	query := fmt.Sprintf(`select id from jobs where id = %s and status = 'A'
+		and (published_at is null or published_at <= %s) and (expired_at is null or expired_at > %s) for update`, r.BuildParam(1), r.BuildParam(2), r.BuildParam(2))
+	return s.Exist(ctx, s.GetTx(ctx, r.DB), query, jobId, now)
+}
+
+func (r *ApplicationAdapter) LockJob(ctx context.Context, jobId string) (bool, error) {
+	query := fmt.Sprintf("select id from jobs where id = %s for update", r.BuildParam(1))
+	return s.Exist(ctx, s.GetTx(ctx, r.DB), query, jobId)
+}
+
+func (r *ApplicationAdapter) Exist(ctx context.Context, jobId string, email string) (bool, error) {
+	query := fmt.Sprintf("select id from job_applications where job_id = %s and lower(email) = lower(%s) limit 1", r.BuildParam(1), r.BuildParam(2))
+	return s.Exist(ctx, s.GetTx(ctx, r.DB), query, jobId, email)
+}
+
+// UpdateStage only updates the application which is still in the stage the user has seen
+func (r *ApplicationAdapter) UpdateStage(ctx context.Context, id string, from string, to string, updatedBy string, updatedAt time.Time) (int64, error) {
+	query := fmt.Sprintf("update job_applications set stage = %s, updated_by = %s, updated_at = %s where id = %s and stage = %s",
+		r.BuildParam(1), r.BuildParam(2), r.BuildParam(3), r.BuildParam(4), r.BuildParam(5))
+	tx := s.GetTx(ctx, r.DB)
+	res, err := tx.ExecContext(ctx, query, to, updatedBy, updatedAt, id, from)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *ApplicationAdapter) CreateStageChange(ctx context.Context, change *StageChange) (int64, error) {
+	query, args := s.BuildToInsert("job_application_stages", change, r.ChangeParameters.BuildParam, r.ChangeParameters.Schema)
+	tx := s.GetTx(ctx, r.DB)
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *ApplicationAdapter) LoadHistory(ctx context.Context, id string) ([]StageChange, error) {
+	var changes []StageChange
+	query := fmt.Sprintf("select %s from job_application_stages where application_id = %s order by changed_at", r.ChangeParameters.Fields, r.BuildParam(1))
+	err := s.Query(ctx, r.DB, r.ChangeParameters.Map, &changes, query, id)
+	return changes, err
+}
+
+// CountApplicants recounts the applications of the job into applicant_count, in the transaction which adds or removes an application
+func (r *ApplicationAdapter) CountApplicants(ctx context.Context, jobId string) (int64, error) {
+	query := fmt.Sprintf("update jobs set applicant_count = (select count(*) from job_applications where job_id = %s) where id = %s", r.BuildParam(1), r.BuildParam(1))
+	tx := s.GetTx(ctx, r.DB)
+	res, err := tx.ExecContext(ctx, query, jobId)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
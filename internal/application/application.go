@@ -0,0 +1,71 @@
+package application
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	StageNew       = "new"
+	StageScreening = "screening"
+	StageInterview = "interview"
+	StageOffer     = "offer"
+	StageHired     = "hired"
+	StageRejected  = "rejected"
+)
+
+var Stages = []string{StageNew, StageScreening, StageInterview, StageOffer, StageHired, StageRejected}
+
+func IsStage(stage string) bool {
+	for _, s := range Stages {
+		if s == stage {
+			return true
+		}
+	}
+	return false
+}
+
+type Config struct {
+	Directory string   `yaml:"directory" mapstructure:"directory" json:"directory,omitempty"`
+	MaxSize   int64    `yaml:"max_size" mapstructure:"max_size" json:"maxSize,omitempty"`
+	Types     []string `yaml:"types" mapstructure:"types" json:"types,omitempty"`
+}
+
+// Storage keeps the résumés, which are not public, so they are only downloaded through the application
+type Storage interface {
+	Upload(ctx context.Context, name string, data []byte, contentType string) (string, error)
+	Load(ctx context.Context, name string) ([]byte, error)
+	Delete(ctx context.Context, name string) (bool, error)
+}
+
+type Application struct {
+	Id          string        `json:"id" gorm:"primary_key;column:id" bson:"_id" dynamodbav:"id,omitempty" firestore:"-"`
+	JobId       string        `json:"jobId,omitempty" gorm:"column:job_id" bson:"jobId,omitempty" dynamodbav:"jobId,omitempty" firestore:"jobId,omitempty"`
+	Name        string        `json:"name,omitempty" gorm:"column:name" bson:"name,omitempty" dynamodbav:"name,omitempty" firestore:"name,omitempty" validate:"required,max=120"`
+	Email       string        `json:"email,omitempty" gorm:"column:email" bson:"email,omitempty" dynamodbav:"email,omitempty" firestore:"email,omitempty" validate:"required,email,max=120"`
+	Phone       *string       `json:"phone,omitempty" gorm:"column:phone" bson:"phone,omitempty" dynamodbav:"phone,omitempty" firestore:"phone,omitempty" validate:"omitempty,max=45"`
+	CoverLetter *string       `json:"coverLetter,omitempty" gorm:"column:cover_letter" bson:"coverLetter,omitempty" dynamodbav:"coverLetter,omitempty" firestore:"coverLetter,omitempty" validate:"omitempty,max=4000"`
+	ResumeName  string        `json:"resumeName,omitempty" gorm:"column:resume_name" bson:"resumeName,omitempty" dynamodbav:"resumeName,omitempty" firestore:"resumeName,omitempty"`
+	ResumePath  string        `json:"-" gorm:"column:resume_path" bson:"resumePath,omitempty" dynamodbav:"resumePath,omitempty" firestore:"resumePath,omitempty"`
+	ResumeType  string        `json:"resumeType,omitempty" gorm:"column:resume_type" bson:"resumeType,omitempty" dynamodbav:"resumeType,omitempty" firestore:"resumeType,omitempty"`
+	ResumeSize  int64         `json:"resumeSize,omitempty" gorm:"column:resume_size" bson:"resumeSize,omitempty" dynamodbav:"resumeSize,omitempty" firestore:"resumeSize,omitempty"`
+	Stage       string        `json:"stage,omitempty" gorm:"column:stage" bson:"stage,omitempty" dynamodbav:"stage,omitempty" firestore:"stage,omitempty"`
+	AppliedAt   *time.Time    `json:"appliedAt,omitempty" gorm:"column:applied_at" bson:"appliedAt,omitempty" dynamodbav:"appliedAt,omitempty" firestore:"appliedAt,omitempty"`
+	UpdatedBy   *string       `json:"updatedBy,omitempty" gorm:"column:updated_by" bson:"updatedBy,omitempty" dynamodbav:"updatedBy,omitempty" firestore:"updatedBy,omitempty"`
+	UpdatedAt   *time.Time    `json:"updatedAt,omitempty" gorm:"column:updated_at" bson:"updatedAt,omitempty" dynamodbav:"updatedAt,omitempty" firestore:"updatedAt,omitempty"`
+	History     []StageChange `json:"history,omitempty"`
+}
+
+type StageChange struct {
+	ApplicationId string     `json:"applicationId" gorm:"column:application_id"`
+	From          *string    `json:"from,omitempty" gorm:"column:from_stage"`
+	To            string     `json:"to" gorm:"column:to_stage"`
+	Note          *string    `json:"note,omitempty" gorm:"column:note"`
+	ChangedBy     *string    `json:"changedBy,omitempty" gorm:"column:changed_by"`
+	ChangedAt     *time.Time `json:"changedAt,omitempty" gorm:"column:changed_at"`
+}
+
+type StageRequest struct {
+	Stage string `json:"stage"`
+	Note  string `json:"note,omitempty"`
+}
@@ -0,0 +1,13 @@
+package application
+
+import "github.com/core-go/search"
+
+type ApplicationFilter struct {
+	*search.Filter
+	Id        string            `json:"id,omitempty" gorm:"primary_key;column:id" bson:"_id" dynamodbav:"id,omitempty" firestore:"-" operator:"="`
+	JobId     string            `json:"jobId,omitempty" gorm:"column:job_id" bson:"jobId,omitempty" dynamodbav:"jobId,omitempty" firestore:"jobId,omitempty" operator:"="`
+	Name      string            `json:"name,omitempty" gorm:"column:name" bson:"name,omitempty" dynamodbav:"name,omitempty" firestore:"name,omitempty" operator:"like" q:"like"`
+	Email     string            `json:"email,omitempty" gorm:"column:email" bson:"email,omitempty" dynamodbav:"email,omitempty" firestore:"email,omitempty" q:"prefix"`
+	Stage     string            `json:"stage,omitempty" gorm:"column:stage" bson:"stage,omitempty" dynamodbav:"stage,omitempty" firestore:"stage,omitempty" operator:"="`
+	AppliedAt *search.TimeRange `json:"appliedAt,omitempty" gorm:"column:applied_at" bson:"appliedAt,omitempty" dynamodbav:"appliedAt,omitempty" firestore:"appliedAt,omitempty"`
+}
@@ -0,0 +1,234 @@
+package application
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/core-go/core"
+	"github.com/core-go/search"
+
+	"go-service/pkg/export"
+)
+
+const docx = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+
+var extensions = map[string]string{
+	"application/pdf": ".pdf",
+	docx:              ".docx",
+}
+
+func NewApplicationHandler(service ApplicationService, logError core.Log, validate core.Validate[*Application], maxSize int64, types []string, exporter *export.Exporter[*ApplicationFilter], writeLog core.WriteLog, action *core.ActionConfig) *ApplicationHandler {
+	applicationType := reflect.TypeOf(Application{})
+	parameters := search.CreateParameters(reflect.TypeOf(ApplicationFilter{}), applicationType)
+	attributes := core.CreateAttributes(applicationType, logError, writeLog, action)
+	return &ApplicationHandler{service: service, Validate: validate, maxSize: maxSize, types: types, Attributes: attributes, Parameters: parameters, Exporter: exporter}
+}
+
+type ApplicationHandler struct {
+	service  ApplicationService
+	Validate core.Validate[*Application]
+	maxSize  int64
+	types    []string
+	*core.Attributes
+	*search.Parameters
+	*export.Exporter[*ApplicationFilter]
+}
+
+func (h *ApplicationHandler) Load(w http.ResponseWriter, r *http.Request) {
+	id, err := core.GetRequiredString(w, r)
+	if err == nil {
+		application, err := h.service.Load(r.Context(), id)
+		if err != nil {
+			h.Error(r.Context(), fmt.Sprintf("Error to get application '%s': %s", id, err.Error()))
+			http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+			return
+		}
+		core.JSON(w, core.IsFound(application), application)
+	}
+}
+func (h *ApplicationHandler) Apply(w http.ResponseWriter, r *http.Request) {
+	jobId, err := core.GetRequiredString(w, r, 1)
+	if err != nil {
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, h.maxSize)
+	if err := r.ParseMultipartForm(h.maxSize); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	file, header, err := r.FormFile("resume")
+	if err != nil {
+		core.JSON(w, http.StatusUnprocessableEntity, []core.ErrorMessage{{Field: "resume", Code: "required"}})
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	mimeType := strings.Split(http.DetectContentType(data), ";")[0]
+	// a docx file is a zip archive, so its type is only known by the extension
+	if mimeType == "application/zip" && strings.EqualFold(filepath.Ext(header.Filename), ".docx") {
+		mimeType = docx
+	}
+	ext, ok := extensions[mimeType]
+	if !ok || !h.isAllowed(mimeType) {
+		h.Log(r.Context(), h.Resource, "apply", false, fmt.Sprintf("unsupported type '%s' of '%s'", mimeType, header.Filename))
+		core.JSON(w, http.StatusUnprocessableEntity, []core.ErrorMessage{{Field: "resume", Code: "type", Param: mimeType}})
+		return
+	}
+	application := Application{
+		JobId:      jobId,
+		Name:       strings.TrimSpace(r.FormValue("name")),
+		Email:      strings.TrimSpace(r.FormValue("email")),
+		ResumeName: filepath.Base(header.Filename),
+		ResumeType: mimeType,
+	}
+	if phone := strings.TrimSpace(r.FormValue("phone")); len(phone) > 0 {
+		application.Phone = &phone
+	}
+	if coverLetter := strings.TrimSpace(r.FormValue("coverLetter")); len(coverLetter) > 0 {
+		application.CoverLetter = &coverLetter
+	}
+	errors, er1 := h.Validate(r.Context(), &application)
+	if !core.HasError(w, r, errors, er1, h.Error, &application, h.Log, h.Resource, "apply") {
+		res, err := h.service.Apply(r.Context(), &application, data, ext)
+		if err != nil {
+			h.Error(r.Context(), err.Error())
+			h.Log(r.Context(), h.Resource, "apply", false, err.Error())
+			http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+			return
+		}
+
+		if res > 0 {
+			h.Log(r.Context(), h.Resource, "apply", true, fmt.Sprintf("apply '%s' to '%s'", application.Id, jobId))
+			core.JSON(w, http.StatusCreated, application)
+		} else if res == 0 {
+			h.Log(r.Context(), h.Resource, "apply", false, fmt.Sprintf("not open '%s'", jobId))
+			core.JSON(w, http.StatusNotFound, res)
+		} else {
+			h.Log(r.Context(), h.Resource, "apply", false, fmt.Sprintf("duplicate '%s' to '%s'", application.Email, jobId))
+			core.JSON(w, http.StatusConflict, res)
+		}
+	}
+}
+func (h *ApplicationHandler) ChangeStage(w http.ResponseWriter, r *http.Request) {
+	id, er1 := core.GetRequiredString(w, r, 1)
+	if er1 == nil {
+		req, er2 := core.Decode[StageRequest](w, r)
+		if er2 == nil {
+			if !IsStage(req.Stage) {
+				core.JSON(w, http.StatusUnprocessableEntity, []core.ErrorMessage{{Field: "stage", Code: "stage", Param: strings.Join(Stages, ",")}})
+				return
+			}
+			if len(req.Note) > 1000 {
+				core.JSON(w, http.StatusUnprocessableEntity, []core.ErrorMessage{{Field: "note", Code: "max", Param: "1000"}})
+				return
+			}
+			application, res, err := h.service.ChangeStage(r.Context(), id, req.Stage, req.Note)
+			if err != nil {
+				h.Error(r.Context(), err.Error())
+				h.Log(r.Context(), h.Resource, "stage", false, err.Error())
+				http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+				return
+			}
+
+			if res > 0 {
+				h.Log(r.Context(), h.Resource, "stage", true, fmt.Sprintf("stage '%s' to '%s'", id, req.Stage))
+				core.JSON(w, http.StatusOK, application)
+			} else if res == 0 {
+				h.Log(r.Context(), h.Resource, "stage", false, fmt.Sprintf("not found '%s'", id))
+				core.JSON(w, http.StatusNotFound, res)
+			} else {
+				h.Log(r.Context(), h.Resource, "stage", false, fmt.Sprintf("conflict '%s' to '%s'", id, req.Stage))
+				core.JSON(w, http.StatusConflict, res)
+			}
+		}
+	}
+}
+func (h *ApplicationHandler) DownloadResume(w http.ResponseWriter, r *http.Request) {
+	id, err := core.GetRequiredString(w, r, 1)
+	if err == nil {
+		application, data, err := h.service.LoadResume(r.Context(), id)
+		if err != nil {
+			h.Error(r.Context(), fmt.Sprintf("Error to get résumé of application '%s': %s", id, err.Error()))
+			http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+			return
+		}
+		if application == nil || data == nil {
+			core.JSON(w, http.StatusNotFound, nil)
+			return
+		}
+		w.Header().Set("Content-Type", application.ResumeType)
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", application.ResumeName))
+		w.WriteHeader(http.StatusOK)
+		w.Write(data)
+	}
+}
+func (h *ApplicationHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := core.GetRequiredString(w, r)
+	if err == nil {
+		res, err := h.service.Delete(r.Context(), id)
+		if err != nil {
+			h.Error(r.Context(), err.Error())
+			h.Log(r.Context(), h.Resource, h.Action.Delete, false, err.Error())
+			http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+			return
+		}
+
+		if res > 0 {
+			h.Log(r.Context(), h.Resource, h.Action.Delete, true, fmt.Sprintf("%s '%s'", h.Action.Delete, id))
+			core.JSON(w, http.StatusOK, res)
+		} else {
+			h.Log(r.Context(), h.Resource, h.Action.Delete, false, fmt.Sprintf("not found '%s'", id))
+			core.JSON(w, http.StatusNotFound, res)
+		}
+	}
+}
+func (h *ApplicationHandler) Search(w http.ResponseWriter, r *http.Request) {
+	filter := ApplicationFilter{Filter: &search.Filter{}}
+	err := search.Decode(r, &filter, h.ParamIndex, h.FilterIndex)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	h.search(w, r, &filter)
+}
+func (h *ApplicationHandler) SearchByJob(w http.ResponseWriter, r *http.Request) {
+	jobId, err := core.GetRequiredString(w, r, 1)
+	if err != nil {
+		return
+	}
+	filter := ApplicationFilter{Filter: &search.Filter{}}
+	err = search.Decode(r, &filter, h.ParamIndex, h.FilterIndex)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	filter.JobId = jobId
+	h.search(w, r, &filter)
+}
+
+func (h *ApplicationHandler) search(w http.ResponseWriter, r *http.Request, filter *ApplicationFilter) {
+	offset := search.GetOffset(filter.Limit, filter.Page)
+	applications, total, err := h.service.Search(r.Context(), filter, filter.Limit, offset)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	core.JSON(w, http.StatusOK, &search.Result{List: &applications, Total: total})
+}
+
+func (h *ApplicationHandler) isAllowed(mimeType string) bool {
+	for _, t := range h.types {
+		if t == mimeType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,20 @@
+package application
+
+import (
+	"context"
+	"time"
+)
+
+type ApplicationRepository interface {
+	Load(ctx context.Context, id string) (*Application, error)
+	Create(ctx context.Context, application *Application) (int64, error)
+	Delete(ctx context.Context, id string) (int64, error)
+	Search(ctx context.Context, filter *ApplicationFilter, limit int64, offset int64) ([]Application, int64, error)
+	IsOpen(ctx context.Context, jobId string, now time.Time) (bool, error)
+	LockJob(ctx context.Context, jobId string) (bool, error)
+	Exist(ctx context.Context, jobId string, email string) (bool, error)
+	UpdateStage(ctx context.Context, id string, from string, to string, updatedBy string, updatedAt time.Time) (int64, error)
+	CreateStageChange(ctx context.Context, change *StageChange) (int64, error)
+	LoadHistory(ctx context.Context, id string) ([]StageChange, error)
+	CountApplicants(ctx context.Context, jobId string) (int64, error)
+}
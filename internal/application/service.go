@@ -0,0 +1,164 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/core-go/core/tx"
+)
+
+type ApplicationService interface {
+	Load(ctx context.Context, id string) (*Application, error)
+	Apply(ctx context.Context, application *Application, data []byte, ext string) (int64, error)
+	ChangeStage(ctx context.Context, id string, stage string, note string) (*Application, int64, error)
+	Delete(ctx context.Context, id string) (int64, error)
+	Search(ctx context.Context, filter *ApplicationFilter, limit int64, offset int64) ([]Application, int64, error)
+	LoadResume(ctx context.Context, id string) (*Application, []byte, error)
+}
+
+func NewApplicationService(db *sql.DB, repository ApplicationRepository, storage Storage, generateId func(context.Context) (string, error), userKey string, logError func(context.Context, string, ...map[string]interface{})) *ApplicationUseCase {
+	return &ApplicationUseCase{db: db, repository: repository, storage: storage, generateId: generateId, userKey: userKey, logError: logError}
+}
+
+type ApplicationUseCase struct {
+	db         *sql.DB
+	repository ApplicationRepository
+	storage    Storage
+	generateId func(context.Context) (string, error)
+	userKey    string
+	logError   func(context.Context, string, ...map[string]interface{})
+}
+
+func (s *ApplicationUseCase) Load(ctx context.Context, id string) (*Application, error) {
+	application, err := s.repository.Load(ctx, id)
+	if err != nil || application == nil {
+		return application, err
+	}
+	application.History, err = s.repository.LoadHistory(ctx, id)
+	return application, err
+}
+
+// Apply stores the résumé, then saves the application, its first stage and the applicant count of the job in one transaction.
+// It returns 0 if the job does not accept applications, and -1 if the email has already applied to the job.
+func (s *ApplicationUseCase) Apply(ctx context.Context, application *Application, data []byte, ext string) (int64, error) {
+	id, err := s.generateId(ctx)
+	if err != nil {
+		return -1, err
+	}
+	now := time.Now()
+	application.Id = id
+	application.Stage = StageNew
+	application.AppliedAt = &now
+	application.ResumePath = id + ext
+	application.ResumeSize = int64(len(data))
+	if _, err = s.storage.Upload(ctx, application.ResumePath, data, application.ResumeType); err != nil {
+		return -1, err
+	}
+	res, err := tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
+		open, err := s.repository.IsOpen(ctx, application.JobId, now)
+		if err != nil || !open {
+			return 0, err
+		}
+		exist, err := s.repository.Exist(ctx, application.JobId, application.Email)
+		if err != nil || exist {
+			return -1, err
+		}
+		res, err := s.repository.Create(ctx, application)
+		if err != nil || res <= 0 {
+			return res, err
+		}
+		change := &StageChange{ApplicationId: id, To: StageNew, ChangedAt: &now}
+		if _, err = s.repository.CreateStageChange(ctx, change); err != nil {
+			return -1, err
+		}
+		if _, err = s.repository.CountApplicants(ctx, application.JobId); err != nil {
+			return -1, err
+		}
+		return res, nil
+	})
+	if err != nil || res <= 0 {
+		s.remove(ctx, application.ResumePath)
+	}
+	return res, err
+}
+
+// ChangeStage moves the application to another stage and keeps the change in the history.
+// It returns 0 if the application is not found, and -1 if it is already in the stage, or has just been changed by another user.
+func (s *ApplicationUseCase) ChangeStage(ctx context.Context, id string, stage string, note string) (*Application, int64, error) {
+	application, err := s.repository.Load(ctx, id)
+	if err != nil || application == nil {
+		return nil, 0, err
+	}
+	if application.Stage == stage {
+		return application, -1, nil
+	}
+	now := time.Now()
+	userId, _ := ctx.Value(s.userKey).(string)
+	res, err := tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
+		res, err := s.repository.UpdateStage(ctx, id, application.Stage, stage, userId, now)
+		if err != nil || res <= 0 {
+			return -1, err
+		}
+		from := application.Stage
+		change := &StageChange{ApplicationId: id, From: &from, To: stage, ChangedAt: &now}
+		if len(note) > 0 {
+			change.Note = &note
+		}
+		if len(userId) > 0 {
+			change.ChangedBy = &userId
+		}
+		if _, err = s.repository.CreateStageChange(ctx, change); err != nil {
+			return -1, err
+		}
+		return res, nil
+	})
+	if err != nil || res <= 0 {
+		return nil, res, err
+	}
+	application, err = s.Load(ctx, id)
+	return application, res, err
+}
+
+func (s *ApplicationUseCase) Delete(ctx context.Context, id string) (int64, error) {
+	application, err := s.repository.Load(ctx, id)
+	if err != nil || application == nil {
+		return 0, err
+	}
+	res, err := tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
+		if _, err := s.repository.LockJob(ctx, application.JobId); err != nil {
+			return -1, err
+		}
+		res, err := s.repository.Delete(ctx, id)
+		if err != nil || res <= 0 {
+			return res, err
+		}
+		if _, err = s.repository.CountApplicants(ctx, application.JobId); err != nil {
+			return -1, err
+		}
+		return res, nil
+	})
+	if err == nil && res > 0 {
+		s.remove(ctx, application.ResumePath)
+	}
+	return res, err
+}
+
+func (s *ApplicationUseCase) Search(ctx context.Context, filter *ApplicationFilter, limit int64, offset int64) ([]Application, int64, error) {
+	return s.repository.Search(ctx, filter, limit, offset)
+}
+
+func (s *ApplicationUseCase) LoadResume(ctx context.Context, id string) (*Application, []byte, error) {
+	application, err := s.repository.Load(ctx, id)
+	if err != nil || application == nil {
+		return nil, nil, err
+	}
+	data, err := s.storage.Load(ctx, application.ResumePath)
+	return application, data, err
+}
+
+func (s *ApplicationUseCase) remove(ctx context.Context, path string) {
+	if _, err := s.storage.Delete(ctx, path); err != nil {
+		s.logError(ctx, "Error to delete résumé '"+path+"': "+err.Error())
+	}
+}
@@ -0,0 +1,42 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/core-go/core"
+	b "github.com/core-go/core/builder"
+	v "github.com/core-go/core/validator"
+	"github.com/core-go/search"
+	"github.com/core-go/sql/query/builder"
+
+	"go-service/pkg/export"
+)
+
+type ApplicationTransport interface {
+	Search(w http.ResponseWriter, r *http.Request)
+	SearchByJob(w http.ResponseWriter, r *http.Request)
+	Load(w http.ResponseWriter, r *http.Request)
+	Apply(w http.ResponseWriter, r *http.Request)
+	ChangeStage(w http.ResponseWriter, r *http.Request)
+	DownloadResume(w http.ResponseWriter, r *http.Request)
+	Delete(w http.ResponseWriter, r *http.Request)
+	Export(w http.ResponseWriter, r *http.Request)
+}
+
+func NewApplicationTransport(db *sql.DB, logError core.Log, storage Storage, conf Config, tracking b.TrackingConfig, generateId func(context.Context) (string, error), writeLog core.WriteLog, action *core.ActionConfig) (ApplicationTransport, error) {
+	validator, err := v.NewValidator[*Application]()
+	if err != nil {
+		return nil, err
+	}
+	queryApplication := builder.UseQuery[Application, *ApplicationFilter](db, "job_applications")
+	applicationRepository, err := NewApplicationAdapter(db, queryApplication)
+	if err != nil {
+		return nil, err
+	}
+	applicationService := NewApplicationService(db, applicationRepository, storage, generateId, tracking.User, logError)
+	applicationExporter := export.NewExporter[Application, *ApplicationFilter](db, "application", func() *ApplicationFilter { return &ApplicationFilter{Filter: &search.Filter{}} }, queryApplication, logError, writeLog)
+	applicationHandler := NewApplicationHandler(applicationService, logError, validator.Validate, conf.MaxSize, conf.Types, applicationExporter, writeLog, action)
+	return applicationHandler, nil
+}
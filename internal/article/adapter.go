@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	s "github.com/core-go/sql"
 )
@@ -83,6 +84,59 @@ func (r *ArticleAdapter) Patch(ctx context.Context, article map[string]interface
 	return res.RowsAffected()
 }
 
+func (r *ArticleAdapter) UpdateStatus(ctx context.Context, id string, from string, to string) (int64, error) {
+	query := fmt.Sprintf("update articles set status = %s where id = %s and coalesce(status, %s) = %s", r.BuildParam(1), r.BuildParam(2), r.BuildParam(3), r.BuildParam(4))
+	tx := s.GetTx(ctx, r.DB)
+	res, err := tx.ExecContext(ctx, query, to, id, StatusDraft, from)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+// ExistSlug checks whether the slug is used by another article, as its current slug or as a redirect
+func (r *ArticleAdapter) ExistSlug(ctx context.Context, slug string, id string) (bool, error) {
+	query := fmt.Sprintf(`select id from articles where slug = %s and id <> %s
+		union all select article_id from article_redirects where slug = %s and article_id <> %s limit 1`,
+		r.BuildParam(1), r.BuildParam(2), r.BuildParam(1), r.BuildParam(2))
+	return s.Exist(ctx, s.GetTx(ctx, r.DB), query, slug, id)
+}
+
+// ResolveSlug finds the article of the current slug, or of an old slug in the redirects
+func (r *ArticleAdapter) ResolveSlug(ctx context.Context, slug string) (*SlugResolution, error) {
+	query := fmt.Sprintf(`select id, slug, false as redirect from articles where slug = %s
+		union all select a.id, a.slug, true as redirect from article_redirects d inner join articles a on a.id = d.article_id where d.slug = %s
+		order by redirect limit 1`, r.BuildParam(1), r.BuildParam(1))
+	var resolutions []SlugResolution
+	err := s.Query(ctx, r.DB, nil, &resolutions, query, slug)
+	if err != nil || len(resolutions) == 0 {
+		return nil, err
+	}
+	return &resolutions[0], nil
+}
+
+func (r *ArticleAdapter) SaveRedirect(ctx context.Context, slug string, id string) (int64, error) {
+	query := fmt.Sprintf(`insert into article_redirects (slug, article_id, created_at) values (%s, %s, %s)
+		on conflict (slug) do update set article_id = excluded.article_id, created_at = excluded.created_at`,
+		r.BuildParam(1), r.BuildParam(2), r.BuildParam(3))
+	tx := s.GetTx(ctx, r.DB)
+	res, err := tx.ExecContext(ctx, query, slug, id, time.Now())
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *ArticleAdapter) DeleteRedirect(ctx context.Context, slug string) (int64, error) {
+	query := fmt.Sprintf("delete from article_redirects where slug = %s", r.BuildParam(1))
+	tx := s.GetTx(ctx, r.DB)
+	res, err := tx.ExecContext(ctx, query, slug)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
 func (r *ArticleAdapter) Delete(ctx context.Context, id string) (int64, error) {
 	query := fmt.Sprintf("delete from articles where id = %s", r.BuildParam(1))
 	tx := s.GetTx(ctx, r.DB)
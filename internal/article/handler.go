@@ -7,13 +7,15 @@ import (
 
 	"github.com/core-go/core"
 	"github.com/core-go/search"
+
+	"go-service/pkg/export"
 )
 
-func NewArticleHandler(service ArticleService, logError core.Log, validate core.Validate[*Article], writeLog core.WriteLog, action *core.ActionConfig) *ArticleHandler {
+func NewArticleHandler(service ArticleService, logError core.Log, validate core.Validate[*Article], exporter *export.Exporter[*ArticleFilter], writeLog core.WriteLog, action *core.ActionConfig) *ArticleHandler {
 	articleType := reflect.TypeOf(Article{})
 	parameters := search.CreateParameters(reflect.TypeOf(ArticleFilter{}), articleType)
 	attributes := core.CreateAttributes(articleType, logError, writeLog, action)
-	return &ArticleHandler{service: service, Validate: validate, Attributes: attributes, Parameters: parameters}
+	return &ArticleHandler{service: service, Validate: validate, Attributes: attributes, Parameters: parameters, Exporter: exporter}
 }
 
 type ArticleHandler struct {
@@ -21,6 +23,7 @@ type ArticleHandler struct {
 	Validate core.Validate[*Article]
 	*core.Attributes
 	*search.Parameters
+	*export.Exporter[*ArticleFilter]
 }
 
 func (h *ArticleHandler) Load(w http.ResponseWriter, r *http.Request) {
@@ -35,6 +38,18 @@ func (h *ArticleHandler) Load(w http.ResponseWriter, r *http.Request) {
 		core.JSON(w, core.IsFound(article), article)
 	}
 }
+func (h *ArticleHandler) ResolveSlug(w http.ResponseWriter, r *http.Request) {
+	slug, err := core.GetRequiredString(w, r)
+	if err == nil {
+		resolution, err := h.service.ResolveSlug(r.Context(), slug)
+		if err != nil {
+			h.Error(r.Context(), fmt.Sprintf("Error to resolve article slug '%s': %s", slug, err.Error()))
+			http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+			return
+		}
+		core.JSON(w, core.IsFound(resolution), resolution)
+	}
+}
 func (h *ArticleHandler) Create(w http.ResponseWriter, r *http.Request) {
 	article, er1 := core.Decode[Article](w, r)
 	if er1 == nil {
@@ -89,6 +104,12 @@ func (h *ArticleHandler) Patch(w http.ResponseWriter, r *http.Request) {
 	if er1 == nil {
 		errors, er2 := h.Validate(r.Context(), &article)
 		if !core.HasError(w, r, errors, er2, h.Error, jsonArticle, h.Log, h.Resource, h.Action.Patch) {
+			if len(article.Sanitized) > 0 {
+				jsonArticle["content"] = article.Content
+			}
+			if _, ok := jsonArticle["slug"]; ok {
+				jsonArticle["slug"] = article.Slug
+			}
 			res, err := h.service.Patch(r.Context(), jsonArticle)
 			if err != nil {
 				h.Error(r.Context(), err.Error())
@@ -99,6 +120,9 @@ func (h *ArticleHandler) Patch(w http.ResponseWriter, r *http.Request) {
 
 			if res > 0 {
 				h.Log(r.Context(), h.Resource, h.Action.Patch, true, fmt.Sprintf("%s '%s'", h.Action.Patch, article.Id))
+				if len(article.Sanitized) > 0 {
+					jsonArticle["sanitized"] = article.Sanitized
+				}
 				core.JSON(w, http.StatusOK, jsonArticle)
 			} else if res == 0 {
 				h.Log(r.Context(), h.Resource, h.Action.Patch, false, fmt.Sprintf("not found '%s'", article.Id))
@@ -149,3 +173,41 @@ func (h *ArticleHandler) Search(w http.ResponseWriter, r *http.Request) {
 	}
 	core.JSON(w, http.StatusOK, &search.Result{List: &articles, Total: total})
 }
+func (h *ArticleHandler) Submit(w http.ResponseWriter, r *http.Request) {
+	h.transit(w, r, ActionSubmit)
+}
+func (h *ArticleHandler) Approve(w http.ResponseWriter, r *http.Request) {
+	h.transit(w, r, ActionApprove)
+}
+func (h *ArticleHandler) Reject(w http.ResponseWriter, r *http.Request) {
+	h.transit(w, r, ActionReject)
+}
+func (h *ArticleHandler) Publish(w http.ResponseWriter, r *http.Request) {
+	h.transit(w, r, ActionPublish)
+}
+func (h *ArticleHandler) Archive(w http.ResponseWriter, r *http.Request) {
+	h.transit(w, r, ActionArchive)
+}
+func (h *ArticleHandler) transit(w http.ResponseWriter, r *http.Request, action string) {
+	id, err := core.GetRequiredString(w, r, 1)
+	if err == nil {
+		change, res, err := h.service.Transit(r.Context(), id, action)
+		if err != nil {
+			h.Error(r.Context(), err.Error())
+			h.Log(r.Context(), h.Resource, action, false, err.Error())
+			http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+			return
+		}
+
+		if res > 0 {
+			h.Log(r.Context(), h.Resource, action, true, fmt.Sprintf("%s '%s' from '%s' to '%s'", action, id, change.From, change.To))
+			core.JSON(w, http.StatusOK, change)
+		} else if res == 0 {
+			h.Log(r.Context(), h.Resource, action, false, fmt.Sprintf("not found '%s'", id))
+			core.JSON(w, http.StatusNotFound, res)
+		} else {
+			h.Log(r.Context(), h.Resource, action, false, fmt.Sprintf("cannot %s '%s' from '%s' to '%s'", action, id, change.From, change.To))
+			core.JSON(w, http.StatusConflict, change)
+		}
+	}
+}
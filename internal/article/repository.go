@@ -8,5 +8,10 @@ type ArticleRepository interface {
 	Update(ctx context.Context, article *Article) (int64, error)
 	Patch(ctx context.Context, article map[string]interface{}) (int64, error)
 	Delete(ctx context.Context, id string) (int64, error)
+	UpdateStatus(ctx context.Context, id string, from string, to string) (int64, error)
+	ExistSlug(ctx context.Context, slug string, id string) (bool, error)
+	ResolveSlug(ctx context.Context, slug string) (*SlugResolution, error)
+	SaveRedirect(ctx context.Context, slug string, id string) (int64, error)
+	DeleteRedirect(ctx context.Context, slug string) (int64, error)
 	Search(ctx context.Context, filter *ArticleFilter, limit int64, offset int64) ([]Article, int64, error)
 }
@@ -3,6 +3,8 @@ package article
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"strings"
 
 	"github.com/core-go/core/tx"
 )
@@ -14,6 +16,8 @@ type ArticleService interface {
 	Patch(ctx context.Context, article map[string]interface{}) (int64, error)
 	Delete(ctx context.Context, id string) (int64, error)
 	Search(ctx context.Context, filter *ArticleFilter, limit int64, offset int64) ([]Article, int64, error)
+	Transit(ctx context.Context, id string, action string) (*StatusChange, int64, error)
+	ResolveSlug(ctx context.Context, slug string) (*SlugResolution, error)
 }
 
 func NewArticleService(db *sql.DB, repository ArticleRepository) *ArticleUseCase {
@@ -29,17 +33,77 @@ func (s *ArticleUseCase) Load(ctx context.Context, id string) (*Article, error)
 	return s.repository.Load(ctx, id)
 }
 func (s *ArticleUseCase) Create(ctx context.Context, article *Article) (int64, error) {
+	if article.Status == nil {
+		status := StatusDraft
+		article.Status = &status
+	}
 	return tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
+		if len(article.Slug) == 0 {
+			slug, err := s.generateSlug(ctx, article.Title, article.Id)
+			if err != nil {
+				return -1, err
+			}
+			article.Slug = slug
+		}
 		return s.repository.Create(ctx, article)
 	})
 }
+
+// Update keeps the current status if the article has no status; the status is checked again in the transaction, -1 if it is changed, because only Transit changes it
 func (s *ArticleUseCase) Update(ctx context.Context, article *Article) (int64, error) {
 	return tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
+		current, err := s.repository.Load(ctx, article.Id)
+		if err != nil || current == nil {
+			return 0, err
+		}
+		if article.Status == nil || len(*article.Status) == 0 {
+			article.Status = current.Status
+		} else if *article.Status != GetStatus(current.Status) {
+			return -1, nil
+		}
+		if len(article.Slug) == 0 {
+			article.Slug = current.Slug
+		}
+		if len(article.Slug) == 0 {
+			if article.Slug, err = s.generateSlug(ctx, article.Title, article.Id); err != nil {
+				return -1, err
+			}
+		}
+		if err = s.redirect(ctx, article.Id, current.Slug, article.Slug); err != nil {
+			return -1, err
+		}
 		return s.repository.Update(ctx, article)
 	})
 }
+
+// Patch cannot change the status, which is checked against the current status in the transaction, -1 if it is changed
 func (s *ArticleUseCase) Patch(ctx context.Context, article map[string]interface{}) (int64, error) {
 	return tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
+		if _, ok := article["status"]; ok {
+			if status, _ := article["status"].(string); len(status) == 0 {
+				delete(article, "status")
+			}
+		}
+		if slug, ok := article["slug"].(string); ok && len(slug) == 0 {
+			delete(article, "slug")
+		}
+		status, hasStatus := article["status"].(string)
+		slug, hasSlug := article["slug"].(string)
+		if hasStatus || hasSlug {
+			id, _ := article["id"].(string)
+			current, err := s.repository.Load(ctx, id)
+			if err != nil || current == nil {
+				return 0, err
+			}
+			if hasStatus && status != GetStatus(current.Status) {
+				return -1, nil
+			}
+			if hasSlug {
+				if err = s.redirect(ctx, id, current.Slug, slug); err != nil {
+					return -1, err
+				}
+			}
+		}
 		return s.repository.Patch(ctx, article)
 	})
 }
@@ -51,3 +115,66 @@ func (s *ArticleUseCase) Delete(ctx context.Context, id string) (int64, error) {
 func (s *ArticleUseCase) Search(ctx context.Context, filter *ArticleFilter, limit int64, offset int64) ([]Article, int64, error) {
 	return s.repository.Search(ctx, filter, limit, offset)
 }
+func (s *ArticleUseCase) Transit(ctx context.Context, id string, action string) (*StatusChange, int64, error) {
+	change := &StatusChange{Id: id, Action: action}
+	res, err := tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
+		article, err := s.repository.Load(ctx, id)
+		if err != nil || article == nil {
+			return 0, err
+		}
+		from := GetStatus(article.Status)
+		to, ok := Transit(action, from)
+		change.From = from
+		change.To = to
+		if !ok {
+			return -1, nil
+		}
+		res, err := s.repository.UpdateStatus(ctx, id, change.From, change.To)
+		if err == nil && res == 0 {
+			return -1, nil
+		}
+		return res, err
+	})
+	return change, res, err
+}
+
+// ResolveSlug returns the article of the slug whatever its status is; the public articles use it and check the status themselves
+func (s *ArticleUseCase) ResolveSlug(ctx context.Context, slug string) (*SlugResolution, error) {
+	return s.repository.ResolveSlug(ctx, slug)
+}
+
+// generateSlug appends a sequence to the slug of the title until it is not used by another article; the slug is cut to keep the sequence within the max length
+func (s *ArticleUseCase) generateSlug(ctx context.Context, title string, id string) (string, error) {
+	base := Slugify(title)
+	if len(base) == 0 {
+		base = Slugify(id)
+	}
+	slug := base
+	for i := 2; ; i++ {
+		exist, err := s.repository.ExistSlug(ctx, slug, id)
+		if err != nil || !exist {
+			return slug, err
+		}
+		suffix := fmt.Sprintf("-%d", i)
+		if len(base)+len(suffix) > maxSlugLength {
+			base = strings.TrimRight(base[:maxSlugLength-len(suffix)], "-")
+		}
+		slug = base + suffix
+	}
+}
+
+// redirect keeps the old slug, so that old links still resolve to the article
+func (s *ArticleUseCase) redirect(ctx context.Context, id string, from string, to string) error {
+	if from == to {
+		return nil
+	}
+	if _, err := s.repository.DeleteRedirect(ctx, to); err != nil {
+		return err
+	}
+	if len(from) > 0 {
+		if _, err := s.repository.SaveRedirect(ctx, from, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
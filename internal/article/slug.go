@@ -0,0 +1,45 @@
+package article
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const maxSlugLength = 200
+
+// SlugResolution is the article of a slug; Redirect is true if the slug is an old slug of the article, which now has Slug
+type SlugResolution struct {
+	Id       string `json:"id" gorm:"column:id"`
+	Slug     string `json:"slug" gorm:"column:slug"`
+	Redirect bool   `json:"redirect" gorm:"column:redirect"`
+}
+
+type ResolveSlug func(ctx context.Context, slug string) (*SlugResolution, error)
+
+// Slugify lowercases the text, removes the diacritics, and joins the words with '-'
+func Slugify(text string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range norm.NFD.String(strings.ToLower(text)) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		if r == 'đ' {
+			r = 'd'
+		}
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+			if b.Len() >= maxSlugLength {
+				break
+			}
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimRight(b.String(), "-")
+}
@@ -2,12 +2,17 @@ package article
 
 import (
 	"database/sql"
+	"github.com/core-go/search"
 	"github.com/lib/pq"
 	"net/http"
 
 	"github.com/core-go/core"
 	v "github.com/core-go/core/validator"
 	"github.com/core-go/sql/query/builder"
+
+	"go-service/internal/tag"
+	"go-service/pkg/export"
+	"go-service/pkg/sanitizer"
 )
 
 type ArticleTransport interface {
@@ -17,9 +22,16 @@ type ArticleTransport interface {
 	Update(w http.ResponseWriter, r *http.Request)
 	Patch(w http.ResponseWriter, r *http.Request)
 	Delete(w http.ResponseWriter, r *http.Request)
+	Export(w http.ResponseWriter, r *http.Request)
+	Submit(w http.ResponseWriter, r *http.Request)
+	Approve(w http.ResponseWriter, r *http.Request)
+	Reject(w http.ResponseWriter, r *http.Request)
+	Publish(w http.ResponseWriter, r *http.Request)
+	Archive(w http.ResponseWriter, r *http.Request)
+	ResolveSlug(w http.ResponseWriter, r *http.Request)
 }
 
-func NewArticleTransport(db *sql.DB, logError core.Log, writeLog core.WriteLog, action *core.ActionConfig) (ArticleTransport, error) {
+func NewArticleTransport(db *sql.DB, logError core.Log, htmlSanitizer *sanitizer.Sanitizer, checkTags tag.Check, writeLog core.WriteLog, action *core.ActionConfig) (ArticleTransport, error) {
 	validator, err := v.NewValidator[*Article]()
 	if err != nil {
 		return nil, err
@@ -29,7 +41,25 @@ func NewArticleTransport(db *sql.DB, logError core.Log, writeLog core.WriteLog,
 	if err != nil {
 		return nil, err
 	}
+	statusValidator := NewStatusValidator(articleRepository.Load, validator.Validate)
+	slugValidator := NewSlugValidator(articleRepository.ExistSlug, statusValidator.Validate)
+	tagValidator := tag.NewValidator[Article](checkTags, func(article *Article) []string {
+		return article.Tags
+	}, slugValidator.Validate)
+	validate := sanitizer.NewValidator[Article](htmlSanitizer, "content", func(article *Article) (*string, *[]sanitizer.Removed) {
+		return &article.Content, &article.Sanitized
+	}, tagValidator)
 	articleService := NewArticleService(db, articleRepository)
-	articleHandler := NewArticleHandler(articleService, logError, validator.Validate, writeLog, action)
+	articleExporter := export.NewExporter[Article, *ArticleFilter](db, "article", func() *ArticleFilter { return &ArticleFilter{Filter: &search.Filter{}} }, queryArticle, logError, writeLog)
+	articleHandler := NewArticleHandler(articleService, logError, validate, articleExporter, writeLog, action)
 	return articleHandler, nil
 }
+
+// NewSlugResolver resolves the slugs for the other modules, such as the public articles
+func NewSlugResolver(db *sql.DB) (ResolveSlug, error) {
+	articleRepository, err := NewArticleAdapter(db, nil, pq.Array)
+	if err != nil {
+		return nil, err
+	}
+	return NewArticleService(db, articleRepository).ResolveSlug, nil
+}
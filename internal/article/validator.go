@@ -0,0 +1,64 @@
+package article
+
+import (
+	"context"
+
+	"github.com/core-go/core"
+)
+
+func NewStatusValidator(load func(ctx context.Context, id string) (*Article, error), validate core.Validate[*Article]) *StatusValidator {
+	return &StatusValidator{load: load, validate: validate}
+}
+
+// StatusValidator rejects status changes through create, update and patch, which must go through the workflow transitions
+type StatusValidator struct {
+	load     func(ctx context.Context, id string) (*Article, error)
+	validate core.Validate[*Article]
+}
+
+func (v *StatusValidator) Validate(ctx context.Context, article *Article) ([]core.ErrorMessage, error) {
+	errors, err := v.validate(ctx, article)
+	if err != nil || article.Status == nil {
+		return errors, err
+	}
+	current, err := v.load(ctx, article.Id)
+	if err != nil {
+		return errors, err
+	}
+	status := StatusDraft
+	if current != nil {
+		status = GetStatus(current.Status)
+	}
+	if *article.Status != status {
+		errors = append(errors, core.ErrorMessage{Field: "status", Code: "transition", Param: status})
+	}
+	return errors, nil
+}
+
+func NewSlugValidator(exist func(ctx context.Context, slug string, id string) (bool, error), validate core.Validate[*Article]) *SlugValidator {
+	return &SlugValidator{exist: exist, validate: validate}
+}
+
+// SlugValidator normalizes the slug edited by users, and rejects the slug used by another article
+type SlugValidator struct {
+	exist    func(ctx context.Context, slug string, id string) (bool, error)
+	validate core.Validate[*Article]
+}
+
+func (v *SlugValidator) Validate(ctx context.Context, article *Article) ([]core.ErrorMessage, error) {
+	if len(article.Slug) > 0 {
+		article.Slug = Slugify(article.Slug)
+	}
+	errors, err := v.validate(ctx, article)
+	if err != nil || len(article.Slug) == 0 {
+		return errors, err
+	}
+	exist, err := v.exist(ctx, article.Slug, article.Id)
+	if err != nil {
+		return errors, err
+	}
+	if exist {
+		errors = append(errors, core.ErrorMessage{Field: "slug", Code: "unique"})
+	}
+	return errors, nil
+}
@@ -0,0 +1,58 @@
+package article
+
+const (
+	StatusDraft     = "D"
+	StatusInReview  = "R"
+	StatusApproved  = "V"
+	StatusPublished = "A"
+	StatusArchived  = "I"
+)
+
+const (
+	ActionSubmit  = "submit"
+	ActionApprove = "approve"
+	ActionReject  = "reject"
+	ActionPublish = "publish"
+	ActionArchive = "archive"
+)
+
+type Transition struct {
+	From []string
+	To   string
+}
+
+var Transitions = map[string]Transition{
+	ActionSubmit:  {From: []string{StatusDraft}, To: StatusInReview},
+	ActionApprove: {From: []string{StatusInReview}, To: StatusApproved},
+	ActionReject:  {From: []string{StatusInReview, StatusApproved}, To: StatusDraft},
+	ActionPublish: {From: []string{StatusApproved}, To: StatusPublished},
+	ActionArchive: {From: []string{StatusPublished}, To: StatusArchived},
+}
+
+type StatusChange struct {
+	Id     string `json:"id,omitempty"`
+	Action string `json:"action,omitempty"`
+	From   string `json:"from,omitempty"`
+	To     string `json:"to,omitempty"`
+}
+
+// GetStatus treats an article without status as a draft
+func GetStatus(status *string) string {
+	if status == nil || len(*status) == 0 {
+		return StatusDraft
+	}
+	return *status
+}
+
+func Transit(action string, from string) (string, bool) {
+	transition, ok := Transitions[action]
+	if !ok {
+		return "", false
+	}
+	for _, s := range transition.From {
+		if s == from {
+			return transition.To, true
+		}
+	}
+	return transition.To, false
+}
@@ -11,5 +11,6 @@ type AuditLog struct {
 	Time     *time.Time `json:"time,omitempty" gorm:"column:time" bson:"time,omitempty" dynamodbav:"time,omitempty" firestore:"time,omitempty"`
 	Status   string     `json:"status,omitempty" gorm:"column:status" bson:"status,omitempty" dynamodbav:"status,omitempty" firestore:"status,omitempty" match:"equal"`
 	Remark   string     `json:"remark,omitempty" gorm:"column:remark" bson:"remark,omitempty" dynamodbav:"remark,omitempty" firestore:"remark,omitempty" validate:"max=255"`
+	ApiKeyId *string    `json:"apiKeyId,omitempty" gorm:"column:api_key_id" bson:"apiKeyId,omitempty" dynamodbav:"apiKeyId,omitempty" firestore:"apiKeyId,omitempty"`
 	Email    *string    `json:"email,omitempty" gorm:"-" bson:"email,omitempty" dynamodbav:"email,omitempty" firestore:"email,omitempty"`
 }
@@ -13,4 +13,5 @@ type AuditLogFilter struct {
 	Actions   []string          `json:"actions,omitempty" gorm:"column:action" bson:"actions,omitempty" dynamodbav:"actions,omitempty" firestore:"actions,omitempty"`
 	Time      *search.TimeRange `json:"time,omitempty" gorm:"column:time" bson:"time,omitempty" dynamodbav:"time,omitempty" firestore:"time,omitempty"`
 	Status    []string          `json:"status,omitempty" gorm:"column:status" bson:"status,omitempty" dynamodbav:"status,omitempty" firestore:"status,omitempty" match:"equal"`
+	ApiKeyId  string            `json:"apiKeyId,omitempty" gorm:"column:api_key_id" bson:"apiKeyId,omitempty" dynamodbav:"apiKeyId,omitempty" firestore:"apiKeyId,omitempty" match:"equal"`
 }
@@ -6,15 +6,18 @@ import (
 
 	"github.com/core-go/core"
 	s "github.com/core-go/search"
+
+	"go-service/pkg/export"
 )
 
-func NewAuditLogHandler(auditLogQuery AuditLogQuery, logError core.Log) *AuditLogHandler {
+func NewAuditLogHandler(auditLogQuery AuditLogQuery, exporter *export.Exporter[*AuditLogFilter], logError core.Log) *AuditLogHandler {
 	paramIndex, filterIndex := s.BuildAttributes(reflect.TypeOf(AuditLogFilter{}))
 	return &AuditLogHandler{
 		query:       auditLogQuery,
 		logError:    logError,
 		paramIndex:  paramIndex,
 		filterIndex: filterIndex,
+		Exporter:    exporter,
 	}
 }
 
@@ -23,6 +26,7 @@ type AuditLogHandler struct {
 	logError    core.Log
 	paramIndex  map[string]int
 	filterIndex int
+	*export.Exporter[*AuditLogFilter]
 }
 
 func (h *AuditLogHandler) Load(w http.ResponseWriter, r *http.Request) {
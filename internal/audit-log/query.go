@@ -16,6 +16,7 @@ import (
 type AuditLogQuery interface {
 	Search(ctx context.Context, filter *AuditLogFilter) ([]AuditLog, int64, error)
 	Load(ctx context.Context, id string) (*AuditLog, error)
+	BuildQuery(filter *AuditLogFilter) (string, []interface{})
 }
 
 type SqlAuditLogQuery struct {
@@ -58,10 +59,7 @@ func (s SqlAuditLogQuery) Search(ctx context.Context, filter *AuditLogFilter) ([
 	if filter.Limit <= 0 {
 		return rows, 0, nil
 	}
-	ftr := convert.ToMap(filter, &s.AuditLogType)
-	ftr["fields"] = s.Fields
-
-	query, params := template.Build(ftr, *s.templates["audit_log"], s.buildParam)
+	query, params := s.BuildQuery(filter)
 	offset := search.GetOffset(filter.Limit, filter.Page)
 	pagingQuery := q.BuildPagingQuery(query, filter.Limit, offset, s.driver)
 	countQuery := q.BuildCountQuery(query)
@@ -91,6 +89,12 @@ func (s SqlAuditLogQuery) Search(ctx context.Context, filter *AuditLogFilter) ([
 	return rows, total, err
 }
 
+func (s SqlAuditLogQuery) BuildQuery(filter *AuditLogFilter) (string, []interface{}) {
+	ftr := convert.ToMap(filter, &s.AuditLogType)
+	ftr["fields"] = s.Fields
+	return template.Build(ftr, *s.templates["audit_log"], s.buildParam)
+}
+
 func toListIds(rows []AuditLog) []string {
 	rs := make([]string, len(rows))
 	for i, row := range rows {
@@ -27,7 +27,7 @@ type CategoryAdapter struct {
 }
 
 func (r *CategoryAdapter) All(ctx context.Context) ([]Category, error) {
-	query := `select * from categories`
+	query := `select * from categories order by sequence, name`
 	var categories []Category
 	err := s.Query(ctx, r.DB, r.Map, &categories, query)
 	return categories, err
@@ -115,6 +115,53 @@ func (r *CategoryAdapter) Delete(ctx context.Context, id string) (int64, error)
 	return res.RowsAffected()
 }
 
+// GetAncestors returns the category and its ancestors, from the root; the visited ids stop the recursion if the parents are in a cycle
+func (r *CategoryAdapter) GetAncestors(ctx context.Context, id string) ([]Category, error) {
+	var categories []Category
+	query := fmt.Sprintf(`with recursive ancestors as (
+		select %s, 0 as depth, array[id] as visited from categories where id = %s
+		union all
+		select %s, a.depth + 1, a.visited || c.id from categories c inner join ancestors a on c.id = a.parent where not c.id = any(a.visited)
+	) select %s from ancestors order by depth desc`, r.Fields, r.BuildParam(1), prefix("c.", r.Fields), r.Fields)
+	err := s.Query(ctx, s.GetTx(ctx, r.DB), r.Map, &categories, query, id)
+	return categories, err
+}
+
+func (r *CategoryAdapter) LoadChildren(ctx context.Context, parent string) ([]Category, error) {
+	var categories []Category
+	query := fmt.Sprintf("select %s from categories where coalesce(parent, '') = %s order by sequence, name", r.Fields, r.BuildParam(1))
+	err := s.Query(ctx, s.GetTx(ctx, r.DB), r.Map, &categories, query, parent)
+	return categories, err
+}
+
+func (r *CategoryAdapter) Move(ctx context.Context, id string, parent string, sequence int) (int64, error) {
+	query := fmt.Sprintf("update categories set parent = %s, sequence = %s, version = coalesce(version, 0) + 1 where id = %s", r.BuildParam(1), r.BuildParam(2), r.BuildParam(3))
+	tx := s.GetTx(ctx, r.DB)
+	res, err := tx.ExecContext(ctx, query, parent, sequence, id)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *CategoryAdapter) UpdateSequence(ctx context.Context, id string, parent string, sequence int) (int64, error) {
+	query := fmt.Sprintf("update categories set sequence = %s, version = coalesce(version, 0) + 1 where id = %s and coalesce(parent, '') = %s", r.BuildParam(1), r.BuildParam(2), r.BuildParam(3))
+	tx := s.GetTx(ctx, r.DB)
+	res, err := tx.ExecContext(ctx, query, sequence, id, parent)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+func prefix(alias string, fields string) string {
+	columns := strings.Split(fields, ",")
+	for i, column := range columns {
+		columns[i] = alias + strings.TrimSpace(column)
+	}
+	return strings.Join(columns, ", ")
+}
+
 func (r *CategoryAdapter) Search(ctx context.Context, filter *CategoryFilter, limit int64, offset int64) ([]Category, int64, error) {
 	var categories []Category
 	if limit <= 0 {
@@ -138,12 +185,18 @@ func (r *CategoryAdapter) Search(ctx context.Context, filter *CategoryFilter, li
 	return categories, total, err
 }
 
+// BuildQuery sorts the categories like the tree, by sequence then name, if the filter has no sort
 func BuildQuery(filter *CategoryFilter) (string, []interface{}) {
 	query := "select * from categories"
 	where, params := BuildFilter(filter)
 	if len(where) > 0 {
 		query = query + " where " + where
 	}
+	if sort := s.BuildSort(filter.Sort, reflect.TypeOf(Category{})); len(sort) > 0 {
+		query = query + sort
+	} else {
+		query = query + " order by sequence, name"
+	}
 	return query, params
 }
 func BuildFilter(filter *CategoryFilter) (string, []interface{}) {
@@ -156,40 +209,29 @@ func BuildFilter(filter *CategoryFilter) (string, []interface{}) {
 		where = append(where, fmt.Sprintf(`id = %s`, buildParam(i)))
 		i++
 	}
-	if filter.SubmittedAt != nil {
-		if filter.SubmittedAt.Min != nil {
-			params = append(params, filter.SubmittedAt.Min)
-			where = append(where, fmt.Sprintf(`submitted_at >= %s`, buildParam(i)))
-			i++
-		}
-		if filter.SubmittedAt.Max != nil {
-			params = append(params, filter.SubmittedAt.Max)
-			where = append(where, fmt.Sprintf(`submitted_at <= %s`, buildParam(i)))
-			i++
-		}
+	if len(filter.Name) > 0 {
+		params = append(params, "%"+escapeLike(filter.Name)+"%")
+		where = append(where, fmt.Sprintf(`name ilike %s`, buildParam(i)))
+		i++
 	}
-	if len(filter.Email) > 0 {
-		q := filter.Email + "%"
-		params = append(params, q)
-		where = append(where, fmt.Sprintf(`email ilike %s`, buildParam(i)))
+	if len(filter.Type) > 0 {
+		params = append(params, filter.Type)
+		where = append(where, fmt.Sprintf(`type = %s`, buildParam(i)))
 		i++
 	}
-	if len(filter.Phone) > 0 {
-		q := filter.Phone + "%"
-		params = append(params, q)
-		where = append(where, fmt.Sprintf(`phone ilike %s`, buildParam(i)))
+	if len(filter.Parent) > 0 {
+		params = append(params, filter.Parent)
+		where = append(where, fmt.Sprintf(`parent = %s`, buildParam(i)))
 		i++
 	}
-	if len(filter.Country) > 0 {
-		q := filter.Country + "%"
-		params = append(params, q)
-		where = append(where, fmt.Sprintf(`country ilike %s`, buildParam(i)))
+	if len(filter.Status) > 0 {
+		params = append(params, filter.Status)
+		where = append(where, fmt.Sprintf(`status = %s`, buildParam(i)))
 		i++
 	}
-	if len(filter.Name) > 0 {
-		q := "%" + filter.Name + "%"
-		params = append(params, q)
-		where = append(where, fmt.Sprintf(`title ilike %s`, buildParam(i)))
+	if len(filter.Path) > 0 {
+		params = append(params, escapeLike(filter.Path)+"%")
+		where = append(where, fmt.Sprintf(`path ilike %s`, buildParam(i)))
 		i++
 	}
 	if len(where) > 0 {
@@ -197,3 +239,7 @@ func BuildFilter(filter *CategoryFilter) (string, []interface{}) {
 	}
 	return "", params
 }
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
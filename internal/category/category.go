@@ -11,4 +11,55 @@ type Category struct {
 	Parent   string `yaml:"parent" mapstructure:"parent" json:"parent,omitempty" gorm:"column:parent" bson:"parent,omitempty" dynamodbav:"parent,omitempty" firestore:"parent,omitempty"`
 	Status   string `yaml:"status" mapstructure:"status" json:"status,omitempty" gorm:"column:status" bson:"status,omitempty" dynamodbav:"status,omitempty" firestore:"status,omitempty"`
 	Version  int64  `yaml:"version" mapstructure:"version" json:"version,omitempty" gorm:"column:version" bson:"version" dynamodbav:"version,omitempty" firestore:"version,omitempty"`
+
+	Children []*Category `yaml:"-" mapstructure:"-" json:"children,omitempty" bson:"-" dynamodbav:"-" firestore:"-"`
+}
+
+type MoveRequest struct {
+	Parent   string `json:"parent"`
+	Sequence int    `json:"sequence,omitempty"`
+}
+
+type ReorderRequest struct {
+	Parent string   `json:"parent"`
+	Ids    []string `json:"ids"`
+}
+
+// BuildTree links the categories to their parents in the order of the list; categories with an unknown parent are shown as roots,
+// and categories in a cycle are not reachable from any root, so they are dropped
+func BuildTree(categories []Category) []*Category {
+	nodes := make(map[string]*Category, len(categories))
+	for i := range categories {
+		nodes[categories[i].Id] = &categories[i]
+	}
+	children := make(map[string][]*Category)
+	roots := make([]*Category, 0)
+	for i := range categories {
+		node := &categories[i]
+		if _, ok := nodes[node.Parent]; ok && len(node.Parent) > 0 {
+			children[node.Parent] = append(children[node.Parent], node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+	var link func(node *Category)
+	link = func(node *Category) {
+		for _, child := range children[node.Id] {
+			node.Children = append(node.Children, child)
+			link(child)
+		}
+	}
+	for _, root := range roots {
+		link(root)
+	}
+	return roots
+}
+
+func contains(categories []Category, id string) bool {
+	for _, c := range categories {
+		if c.Id == id {
+			return true
+		}
+	}
+	return false
 }
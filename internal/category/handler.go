@@ -8,14 +8,16 @@ import (
 	"github.com/core-go/core"
 	b "github.com/core-go/core/builder"
 	"github.com/core-go/search"
+
+	"go-service/pkg/export"
 )
 
-func NewCategoryHandler(service CategoryService, logError core.Log, validate core.Validate[*Category], tracking b.TrackingConfig, writeLog core.WriteLog, action *core.ActionConfig) *CategoryHandler {
+func NewCategoryHandler(service CategoryService, logError core.Log, validate core.Validate[*Category], tracking b.TrackingConfig, exporter *export.Exporter[*CategoryFilter], writeLog core.WriteLog, action *core.ActionConfig) *CategoryHandler {
 	categoryType := reflect.TypeOf(Category{})
 	parameters := search.CreateParameters(reflect.TypeOf(CategoryFilter{}), categoryType)
 	attributes := core.CreateAttributes(categoryType, logError, writeLog, action)
 	builder := b.NewBuilderByConfig[Category](nil, tracking)
-	return &CategoryHandler{service: service, Validate: validate, builder: builder, Attributes: attributes, Parameters: parameters}
+	return &CategoryHandler{service: service, Validate: validate, builder: builder, Attributes: attributes, Parameters: parameters, Exporter: exporter}
 }
 
 type CategoryHandler struct {
@@ -24,6 +26,7 @@ type CategoryHandler struct {
 	builder  core.Builder[Category]
 	*core.Attributes
 	*search.Parameters
+	*export.Exporter[*CategoryFilter]
 }
 
 func (h *CategoryHandler) Load(w http.ResponseWriter, r *http.Request) {
@@ -152,3 +155,77 @@ func (h *CategoryHandler) Search(w http.ResponseWriter, r *http.Request) {
 	}
 	core.JSON(w, http.StatusOK, &search.Result{List: &categories, Total: total})
 }
+func (h *CategoryHandler) GetTree(w http.ResponseWriter, r *http.Request) {
+	tree, err := h.service.GetTree(r.Context())
+	if err != nil {
+		h.Error(r.Context(), fmt.Sprintf("Error to get category tree: %s", err.Error()))
+		http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+		return
+	}
+	core.JSON(w, http.StatusOK, tree)
+}
+func (h *CategoryHandler) GetBreadcrumb(w http.ResponseWriter, r *http.Request) {
+	id, err := core.GetRequiredString(w, r, 1)
+	if err == nil {
+		categories, err := h.service.GetBreadcrumb(r.Context(), id)
+		if err != nil {
+			h.Error(r.Context(), fmt.Sprintf("Error to get breadcrumb of category '%s': %s", id, err.Error()))
+			http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+			return
+		}
+		if len(categories) == 0 {
+			core.JSON(w, http.StatusNotFound, nil)
+			return
+		}
+		core.JSON(w, http.StatusOK, categories)
+	}
+}
+func (h *CategoryHandler) Move(w http.ResponseWriter, r *http.Request) {
+	id, er1 := core.GetRequiredString(w, r, 1)
+	if er1 == nil {
+		req, er2 := core.Decode[MoveRequest](w, r)
+		if er2 == nil {
+			res, err := h.service.Move(r.Context(), id, req.Parent, req.Sequence)
+			if err != nil {
+				h.Error(r.Context(), err.Error())
+				h.Log(r.Context(), h.Resource, "move", false, err.Error())
+				http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+				return
+			}
+
+			if res > 0 {
+				h.Log(r.Context(), h.Resource, "move", true, fmt.Sprintf("move '%s' to '%s'", id, req.Parent))
+				core.JSON(w, http.StatusOK, res)
+			} else if res == 0 {
+				h.Log(r.Context(), h.Resource, "move", false, fmt.Sprintf("not found '%s' or '%s'", id, req.Parent))
+				core.JSON(w, http.StatusNotFound, res)
+			} else {
+				h.Log(r.Context(), h.Resource, "move", false, fmt.Sprintf("cycle '%s' to '%s'", id, req.Parent))
+				core.JSON(w, http.StatusConflict, res)
+			}
+		}
+	}
+}
+func (h *CategoryHandler) Reorder(w http.ResponseWriter, r *http.Request) {
+	req, er1 := core.Decode[ReorderRequest](w, r)
+	if er1 == nil {
+		res, err := h.service.Reorder(r.Context(), req.Parent, req.Ids)
+		if err != nil {
+			h.Error(r.Context(), err.Error())
+			h.Log(r.Context(), h.Resource, "reorder", false, err.Error())
+			http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+			return
+		}
+
+		if res > 0 {
+			h.Log(r.Context(), h.Resource, "reorder", true, fmt.Sprintf("reorder children of '%s'", req.Parent))
+			core.JSON(w, http.StatusOK, res)
+		} else if res == 0 {
+			h.Log(r.Context(), h.Resource, "reorder", false, fmt.Sprintf("not found children of '%s'", req.Parent))
+			core.JSON(w, http.StatusNotFound, res)
+		} else {
+			h.Log(r.Context(), h.Resource, "reorder", false, fmt.Sprintf("conflict children of '%s'", req.Parent))
+			core.JSON(w, http.StatusConflict, res)
+		}
+	}
+}
@@ -3,10 +3,15 @@ package category
 import "context"
 
 type CategoryRepository interface {
+	All(ctx context.Context) ([]Category, error)
 	Load(ctx context.Context, id string) (*Category, error)
 	Create(ctx context.Context, category *Category) (int64, error)
 	Update(ctx context.Context, category *Category) (int64, error)
 	Patch(ctx context.Context, category map[string]interface{}) (int64, error)
 	Delete(ctx context.Context, id string) (int64, error)
 	Search(ctx context.Context, filter *CategoryFilter, limit int64, offset int64) ([]Category, int64, error)
+	GetAncestors(ctx context.Context, id string) ([]Category, error)
+	LoadChildren(ctx context.Context, parent string) ([]Category, error)
+	Move(ctx context.Context, id string, parent string, sequence int) (int64, error)
+	UpdateSequence(ctx context.Context, id string, parent string, sequence int) (int64, error)
 }
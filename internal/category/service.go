@@ -14,6 +14,10 @@ type CategoryService interface {
 	Patch(ctx context.Context, category map[string]interface{}) (int64, error)
 	Delete(ctx context.Context, id string) (int64, error)
 	Search(ctx context.Context, filter *CategoryFilter, limit int64, offset int64) ([]Category, int64, error)
+	GetTree(ctx context.Context) ([]*Category, error)
+	GetBreadcrumb(ctx context.Context, id string) ([]Category, error)
+	Move(ctx context.Context, id string, parent string, sequence int) (int64, error)
+	Reorder(ctx context.Context, parent string, ids []string) (int64, error)
 }
 
 func NewCategoryService(db *sql.DB, repository CategoryRepository) *CategoryUseCase {
@@ -35,11 +39,20 @@ func (s *CategoryUseCase) Create(ctx context.Context, category *Category) (int64
 }
 func (s *CategoryUseCase) Update(ctx context.Context, category *Category) (int64, error) {
 	return tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
+		if cycle, err := s.isCycle(ctx, category.Id, category.Parent); err != nil || cycle {
+			return -1, err
+		}
 		return s.repository.Update(ctx, category)
 	})
 }
 func (s *CategoryUseCase) Patch(ctx context.Context, category map[string]interface{}) (int64, error) {
 	return tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
+		if parent, ok := category["parent"].(string); ok {
+			id, _ := category["id"].(string)
+			if cycle, err := s.isCycle(ctx, id, parent); err != nil || cycle {
+				return -1, err
+			}
+		}
 		return s.repository.Patch(ctx, category)
 	})
 }
@@ -51,3 +64,95 @@ func (s *CategoryUseCase) Delete(ctx context.Context, id string) (int64, error)
 func (s *CategoryUseCase) Search(ctx context.Context, filter *CategoryFilter, limit int64, offset int64) ([]Category, int64, error) {
 	return s.repository.Search(ctx, filter, limit, offset)
 }
+func (s *CategoryUseCase) GetTree(ctx context.Context) ([]*Category, error) {
+	categories, err := s.repository.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return BuildTree(categories), nil
+}
+
+// GetBreadcrumb returns the path from the root to the category
+func (s *CategoryUseCase) GetBreadcrumb(ctx context.Context, id string) ([]Category, error) {
+	return s.repository.GetAncestors(ctx, id)
+}
+
+// Move changes the parent of the category; when the sequence is not set, the category is added after its new siblings.
+// It returns 0 if the category or the parent is not found, and -1 if the parent is the category itself or one of its descendants.
+func (s *CategoryUseCase) Move(ctx context.Context, id string, parent string, sequence int) (int64, error) {
+	return tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
+		category, err := s.repository.Load(ctx, id)
+		if err != nil || category == nil {
+			return 0, err
+		}
+		if len(parent) > 0 {
+			ancestors, err := s.repository.GetAncestors(ctx, parent)
+			if err != nil || len(ancestors) == 0 {
+				return 0, err
+			}
+			if contains(ancestors, id) {
+				return -1, nil
+			}
+		}
+		if sequence <= 0 {
+			siblings, err := s.repository.LoadChildren(ctx, parent)
+			if err != nil {
+				return -1, err
+			}
+			sequence = 1
+			for _, sibling := range siblings {
+				if sibling.Id != id && sibling.Sequence >= sequence {
+					sequence = sibling.Sequence + 1
+				}
+			}
+		}
+		return s.repository.Move(ctx, id, parent, sequence)
+	})
+}
+
+// Reorder sets the sequence of the children of the parent to the order of the ids, which must list all the children.
+// It returns -1 if the ids are not the children of the parent.
+func (s *CategoryUseCase) Reorder(ctx context.Context, parent string, ids []string) (int64, error) {
+	return tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
+		children, err := s.repository.LoadChildren(ctx, parent)
+		if err != nil {
+			return -1, err
+		}
+		if len(children) == 0 {
+			return 0, nil
+		}
+		unique := make(map[string]bool, len(ids))
+		for _, id := range ids {
+			if !contains(children, id) {
+				return -1, nil
+			}
+			unique[id] = true
+		}
+		if len(unique) != len(children) || len(ids) != len(children) {
+			return -1, nil
+		}
+		var total int64
+		for i, id := range ids {
+			res, err := s.repository.UpdateSequence(ctx, id, parent, i+1)
+			if err != nil {
+				return -1, err
+			}
+			total = total + res
+		}
+		return total, nil
+	})
+}
+
+func (s *CategoryUseCase) isCycle(ctx context.Context, id string, parent string) (bool, error) {
+	if len(parent) == 0 {
+		return false, nil
+	}
+	if parent == id {
+		return true, nil
+	}
+	ancestors, err := s.repository.GetAncestors(ctx, parent)
+	if err != nil {
+		return false, err
+	}
+	return contains(ancestors, id), nil
+}
@@ -7,7 +7,9 @@ import (
 	"github.com/core-go/core"
 	b "github.com/core-go/core/builder"
 	v "github.com/core-go/core/validator"
-	"github.com/core-go/sql/query/builder"
+	"github.com/core-go/search"
+
+	"go-service/pkg/export"
 )
 
 type CategoryTransport interface {
@@ -17,6 +19,11 @@ type CategoryTransport interface {
 	Update(w http.ResponseWriter, r *http.Request)
 	Patch(w http.ResponseWriter, r *http.Request)
 	Delete(w http.ResponseWriter, r *http.Request)
+	Export(w http.ResponseWriter, r *http.Request)
+	GetTree(w http.ResponseWriter, r *http.Request)
+	GetBreadcrumb(w http.ResponseWriter, r *http.Request)
+	Move(w http.ResponseWriter, r *http.Request)
+	Reorder(w http.ResponseWriter, r *http.Request)
 }
 
 func NewCategoryTransport(db *sql.DB, logError core.Log, tracking b.TrackingConfig, writeLog core.WriteLog, action *core.ActionConfig) (CategoryTransport, error) {
@@ -24,12 +31,13 @@ func NewCategoryTransport(db *sql.DB, logError core.Log, tracking b.TrackingConf
 	if err != nil {
 		return nil, err
 	}
-	queryCategory := builder.UseQuery[Category, *CategoryFilter](db, "categories")
+	queryCategory := BuildQuery
 	categoryRepository, err := NewCategoryAdapter(db, queryCategory)
 	if err != nil {
 		return nil, err
 	}
 	categoryService := NewCategoryService(db, categoryRepository)
-	categoryHandler := NewCategoryHandler(categoryService, logError, validator.Validate, tracking, writeLog, action)
+	categoryExporter := export.NewExporter[Category, *CategoryFilter](db, "category", func() *CategoryFilter { return &CategoryFilter{Filter: &search.Filter{}} }, queryCategory, logError, writeLog)
+	categoryHandler := NewCategoryHandler(categoryService, logError, validator.Validate, tracking, categoryExporter, writeLog, action)
 	return categoryHandler, nil
 }
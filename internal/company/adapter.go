@@ -0,0 +1,143 @@
+package company
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+
+	s "github.com/core-go/sql"
+)
+
+func NewCompanyAdapter(db *sql.DB, buildQuery func(*CompanyFilter) (string, []interface{})) (*CompanyAdapter, error) {
+	parameters, err := s.CreateParameters(reflect.TypeOf(Company{}), db)
+	if err != nil {
+		return nil, err
+	}
+	return &CompanyAdapter{DB: db, Parameters: parameters, BuildQuery: buildQuery}, nil
+}
+
+type CompanyAdapter struct {
+	DB         *sql.DB
+	BuildQuery func(*CompanyFilter) (string, []interface{})
+	*s.Parameters
+}
+
+func (r *CompanyAdapter) Load(ctx context.Context, id string) (*Company, error) {
+	var companies []Company
+	query := fmt.Sprintf("select %s from companies where id = %s limit 1", r.Fields, r.BuildParam(1))
+	err := s.Query(ctx, r.DB, r.Map, &companies, query, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(companies) > 0 {
+		return &companies[0], nil
+	}
+	return nil, nil
+}
+
+func (r *CompanyAdapter) Create(ctx context.Context, company *Company) (int64, error) {
+	query, args := s.BuildToInsert("companies", company, r.BuildParam, r.Schema)
+	tx := s.GetTx(ctx, r.DB)
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *CompanyAdapter) Update(ctx context.Context, company *Company) (int64, error) {
+	query, args := s.BuildToUpdate("companies", company, r.BuildParam, r.Schema)
+	tx := s.GetTx(ctx, r.DB)
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *CompanyAdapter) Patch(ctx context.Context, company map[string]interface{}) (int64, error) {
+	colMap := s.JSONToColumns(company, r.JsonColumnMap)
+	query, args := s.BuildToPatch("companies", colMap, r.Keys, r.BuildParam)
+	tx := s.GetTx(ctx, r.DB)
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+// Delete refuses to delete the company which still has jobs
+func (r *CompanyAdapter) Delete(ctx context.Context, id string) (int64, error) {
+	tx := s.GetTx(ctx, r.DB)
+	exist, err := s.Exist(ctx, tx, fmt.Sprintf("select id from jobs where company_id = %s limit 1", r.BuildParam(1)), id)
+	if err != nil || exist {
+		return -1, err
+	}
+	query := fmt.Sprintf("delete from companies where id = %s", r.BuildParam(1))
+	res, err := tx.ExecContext(ctx, query, id)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *CompanyAdapter) Search(ctx context.Context, filter *CompanyFilter, limit int64, offset int64) ([]Company, int64, error) {
+	var companies []Company
+	if limit <= 0 {
+		return companies, 0, nil
+	}
+	query, params := r.BuildQuery(filter)
+	pagingQuery := s.BuildPagingQuery(query, limit, offset)
+	countQuery := s.BuildCountQuery(query)
+
+	row := r.DB.QueryRowContext(ctx, countQuery, params...)
+	if row.Err() != nil {
+		return companies, 0, row.Err()
+	}
+	var total int64
+	err := row.Scan(&total)
+	if err != nil || total == 0 {
+		return companies, total, err
+	}
+
+	err = s.Query(ctx, r.DB, r.Map, &companies, pagingQuery, params...)
+	return companies, total, err
+}
+
+func BuildQuery(filter *CompanyFilter) (string, []interface{}) {
+	query := "select * from companies"
+	where, params := BuildFilter(filter)
+	if len(where) > 0 {
+		query = query + " where " + where
+	}
+	return query, params
+}
+func BuildFilter(filter *CompanyFilter) (string, []interface{}) {
+	buildParam := s.BuildDollarParam
+	var where []string
+	var params []interface{}
+	i := 1
+	if len(filter.Id) > 0 {
+		params = append(params, filter.Id)
+		where = append(where, fmt.Sprintf(`id = %s`, buildParam(i)))
+		i++
+	}
+	if len(filter.Name) > 0 {
+		q := "%" + filter.Name + "%"
+		params = append(params, q)
+		where = append(where, fmt.Sprintf(`name ilike %s`, buildParam(i)))
+		i++
+	}
+	if len(filter.Slogan) > 0 {
+		q := "%" + filter.Slogan + "%"
+		params = append(params, q)
+		where = append(where, fmt.Sprintf(`slogan ilike %s`, buildParam(i)))
+		i++
+	}
+	if len(where) > 0 {
+		return strings.Join(where, " and "), params
+	}
+	return "", params
+}
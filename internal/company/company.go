@@ -0,0 +1,11 @@
+package company
+
+type Company struct {
+	Id          string  `yaml:"id" mapstructure:"id" json:"id,omitempty" gorm:"column:id;primary_key" bson:"_id,omitempty" dynamodbav:"id,omitempty" firestore:"id,omitempty" validate:"required,max=40"`
+	Name        string  `yaml:"name" mapstructure:"name" json:"name,omitempty" gorm:"column:name" bson:"name,omitempty" dynamodbav:"name,omitempty" firestore:"name,omitempty" validate:"required,max=300"`
+	Description *string `yaml:"description" mapstructure:"description" json:"description,omitempty" gorm:"column:description" bson:"description,omitempty" dynamodbav:"description,omitempty" firestore:"description,omitempty" validate:"omitempty,max=2000"`
+	Slogan      *string `yaml:"slogan" mapstructure:"slogan" json:"slogan,omitempty" gorm:"column:slogan" bson:"slogan,omitempty" dynamodbav:"slogan,omitempty" firestore:"slogan,omitempty" validate:"omitempty,max=300"`
+	ImageURL    *string `yaml:"image_url" mapstructure:"image_url" json:"imageURL,omitempty" gorm:"column:image_url" bson:"imageURL,omitempty" dynamodbav:"imageURL,omitempty" firestore:"imageURL,omitempty" validate:"omitempty,max=500"`
+	CoverURL    *string `yaml:"cover_url" mapstructure:"cover_url" json:"coverURL,omitempty" gorm:"column:cover_url" bson:"coverURL,omitempty" dynamodbav:"coverURL,omitempty" firestore:"coverURL,omitempty" validate:"omitempty,max=500"`
+	Sequence    int     `yaml:"sequence" mapstructure:"sequence" json:"sequence,omitempty" gorm:"column:sequence" bson:"sequence" dynamodbav:"sequence,omitempty" firestore:"sequence,omitempty"`
+}
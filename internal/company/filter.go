@@ -0,0 +1,12 @@
+package company
+
+import (
+	"github.com/core-go/search"
+)
+
+type CompanyFilter struct {
+	*search.Filter
+	Id     string `yaml:"id" mapstructure:"id" json:"id" gorm:"column:id;primary_key" bson:"_id" dynamodbav:"id" firestore:"-" avro:"id" validate:"max=40" operator:"="`
+	Name   string `yaml:"name" mapstructure:"name" json:"name" gorm:"column:name" bson:"name" dynamodbav:"name" firestore:"name" avro:"name" validate:"max=300" operator:"like" q:"like"`
+	Slogan string `yaml:"slogan" mapstructure:"slogan" json:"slogan" gorm:"column:slogan" bson:"slogan" dynamodbav:"slogan" firestore:"slogan" avro:"slogan" validate:"max=300" operator:"like"`
+}
@@ -0,0 +1,154 @@
+package company
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/core-go/core"
+	"github.com/core-go/search"
+
+	"go-service/pkg/export"
+)
+
+func NewCompanyHandler(service CompanyService, logError core.Log, validate core.Validate[*Company], exporter *export.Exporter[*CompanyFilter], writeLog core.WriteLog, action *core.ActionConfig) *CompanyHandler {
+	companyType := reflect.TypeOf(Company{})
+	parameters := search.CreateParameters(reflect.TypeOf(CompanyFilter{}), companyType)
+	attributes := core.CreateAttributes(companyType, logError, writeLog, action)
+	return &CompanyHandler{service: service, Validate: validate, Attributes: attributes, Parameters: parameters, Exporter: exporter}
+}
+
+type CompanyHandler struct {
+	service  CompanyService
+	Validate core.Validate[*Company]
+	*core.Attributes
+	*search.Parameters
+	*export.Exporter[*CompanyFilter]
+}
+
+func (h *CompanyHandler) Load(w http.ResponseWriter, r *http.Request) {
+	id, err := core.GetRequiredString(w, r)
+	if err == nil {
+		company, err := h.service.Load(r.Context(), id)
+		if err != nil {
+			h.Error(r.Context(), fmt.Sprintf("Error to get company '%s': %s", id, err.Error()))
+			http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+			return
+		}
+		core.JSON(w, core.IsFound(company), company)
+	}
+}
+func (h *CompanyHandler) Create(w http.ResponseWriter, r *http.Request) {
+	company, er1 := core.Decode[Company](w, r)
+	if er1 == nil {
+		errors, er2 := h.Validate(r.Context(), &company)
+		if !core.HasError(w, r, errors, er2, h.Error, &company, h.Log, h.Resource, h.Action.Create) {
+			res, er3 := h.service.Create(r.Context(), &company)
+			if er3 != nil {
+				h.Error(r.Context(), er3.Error())
+				h.Log(r.Context(), h.Resource, h.Action.Update, false, er3.Error())
+				http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+				return
+			}
+
+			if res > 0 {
+				h.Log(r.Context(), h.Resource, h.Action.Update, true, fmt.Sprintf("create '%s'", company.Id))
+				core.JSON(w, http.StatusCreated, company)
+			} else {
+				h.Log(r.Context(), h.Resource, h.Action.Update, false, fmt.Sprintf("conflict '%s'", company.Id))
+				core.JSON(w, http.StatusConflict, res)
+			}
+		}
+	}
+}
+func (h *CompanyHandler) Update(w http.ResponseWriter, r *http.Request) {
+	company, er1 := core.DecodeAndCheckId[Company](w, r, h.Keys, h.Indexes)
+	if er1 == nil {
+		errors, er2 := h.Validate(r.Context(), &company)
+		if !core.HasError(w, r, errors, er2, h.Error, &company, h.Log, h.Resource, h.Action.Update) {
+			res, err := h.service.Update(r.Context(), &company)
+			if err != nil {
+				h.Error(r.Context(), err.Error())
+				h.Log(r.Context(), h.Resource, h.Action.Update, false, err.Error())
+				http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+				return
+			}
+
+			if res > 0 {
+				h.Log(r.Context(), h.Resource, h.Action.Update, true, fmt.Sprintf("%s '%s'", h.Action.Update, company.Id))
+				core.JSON(w, http.StatusOK, company)
+			} else if res == 0 {
+				h.Log(r.Context(), h.Resource, h.Action.Update, false, fmt.Sprintf("not found '%s'", company.Id))
+				core.JSON(w, http.StatusNotFound, res)
+			} else {
+				h.Log(r.Context(), h.Resource, h.Action.Update, false, fmt.Sprintf("conflict '%s'", company.Id))
+				core.JSON(w, http.StatusConflict, res)
+			}
+		}
+	}
+}
+func (h *CompanyHandler) Patch(w http.ResponseWriter, r *http.Request) {
+	r, company, jsonCompany, er1 := core.BuildMapAndCheckId[Company](w, r, h.Keys, h.Indexes)
+	if er1 == nil {
+		errors, er2 := h.Validate(r.Context(), &company)
+		if !core.HasError(w, r, errors, er2, h.Error, jsonCompany, h.Log, h.Resource, h.Action.Patch) {
+			res, err := h.service.Patch(r.Context(), jsonCompany)
+			if err != nil {
+				h.Error(r.Context(), err.Error())
+				h.Log(r.Context(), h.Resource, h.Action.Patch, false, err.Error())
+				http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+				return
+			}
+
+			if res > 0 {
+				h.Log(r.Context(), h.Resource, h.Action.Patch, true, fmt.Sprintf("%s '%s'", h.Action.Patch, company.Id))
+				core.JSON(w, http.StatusOK, jsonCompany)
+			} else if res == 0 {
+				h.Log(r.Context(), h.Resource, h.Action.Patch, false, fmt.Sprintf("not found '%s'", company.Id))
+				core.JSON(w, http.StatusNotFound, res)
+			} else {
+				h.Log(r.Context(), h.Resource, h.Action.Patch, false, fmt.Sprintf("conflict '%s'", company.Id))
+				core.JSON(w, http.StatusConflict, res)
+			}
+		}
+	}
+}
+func (h *CompanyHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := core.GetRequiredString(w, r)
+	if err == nil {
+		res, err := h.service.Delete(r.Context(), id)
+		if err != nil {
+			h.Error(r.Context(), err.Error())
+			h.Log(r.Context(), h.Resource, h.Action.Delete, false, err.Error())
+			http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+			return
+		}
+
+		if res > 0 {
+			h.Log(r.Context(), h.Resource, h.Action.Delete, true, fmt.Sprintf("%s '%s'", h.Action.Delete, id))
+			core.JSON(w, http.StatusOK, res)
+		} else if res == 0 {
+			h.Log(r.Context(), h.Resource, h.Action.Delete, false, fmt.Sprintf("not found '%s'", id))
+			core.JSON(w, http.StatusNotFound, res)
+		} else {
+			h.Log(r.Context(), h.Resource, h.Action.Delete, false, fmt.Sprintf("has jobs '%s'", id))
+			core.JSON(w, http.StatusConflict, res)
+		}
+	}
+}
+func (h *CompanyHandler) Search(w http.ResponseWriter, r *http.Request) {
+	filter := CompanyFilter{Filter: &search.Filter{}}
+	err := search.Decode(r, &filter, h.ParamIndex, h.FilterIndex)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	offset := search.GetOffset(filter.Limit, filter.Page)
+	companies, total, err := h.service.Search(r.Context(), &filter, filter.Limit, offset)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	core.JSON(w, http.StatusOK, &search.Result{List: &companies, Total: total})
+}
@@ -0,0 +1,53 @@
+package company
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/core-go/core/tx"
+)
+
+type CompanyService interface {
+	Load(ctx context.Context, id string) (*Company, error)
+	Create(ctx context.Context, company *Company) (int64, error)
+	Update(ctx context.Context, company *Company) (int64, error)
+	Patch(ctx context.Context, company map[string]interface{}) (int64, error)
+	Delete(ctx context.Context, id string) (int64, error)
+	Search(ctx context.Context, filter *CompanyFilter, limit int64, offset int64) ([]Company, int64, error)
+}
+
+func NewCompanyService(db *sql.DB, repository CompanyRepository) *CompanyUseCase {
+	return &CompanyUseCase{db: db, repository: repository}
+}
+
+type CompanyUseCase struct {
+	db         *sql.DB
+	repository CompanyRepository
+}
+
+func (s *CompanyUseCase) Load(ctx context.Context, id string) (*Company, error) {
+	return s.repository.Load(ctx, id)
+}
+func (s *CompanyUseCase) Create(ctx context.Context, company *Company) (int64, error) {
+	return tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
+		return s.repository.Create(ctx, company)
+	})
+}
+func (s *CompanyUseCase) Update(ctx context.Context, company *Company) (int64, error) {
+	return tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
+		return s.repository.Update(ctx, company)
+	})
+}
+func (s *CompanyUseCase) Patch(ctx context.Context, company map[string]interface{}) (int64, error) {
+	return tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
+		return s.repository.Patch(ctx, company)
+	})
+}
+func (s *CompanyUseCase) Delete(ctx context.Context, id string) (int64, error) {
+	return tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
+		return s.repository.Delete(ctx, id)
+	})
+}
+func (s *CompanyUseCase) Search(ctx context.Context, filter *CompanyFilter, limit int64, offset int64) ([]Company, int64, error) {
+	return s.repository.Search(ctx, filter, limit, offset)
+}
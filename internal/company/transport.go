@@ -0,0 +1,39 @@
+package company
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/core-go/core"
+	v "github.com/core-go/core/validator"
+	"github.com/core-go/search"
+	"github.com/core-go/sql/query/builder"
+
+	"go-service/pkg/export"
+)
+
+type CompanyTransport interface {
+	Search(w http.ResponseWriter, r *http.Request)
+	Load(w http.ResponseWriter, r *http.Request)
+	Create(w http.ResponseWriter, r *http.Request)
+	Update(w http.ResponseWriter, r *http.Request)
+	Patch(w http.ResponseWriter, r *http.Request)
+	Delete(w http.ResponseWriter, r *http.Request)
+	Export(w http.ResponseWriter, r *http.Request)
+}
+
+func NewCompanyTransport(db *sql.DB, logError core.Log, writeLog core.WriteLog, action *core.ActionConfig) (CompanyTransport, error) {
+	validator, err := v.NewValidator[*Company]()
+	if err != nil {
+		return nil, err
+	}
+	queryCompany := builder.UseQuery[Company, *CompanyFilter](db, "companies")
+	companyRepository, err := NewCompanyAdapter(db, queryCompany)
+	if err != nil {
+		return nil, err
+	}
+	companyService := NewCompanyService(db, companyRepository)
+	companyExporter := export.NewExporter[Company, *CompanyFilter](db, "company", func() *CompanyFilter { return &CompanyFilter{Filter: &search.Filter{}} }, queryCompany, logError, writeLog)
+	companyHandler := NewCompanyHandler(companyService, logError, validator.Validate, companyExporter, writeLog, action)
+	return companyHandler, nil
+}
@@ -6,22 +6,34 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	s "github.com/core-go/sql"
 )
 
-func NewContactAdapter(db *sql.DB, buildQuery func(*ContactFilter) (string, []interface{})) (*ContactAdapter, error) {
+func NewContactAdapter(db *sql.DB, buildQuery func(*ContactFilter) (string, []interface{}), toArray s.Array) (*ContactAdapter, error) {
 	parameters, err := s.CreateParameters(reflect.TypeOf(Contact{}), db)
 	if err != nil {
 		return nil, err
 	}
-	return &ContactAdapter{DB: db, Parameters: parameters, BuildQuery: buildQuery}, nil
+	noteParameters, err := s.CreateParameters(reflect.TypeOf(Note{}), db)
+	if err != nil {
+		return nil, err
+	}
+	mergeParameters, err := s.CreateParameters(reflect.TypeOf(Merge{}), db)
+	if err != nil {
+		return nil, err
+	}
+	return &ContactAdapter{DB: db, Parameters: parameters, NoteParameters: noteParameters, MergeParameters: mergeParameters, BuildQuery: buildQuery, Array: toArray}, nil
 }
 
 type ContactAdapter struct {
 	DB         *sql.DB
 	BuildQuery func(*ContactFilter) (string, []interface{})
 	*s.Parameters
+	NoteParameters  *s.Parameters
+	MergeParameters *s.Parameters
+	Array           s.Array
 }
 
 func (r *ContactAdapter) All(ctx context.Context) ([]Contact, error) {
@@ -54,27 +66,6 @@ func (r *ContactAdapter) Create(ctx context.Context, contact *Contact) (int64, e
 	return res.RowsAffected()
 }
 
-func (r *ContactAdapter) Update(ctx context.Context, contact *Contact) (int64, error) {
-	query, args := s.BuildToUpdate("contacts", contact, r.BuildParam, r.Schema)
-	tx := s.GetTx(ctx, r.DB)
-	res, err := tx.ExecContext(ctx, query, args...)
-	if err != nil {
-		return -1, err
-	}
-	return res.RowsAffected()
-}
-
-func (r *ContactAdapter) Patch(ctx context.Context, contact map[string]interface{}) (int64, error) {
-	colMap := s.JSONToColumns(contact, r.JsonColumnMap)
-	query, args := s.BuildToPatch("contacts", colMap, r.Keys, r.BuildParam)
-	tx := s.GetTx(ctx, r.DB)
-	res, err := tx.ExecContext(ctx, query, args...)
-	if err != nil {
-		return -1, err
-	}
-	return res.RowsAffected()
-}
-
 func (r *ContactAdapter) Delete(ctx context.Context, id string) (int64, error) {
 	query := fmt.Sprintf("delete from contacts where id = %s", r.BuildParam(1))
 	tx := s.GetTx(ctx, r.DB)
@@ -108,6 +99,163 @@ func (r *ContactAdapter) Search(ctx context.Context, filter *ContactFilter, limi
 	return contacts, total, err
 }
 
+func (r *ContactAdapter) Assign(ctx context.Context, id string, userId string, assignedAt time.Time) (int64, error) {
+	query := fmt.Sprintf("update contacts set assigned_to = %s, assigned_at = %s where id = %s", r.BuildParam(1), r.BuildParam(2), r.BuildParam(3))
+	tx := s.GetTx(ctx, r.DB)
+	res, err := tx.ExecContext(ctx, query, userId, assignedAt, id)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+// MarkContacted only stamps the inquiry which has not been contacted yet, so the first contact is kept
+func (r *ContactAdapter) MarkContacted(ctx context.Context, id string, userId string, contactedAt time.Time) (int64, error) {
+	query := fmt.Sprintf("update contacts set contacted_by = %s, contacted_at = %s where id = %s and contacted_at is null", r.BuildParam(1), r.BuildParam(2), r.BuildParam(3))
+	tx := s.GetTx(ctx, r.DB)
+	res, err := tx.ExecContext(ctx, query, userId, contactedAt, id)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *ContactAdapter) ExistUser(ctx context.Context, userId string) (bool, error) {
+	return s.Exist(ctx, r.DB, fmt.Sprintf("select user_id from users where user_id = %s and status = 'A' limit 1", r.BuildParam(1)), userId)
+}
+
+func (r *ContactAdapter) CreateNote(ctx context.Context, note *Note) (int64, error) {
+	query, args := s.BuildToInsert("contact_notes", note, r.NoteParameters.BuildParam, r.NoteParameters.Schema)
+	tx := s.GetTx(ctx, r.DB)
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *ContactAdapter) LoadNotes(ctx context.Context, id string) ([]Note, error) {
+	notes := make([]Note, 0)
+	query := fmt.Sprintf("select %s from contact_notes where contact_id = %s order by created_at", r.NoteParameters.Fields, r.BuildParam(1))
+	err := s.Query(ctx, r.DB, r.NoteParameters.Map, &notes, query, id)
+	return notes, err
+}
+
+// Overdue loads the inquiries which have not been contacted and were submitted before the time, the oldest first
+func (r *ContactAdapter) Overdue(ctx context.Context, before time.Time) ([]Contact, error) {
+	contacts := make([]Contact, 0)
+	query := fmt.Sprintf("select %s from contacts where contacted_at is null and merged_into is null and submitted_at < %s order by submitted_at", r.Fields, r.BuildParam(1))
+	err := s.Query(ctx, r.DB, r.Map, &contacts, query, before)
+	return contacts, err
+}
+
+// FindDuplicate returns the id of the latest inquiry with the same email or phone, submitted since the time
+func (r *ContactAdapter) FindDuplicate(ctx context.Context, email string, phone string, since time.Time) (string, error) {
+	var ids []string
+	query := fmt.Sprintf(`select id from contacts where (lower(email) = lower(%s) or (%s <> '' and phone = %s)) and submitted_at >= %s
+		order by submitted_at desc limit 1`, r.BuildParam(1), r.BuildParam(2), r.BuildParam(2), r.BuildParam(3))
+	rows, err := r.DB.QueryContext(ctx, query, email, phone, since)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id string
+		if err = rows.Scan(&id); err != nil {
+			return "", err
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return "", rows.Err()
+	}
+	return ids[0], rows.Err()
+}
+
+// LoadUnmerged loads the contacts which have not been merged into another one, the oldest first, so the first of a group is the original inquiry
+func (r *ContactAdapter) LoadUnmerged(ctx context.Context) ([]Contact, error) {
+	var contacts []Contact
+	query := fmt.Sprintf("select %s from contacts where merged_into is null order by submitted_at nulls first, id", r.Fields)
+	err := s.Query(ctx, r.DB, r.Map, &contacts, query)
+	return contacts, err
+}
+
+func (r *ContactAdapter) LoadByIds(ctx context.Context, ids []string) ([]Contact, error) {
+	var contacts []Contact
+	query := fmt.Sprintf("select %s from contacts where id = any(%s) order by submitted_at nulls first, id", r.Fields, r.BuildParam(1))
+	err := s.Query(ctx, r.DB, r.Map, &contacts, query, r.Array(ids))
+	return contacts, err
+}
+
+// Link marks the contacts as merged into the primary one, only if they have not been merged yet
+func (r *ContactAdapter) Link(ctx context.Context, ids []string, primaryId string) (int64, error) {
+	query := fmt.Sprintf("update contacts set merged_into = %s where id = any(%s) and merged_into is null", r.BuildParam(1), r.BuildParam(2))
+	tx := s.GetTx(ctx, r.DB)
+	res, err := tx.ExecContext(ctx, query, primaryId, r.Array(ids))
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *ContactAdapter) Unlink(ctx context.Context, ids []string, primaryId string) (int64, error) {
+	query := fmt.Sprintf("update contacts set merged_into = null where id = any(%s) and merged_into = %s", r.BuildParam(1), r.BuildParam(2))
+	tx := s.GetTx(ctx, r.DB)
+	res, err := tx.ExecContext(ctx, query, r.Array(ids), primaryId)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *ContactAdapter) DeleteNotes(ctx context.Context, ids []string) (int64, error) {
+	query := fmt.Sprintf("delete from contact_notes where id = any(%s)", r.BuildParam(1))
+	tx := s.GetTx(ctx, r.DB)
+	res, err := tx.ExecContext(ctx, query, r.Array(ids))
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *ContactAdapter) CreateMerge(ctx context.Context, merge *Merge) (int64, error) {
+	query, args := s.BuildToInsertWithArray("contact_merges", merge, r.MergeParameters.BuildParam, true, r.Array, r.MergeParameters.Schema)
+	tx := s.GetTx(ctx, r.DB)
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *ContactAdapter) LoadMerge(ctx context.Context, id string) (*Merge, error) {
+	var merges []Merge
+	query := fmt.Sprintf("select %s from contact_merges where id = %s limit 1", r.MergeParameters.Fields, r.BuildParam(1))
+	err := s.QueryWithArray(ctx, r.DB, r.MergeParameters.Map, &merges, r.Array, query, id)
+	if err != nil || len(merges) == 0 {
+		return nil, err
+	}
+	return &merges[0], nil
+}
+
+func (r *ContactAdapter) LoadMerges(ctx context.Context, primaryId string) ([]Merge, error) {
+	merges := make([]Merge, 0)
+	query := fmt.Sprintf("select %s from contact_merges where primary_id = %s order by merged_at desc", r.MergeParameters.Fields, r.BuildParam(1))
+	err := s.QueryWithArray(ctx, r.DB, r.MergeParameters.Map, &merges, r.Array, query, primaryId)
+	return merges, err
+}
+
+// UndoMerge stamps the merge as undone, only once
+func (r *ContactAdapter) UndoMerge(ctx context.Context, id string, undoneBy string, undoneAt time.Time) (int64, error) {
+	query := fmt.Sprintf("update contact_merges set undone_by = %s, undone_at = %s where id = %s and undone_at is null", r.BuildParam(1), r.BuildParam(2), r.BuildParam(3))
+	tx := s.GetTx(ctx, r.DB)
+	res, err := tx.ExecContext(ctx, query, undoneBy, undoneAt, id)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
 func BuildQuery(filter *ContactFilter) (string, []interface{}) {
 	query := "select * from contacts"
 	where, params := BuildFilter(filter)
@@ -162,6 +310,11 @@ func BuildFilter(filter *ContactFilter) (string, []interface{}) {
 		where = append(where, fmt.Sprintf(`title ilike %s`, buildParam(i)))
 		i++
 	}
+	if len(filter.AssignedTo) > 0 {
+		params = append(params, filter.AssignedTo)
+		where = append(where, fmt.Sprintf(`assigned_to = %s`, buildParam(i)))
+		i++
+	}
 	if len(where) > 0 {
 		return strings.Join(where, " and "), params
 	}
@@ -0,0 +1,65 @@
+package contact
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type ChallengeConfig struct {
+	Url     string `yaml:"url" mapstructure:"url" json:"url,omitempty"`
+	Secret  string `yaml:"secret" mapstructure:"secret" json:"secret,omitempty"`
+	Timeout int64  `yaml:"timeout" mapstructure:"timeout" json:"timeout,omitempty"`
+}
+
+// Verify checks the challenge token, such as a captcha response, which the public site submits with the form
+type Verify func(ctx context.Context, token string, ip string) (bool, error)
+
+// NewSiteVerifier verifies the token with the siteverify API, which reCAPTCHA, hCaptcha and Turnstile have in common.
+// It returns nil if there is no url, so the challenge is not required.
+func NewSiteVerifier(conf ChallengeConfig) *SiteVerifier {
+	if len(conf.Url) == 0 {
+		return nil
+	}
+	timeout := conf.Timeout
+	if timeout <= 0 {
+		timeout = 5000
+	}
+	return &SiteVerifier{Url: conf.Url, Secret: conf.Secret, Client: &http.Client{Timeout: time.Duration(timeout) * time.Millisecond}}
+}
+
+type SiteVerifier struct {
+	Url    string
+	Secret string
+	Client *http.Client
+}
+
+func (v *SiteVerifier) Verify(ctx context.Context, token string, ip string) (bool, error) {
+	if len(token) == 0 {
+		return false, nil
+	}
+	form := url.Values{"secret": {v.Secret}, "response": {token}}
+	if len(ip) > 0 {
+		form.Set("remoteip", ip)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.Url, strings.NewReader(form.Encode()))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	res, err := v.Client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer res.Body.Close()
+	var result struct {
+		Success bool `json:"success"`
+	}
+	if err = json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return false, err
+	}
+	return result.Success, nil
+}
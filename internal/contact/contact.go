@@ -1,6 +1,11 @@
 package contact
 
-import "time"
+import (
+	"strings"
+	"time"
+
+	"go-service/pkg/ratelimit"
+)
 
 type Contact struct {
 	Id          string     `yaml:"id" mapstructure:"id" json:"id" gorm:"column:id;primary_key" bson:"_id" dynamodbav:"id" firestore:"-" avro:"id" validate:"max=40" operator:"="`
@@ -14,4 +19,60 @@ type Contact struct {
 	SubmittedAt *time.Time `yaml:"submitted_at" json:"submittedAt,omitempty" gorm:"column:submitted_at" bson:"submittedAt,omitempty" dynamodbav:"submittedAt,omitempty" firestore:"submittedAt,omitempty"`
 	ContactedAt *time.Time `yaml:"contacted_at" json:"contactedAt,omitempty" gorm:"column:contacted_at" bson:"contactedAt,omitempty" dynamodbav:"contactedAt,omitempty" firestore:"contactedAt,omitempty"`
 	ContactedBy *string    `yaml:"contacted_by" json:"contactedBy,omitempty" gorm:"column:contacted_by" bson:"contactedBy,omitempty" dynamodbav:"contactedBy,omitempty" firestore:"contactedBy,omitempty"`
+	AssignedTo  *string    `yaml:"assigned_to" json:"assignedTo,omitempty" gorm:"column:assigned_to" bson:"assignedTo,omitempty" dynamodbav:"assignedTo,omitempty" firestore:"assignedTo,omitempty"`
+	AssignedAt  *time.Time `yaml:"assigned_at" json:"assignedAt,omitempty" gorm:"column:assigned_at" bson:"assignedAt,omitempty" dynamodbav:"assignedAt,omitempty" firestore:"assignedAt,omitempty"`
+	DuplicateOf *string    `yaml:"duplicate_of" json:"duplicateOf,omitempty" gorm:"column:duplicate_of" bson:"duplicateOf,omitempty" dynamodbav:"duplicateOf,omitempty" firestore:"duplicateOf,omitempty"`
+	MergedInto  *string    `yaml:"merged_into" json:"mergedInto,omitempty" gorm:"column:merged_into" bson:"mergedInto,omitempty" dynamodbav:"mergedInto,omitempty" firestore:"mergedInto,omitempty"`
+	Notes       []Note     `yaml:"notes" json:"notes,omitempty" bson:"notes,omitempty" dynamodbav:"notes,omitempty" firestore:"notes,omitempty"`
+}
+
+type Config struct {
+	SLA             int64            `yaml:"sla" mapstructure:"sla" json:"sla,omitempty"`
+	DuplicateWindow int64            `yaml:"duplicate_window" mapstructure:"duplicate_window" json:"duplicateWindow,omitempty"`
+	RateLimit       ratelimit.Config `yaml:"rate_limit" mapstructure:"rate_limit" json:"rateLimit,omitempty"`
+	Challenge       ChallengeConfig  `yaml:"challenge" mapstructure:"challenge" json:"challenge,omitempty"`
+	MergeGrace      int64            `yaml:"merge_grace" mapstructure:"merge_grace" json:"mergeGrace,omitempty"`
+}
+
+// Submission is the contact form of the public site. Website is a honeypot, which is hidden from people, so only bots fill it in.
+type Submission struct {
+	Name      string `json:"name"`
+	Country   string `json:"country"`
+	Company   string `json:"company"`
+	JobTitle  string `json:"jobTitle"`
+	Email     string `json:"email"`
+	Phone     string `json:"phone"`
+	Message   string `json:"message"`
+	Website   string `json:"website,omitempty"`
+	Challenge string `json:"challenge,omitempty"`
+}
+
+func (s *Submission) ToContact() *Contact {
+	return &Contact{
+		Name:     strings.TrimSpace(s.Name),
+		Country:  strings.TrimSpace(s.Country),
+		Company:  strings.TrimSpace(s.Company),
+		JobTitle: strings.TrimSpace(s.JobTitle),
+		Email:    strings.TrimSpace(s.Email),
+		Phone:    strings.TrimSpace(s.Phone),
+		Message:  strings.TrimSpace(s.Message),
+	}
+}
+
+type SubmitResult struct {
+	Id          string     `json:"id"`
+	SubmittedAt *time.Time `json:"submittedAt,omitempty"`
+}
+
+// Note is a follow-up of the inquiry, written by a backoffice user
+type Note struct {
+	Id        string     `json:"id" gorm:"column:id;primary_key"`
+	ContactId string     `json:"contactId" gorm:"column:contact_id"`
+	Note      string     `json:"note" gorm:"column:note" validate:"required,max=2000"`
+	CreatedBy *string    `json:"createdBy,omitempty" gorm:"column:created_by"`
+	CreatedAt *time.Time `json:"createdAt,omitempty" gorm:"column:created_at"`
+}
+
+type AssignRequest struct {
+	UserId string `json:"userId"`
 }
@@ -14,4 +14,5 @@ type ContactFilter struct {
 	Email       string            `yaml:"email" mapstructure:"email" json:"email" gorm:"column:email" bson:"email" dynamodbav:"email" firestore:"email" avro:"email" validate:"email,max=120"`
 	Phone       string            `yaml:"phone" mapstructure:"phone" json:"phone" gorm:"column:phone" bson:"phone" dynamodbav:"phone" firestore:"phone" avro:"phone" validate:"required,phone,max=18" operator:"like"`
 	SubmittedAt *search.TimeRange `yaml:"submitted_at" json:"submittedAt,omitempty" gorm:"column:submitted_at" bson:"submittedAt,omitempty" dynamodbav:"submittedAt,omitempty" firestore:"submittedAt,omitempty"`
+	AssignedTo  string            `yaml:"assigned_to" json:"assignedTo,omitempty" gorm:"column:assigned_to" bson:"assignedTo,omitempty" dynamodbav:"assignedTo,omitempty" firestore:"assignedTo,omitempty" operator:"="`
 }
@@ -4,23 +4,33 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/core-go/core"
 	"github.com/core-go/search"
+
+	"go-service/pkg/export"
+	"go-service/pkg/ratelimit"
 )
 
-func NewContactHandler(service ContactService, logError core.Log, validate core.Validate[*Contact], writeLog core.WriteLog, action *core.ActionConfig) *ContactHandler {
+func NewContactHandler(service ContactService, logError core.Log, validate core.Validate[*Contact], validateNote core.Validate[*Note], limiter *ratelimit.Limiter, verify Verify, exporter *export.Exporter[*ContactFilter], writeLog core.WriteLog, action *core.ActionConfig) *ContactHandler {
 	contactType := reflect.TypeOf(Contact{})
 	parameters := search.CreateParameters(reflect.TypeOf(ContactFilter{}), contactType)
 	attributes := core.CreateAttributes(contactType, logError, writeLog, action)
-	return &ContactHandler{service: service, Validate: validate, Attributes: attributes, Parameters: parameters}
+	return &ContactHandler{service: service, Validate: validate, ValidateNote: validateNote, limiter: limiter, verify: verify, Attributes: attributes, Parameters: parameters, Exporter: exporter}
 }
 
 type ContactHandler struct {
-	service  ContactService
-	Validate core.Validate[*Contact]
+	service      ContactService
+	Validate     core.Validate[*Contact]
+	ValidateNote core.Validate[*Note]
+	limiter      *ratelimit.Limiter
+	verify       Verify
 	*core.Attributes
 	*search.Parameters
+	*export.Exporter[*ContactFilter]
 }
 
 func (h *ContactHandler) Load(w http.ResponseWriter, r *http.Request) {
@@ -58,94 +68,271 @@ func (h *ContactHandler) Create(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 }
-func (h *ContactHandler) Update(w http.ResponseWriter, r *http.Request) {
-	contact, er1 := core.DecodeAndCheckId[Contact](w, r, h.Keys, h.Indexes)
+func (h *ContactHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := core.GetRequiredString(w, r)
+	if err == nil {
+		res, err := h.service.Delete(r.Context(), id)
+		if err != nil {
+			h.Error(r.Context(), err.Error())
+			h.Log(r.Context(), h.Resource, h.Action.Delete, false, err.Error())
+			http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+			return
+		}
+
+		if res > 0 {
+			h.Log(r.Context(), h.Resource, h.Action.Delete, true, fmt.Sprintf("%s '%s'", h.Action.Delete, id))
+			core.JSON(w, http.StatusOK, res)
+		} else if res == 0 {
+			h.Log(r.Context(), h.Resource, h.Action.Delete, false, fmt.Sprintf("not found '%s'", id))
+			core.JSON(w, http.StatusNotFound, res)
+		} else {
+			h.Log(r.Context(), h.Resource, h.Action.Delete, false, fmt.Sprintf("conflict '%s'", id))
+			core.JSON(w, http.StatusConflict, res)
+		}
+	}
+}
+func (h *ContactHandler) Search(w http.ResponseWriter, r *http.Request) {
+	filter := ContactFilter{Filter: &search.Filter{}}
+	err := search.Decode(r, &filter, h.ParamIndex, h.FilterIndex)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	offset := search.GetOffset(filter.Limit, filter.Page)
+	contacts, total, err := h.service.Search(r.Context(), &filter, filter.Limit, offset)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	core.JSON(w, http.StatusOK, &search.Result{List: &contacts, Total: total})
+}
+func (h *ContactHandler) Assign(w http.ResponseWriter, r *http.Request) {
+	id, er1 := core.GetRequiredString(w, r, 1)
 	if er1 == nil {
-		errors, er2 := h.Validate(r.Context(), &contact)
-		if !core.HasError(w, r, errors, er2, h.Error, &contact, h.Log, h.Resource, h.Action.Update) {
-			res, err := h.service.Update(r.Context(), &contact)
+		req, er2 := core.Decode[AssignRequest](w, r)
+		if er2 == nil {
+			if len(req.UserId) == 0 {
+				core.JSON(w, http.StatusUnprocessableEntity, []core.ErrorMessage{{Field: "userId", Code: "required"}})
+				return
+			}
+			res, err := h.service.Assign(r.Context(), id, req.UserId)
 			if err != nil {
 				h.Error(r.Context(), err.Error())
-				h.Log(r.Context(), h.Resource, h.Action.Update, false, err.Error())
+				h.Log(r.Context(), h.Resource, "assign", false, err.Error())
 				http.Error(w, core.InternalServerError, http.StatusInternalServerError)
 				return
 			}
 
 			if res > 0 {
-				h.Log(r.Context(), h.Resource, h.Action.Update, true, fmt.Sprintf("%s '%s'", h.Action.Update, contact.Id))
-				core.JSON(w, http.StatusOK, contact)
+				h.Log(r.Context(), h.Resource, "assign", true, fmt.Sprintf("assign '%s' to '%s'", id, req.UserId))
+				core.JSON(w, http.StatusOK, res)
 			} else if res == 0 {
-				h.Log(r.Context(), h.Resource, h.Action.Update, false, fmt.Sprintf("not found '%s'", contact.Id))
+				h.Log(r.Context(), h.Resource, "assign", false, fmt.Sprintf("not found '%s'", id))
 				core.JSON(w, http.StatusNotFound, res)
 			} else {
-				h.Log(r.Context(), h.Resource, h.Action.Update, false, fmt.Sprintf("conflict '%s'", contact.Id))
+				h.Log(r.Context(), h.Resource, "assign", false, fmt.Sprintf("user not found '%s'", req.UserId))
+				core.JSON(w, http.StatusUnprocessableEntity, []core.ErrorMessage{{Field: "userId", Code: "exist"}})
+			}
+		}
+	}
+}
+func (h *ContactHandler) MarkContacted(w http.ResponseWriter, r *http.Request) {
+	id, err := core.GetRequiredString(w, r, 1)
+	if err == nil {
+		contact, res, err := h.service.MarkContacted(r.Context(), id)
+		if err != nil {
+			h.Error(r.Context(), err.Error())
+			h.Log(r.Context(), h.Resource, "contacted", false, err.Error())
+			http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+			return
+		}
+
+		if res > 0 {
+			h.Log(r.Context(), h.Resource, "contacted", true, fmt.Sprintf("contacted '%s'", id))
+			core.JSON(w, http.StatusOK, contact)
+		} else if res == 0 {
+			h.Log(r.Context(), h.Resource, "contacted", false, fmt.Sprintf("not found '%s'", id))
+			core.JSON(w, http.StatusNotFound, res)
+		} else {
+			h.Log(r.Context(), h.Resource, "contacted", false, fmt.Sprintf("already contacted '%s'", id))
+			core.JSON(w, http.StatusConflict, contact)
+		}
+	}
+}
+func (h *ContactHandler) GetNotes(w http.ResponseWriter, r *http.Request) {
+	id, err := core.GetRequiredString(w, r, 1)
+	if err == nil {
+		notes, err := h.service.GetNotes(r.Context(), id)
+		if err != nil {
+			h.Error(r.Context(), fmt.Sprintf("Error to get notes of contact '%s': %s", id, err.Error()))
+			http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+			return
+		}
+		core.JSON(w, http.StatusOK, notes)
+	}
+}
+func (h *ContactHandler) AddNote(w http.ResponseWriter, r *http.Request) {
+	id, er1 := core.GetRequiredString(w, r, 1)
+	if er1 == nil {
+		note, er2 := core.Decode[Note](w, r)
+		if er2 == nil {
+			errors, er3 := h.ValidateNote(r.Context(), &note)
+			if !core.HasError(w, r, errors, er3, h.Error, &note, h.Log, h.Resource, "note") {
+				res, err := h.service.AddNote(r.Context(), id, &note)
+				if err != nil {
+					h.Error(r.Context(), err.Error())
+					h.Log(r.Context(), h.Resource, "note", false, err.Error())
+					http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+					return
+				}
+
+				if res > 0 {
+					h.Log(r.Context(), h.Resource, "note", true, fmt.Sprintf("note '%s' of '%s'", note.Id, id))
+					core.JSON(w, http.StatusCreated, note)
+				} else {
+					h.Log(r.Context(), h.Resource, "note", false, fmt.Sprintf("not found '%s'", id))
+					core.JSON(w, http.StatusNotFound, res)
+				}
+			}
+		}
+	}
+}
+func (h *ContactHandler) Overdue(w http.ResponseWriter, r *http.Request) {
+	contacts, err := h.service.Overdue(r.Context())
+	if err != nil {
+		h.Error(r.Context(), fmt.Sprintf("Error to get overdue contacts: %s", err.Error()))
+		http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+		return
+	}
+	core.JSON(w, http.StatusOK, contacts)
+}
+func (h *ContactHandler) Submit(w http.ResponseWriter, r *http.Request) {
+	ip := core.GetRemoteIp(r)
+	if ok, wait := h.limiter.Allow(ip); !ok {
+		h.Log(r.Context(), h.Resource, "submit", false, fmt.Sprintf("too many requests from '%s'", ip))
+		w.Header().Set("Retry-After", strconv.FormatInt(int64(wait/time.Second)+1, 10))
+		core.JSON(w, http.StatusTooManyRequests, nil)
+		return
+	}
+	submission, er1 := core.Decode[Submission](w, r)
+	if er1 == nil {
+		if len(submission.Website) > 0 {
+			// the bot gets the same response as a person, so it does not learn that it was caught
+			h.Log(r.Context(), h.Resource, "submit", false, fmt.Sprintf("honeypot from '%s'", ip))
+			now := time.Now()
+			core.JSON(w, http.StatusCreated, &SubmitResult{Id: strconv.FormatInt(now.UnixNano(), 36), SubmittedAt: &now})
+			return
+		}
+		if h.verify != nil {
+			ok, err := h.verify(r.Context(), submission.Challenge, ip)
+			if err != nil {
+				h.Error(r.Context(), fmt.Sprintf("Error to verify challenge: %s", err.Error()))
+				http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+				return
+			}
+			if !ok {
+				h.Log(r.Context(), h.Resource, "submit", false, fmt.Sprintf("challenge failed from '%s'", ip))
+				core.JSON(w, http.StatusUnprocessableEntity, []core.ErrorMessage{{Field: "challenge", Code: "challenge"}})
+				return
+			}
+		}
+		contact := submission.ToContact()
+		errors, er2 := h.Validate(r.Context(), contact)
+		if !core.HasError(w, r, errors, er2, h.Error, contact, h.Log, h.Resource, "submit") {
+			res, err := h.service.Submit(r.Context(), contact)
+			if err != nil {
+				h.Error(r.Context(), err.Error())
+				h.Log(r.Context(), h.Resource, "submit", false, err.Error())
+				http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+				return
+			}
+
+			if res > 0 {
+				if contact.DuplicateOf != nil {
+					h.Log(r.Context(), h.Resource, "submit", true, fmt.Sprintf("submit '%s' duplicate of '%s'", contact.Id, *contact.DuplicateOf))
+				} else {
+					h.Log(r.Context(), h.Resource, "submit", true, fmt.Sprintf("submit '%s'", contact.Id))
+				}
+				core.JSON(w, http.StatusCreated, &SubmitResult{Id: contact.Id, SubmittedAt: contact.SubmittedAt})
+			} else {
+				h.Log(r.Context(), h.Resource, "submit", false, fmt.Sprintf("conflict '%s'", contact.Id))
 				core.JSON(w, http.StatusConflict, res)
 			}
 		}
 	}
 }
-func (h *ContactHandler) Patch(w http.ResponseWriter, r *http.Request) {
-	r, contact, jsonContact, er1 := core.BuildMapAndCheckId[Contact](w, r, h.Keys, h.Indexes)
+func (h *ContactHandler) GetDuplicates(w http.ResponseWriter, r *http.Request) {
+	groups, err := h.service.GetDuplicates(r.Context())
+	if err != nil {
+		h.Error(r.Context(), fmt.Sprintf("Error to get duplicate contacts: %s", err.Error()))
+		http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+		return
+	}
+	core.JSON(w, http.StatusOK, groups)
+}
+func (h *ContactHandler) Merge(w http.ResponseWriter, r *http.Request) {
+	id, er1 := core.GetRequiredString(w, r, 1)
 	if er1 == nil {
-		errors, er2 := h.Validate(r.Context(), &contact)
-		if !core.HasError(w, r, errors, er2, h.Error, jsonContact, h.Log, h.Resource, h.Action.Patch) {
-			res, err := h.service.Patch(r.Context(), jsonContact)
+		req, er2 := core.Decode[MergeRequest](w, r)
+		if er2 == nil {
+			if len(distinct(req.Ids, id)) == 0 {
+				core.JSON(w, http.StatusUnprocessableEntity, []core.ErrorMessage{{Field: "ids", Code: "required"}})
+				return
+			}
+			merge, res, err := h.service.Merge(r.Context(), id, req.Ids)
 			if err != nil {
 				h.Error(r.Context(), err.Error())
-				h.Log(r.Context(), h.Resource, h.Action.Patch, false, err.Error())
+				h.Log(r.Context(), h.Resource, "merge", false, err.Error())
 				http.Error(w, core.InternalServerError, http.StatusInternalServerError)
 				return
 			}
 
 			if res > 0 {
-				h.Log(r.Context(), h.Resource, h.Action.Patch, true, fmt.Sprintf("%s '%s'", h.Action.Patch, contact.Id))
-				core.JSON(w, http.StatusOK, jsonContact)
+				h.Log(r.Context(), h.Resource, "merge", true, fmt.Sprintf("merge '%s' into '%s' by '%s'", strings.Join(merge.MergedIds, ","), id, merge.Id))
+				core.JSON(w, http.StatusOK, merge)
 			} else if res == 0 {
-				h.Log(r.Context(), h.Resource, h.Action.Patch, false, fmt.Sprintf("not found '%s'", contact.Id))
+				h.Log(r.Context(), h.Resource, "merge", false, fmt.Sprintf("not found '%s' or '%s'", id, strings.Join(req.Ids, ",")))
 				core.JSON(w, http.StatusNotFound, res)
 			} else {
-				h.Log(r.Context(), h.Resource, h.Action.Patch, false, fmt.Sprintf("conflict '%s'", contact.Id))
+				h.Log(r.Context(), h.Resource, "merge", false, fmt.Sprintf("already merged '%s' or '%s'", id, strings.Join(req.Ids, ",")))
 				core.JSON(w, http.StatusConflict, res)
 			}
 		}
 	}
 }
-func (h *ContactHandler) Delete(w http.ResponseWriter, r *http.Request) {
-	id, err := core.GetRequiredString(w, r)
+func (h *ContactHandler) GetMerges(w http.ResponseWriter, r *http.Request) {
+	id, err := core.GetRequiredString(w, r, 1)
 	if err == nil {
-		res, err := h.service.Delete(r.Context(), id)
+		merges, err := h.service.GetMerges(r.Context(), id)
+		if err != nil {
+			h.Error(r.Context(), fmt.Sprintf("Error to get merges of contact '%s': %s", id, err.Error()))
+			http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+			return
+		}
+		core.JSON(w, http.StatusOK, merges)
+	}
+}
+func (h *ContactHandler) UndoMerge(w http.ResponseWriter, r *http.Request) {
+	id, err := core.GetRequiredString(w, r, 1)
+	if err == nil {
+		merge, res, err := h.service.UndoMerge(r.Context(), id)
 		if err != nil {
 			h.Error(r.Context(), err.Error())
-			h.Log(r.Context(), h.Resource, h.Action.Delete, false, err.Error())
+			h.Log(r.Context(), h.Resource, "unmerge", false, err.Error())
 			http.Error(w, core.InternalServerError, http.StatusInternalServerError)
 			return
 		}
 
 		if res > 0 {
-			h.Log(r.Context(), h.Resource, h.Action.Delete, true, fmt.Sprintf("%s '%s'", h.Action.Delete, id))
-			core.JSON(w, http.StatusOK, res)
+			h.Log(r.Context(), h.Resource, "unmerge", true, fmt.Sprintf("unmerge '%s' from '%s' by '%s'", strings.Join(merge.MergedIds, ","), merge.PrimaryId, id))
+			core.JSON(w, http.StatusOK, merge)
 		} else if res == 0 {
-			h.Log(r.Context(), h.Resource, h.Action.Delete, false, fmt.Sprintf("not found '%s'", id))
+			h.Log(r.Context(), h.Resource, "unmerge", false, fmt.Sprintf("not found '%s'", id))
 			core.JSON(w, http.StatusNotFound, res)
 		} else {
-			h.Log(r.Context(), h.Resource, h.Action.Delete, false, fmt.Sprintf("conflict '%s'", id))
-			core.JSON(w, http.StatusConflict, res)
+			h.Log(r.Context(), h.Resource, "unmerge", false, fmt.Sprintf("already undone or expired '%s'", id))
+			core.JSON(w, http.StatusConflict, merge)
 		}
 	}
 }
-func (h *ContactHandler) Search(w http.ResponseWriter, r *http.Request) {
-	filter := ContactFilter{Filter: &search.Filter{}}
-	err := search.Decode(r, &filter, h.ParamIndex, h.FilterIndex)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
-	}
-
-	offset := search.GetOffset(filter.Limit, filter.Page)
-	contacts, total, err := h.service.Search(r.Context(), &filter, filter.Limit, offset)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	core.JSON(w, http.StatusOK, &search.Result{List: &contacts, Total: total})
-}
@@ -0,0 +1,130 @@
+package contact
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	ReasonEmail = "email"
+	ReasonPhone = "phone"
+)
+
+type DuplicateGroup struct {
+	Reasons  []string  `json:"reasons"`
+	Contacts []Contact `json:"contacts"`
+}
+
+type MergeRequest struct {
+	Ids []string `json:"ids"`
+}
+
+// Merge is the audit of merging contacts into the primary one, which keeps what is needed to undo it
+type Merge struct {
+	Id        string     `json:"id" gorm:"column:id;primary_key"`
+	PrimaryId string     `json:"primaryId" gorm:"column:primary_id"`
+	MergedIds []string   `json:"mergedIds" gorm:"column:merged_ids"`
+	NoteIds   []string   `json:"noteIds,omitempty" gorm:"column:note_ids"`
+	MergedBy  *string    `json:"mergedBy,omitempty" gorm:"column:merged_by"`
+	MergedAt  *time.Time `json:"mergedAt,omitempty" gorm:"column:merged_at"`
+	UndoneBy  *string    `json:"undoneBy,omitempty" gorm:"column:undone_by"`
+	UndoneAt  *time.Time `json:"undoneAt,omitempty" gorm:"column:undone_at"`
+}
+
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// NormalizePhone keeps the digits only, so "93 334-7686" and "933347686" are the same
+func NormalizePhone(phone string) string {
+	var b strings.Builder
+	for _, r := range phone {
+		if unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func NormalizeName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// GroupDuplicates groups the contacts with the same email, or with the same phone and name.
+// The phone alone is not enough, because the same placeholder phone is often typed by different people.
+func GroupDuplicates(contacts []Contact) []DuplicateGroup {
+	parent := make([]int, len(contacts))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	reasons := make(map[int]map[string]bool)
+	union := func(i, j int, reason string) {
+		a, b := find(i), find(j)
+		if a != b {
+			parent[b] = a
+		}
+		if reasons[i] == nil {
+			reasons[i] = make(map[string]bool)
+		}
+		reasons[i][reason] = true
+	}
+	emails := make(map[string]int)
+	phones := make(map[string]int)
+	for i, contact := range contacts {
+		if email := NormalizeEmail(contact.Email); len(email) > 0 {
+			if j, ok := emails[email]; ok {
+				union(j, i, ReasonEmail)
+			} else {
+				emails[email] = i
+			}
+		}
+		phone := NormalizePhone(contact.Phone)
+		name := NormalizeName(contact.Name)
+		if len(phone) >= 7 && len(name) > 0 {
+			key := phone + "|" + name
+			if j, ok := phones[key]; ok {
+				union(j, i, ReasonPhone)
+			} else {
+				phones[key] = i
+			}
+		}
+	}
+	members := make(map[int][]int)
+	var roots []int
+	for i := range contacts {
+		root := find(i)
+		if _, ok := members[root]; !ok {
+			roots = append(roots, root)
+		}
+		members[root] = append(members[root], i)
+	}
+	groups := make([]DuplicateGroup, 0)
+	for _, root := range roots {
+		if len(members[root]) < 2 {
+			continue
+		}
+		group := DuplicateGroup{Reasons: make([]string, 0)}
+		found := make(map[string]bool)
+		for _, i := range members[root] {
+			group.Contacts = append(group.Contacts, contacts[i])
+			for reason := range reasons[i] {
+				if !found[reason] {
+					found[reason] = true
+					group.Reasons = append(group.Reasons, reason)
+				}
+			}
+		}
+		sort.Strings(group.Reasons)
+		groups = append(groups, group)
+	}
+	return groups
+}
@@ -1,12 +1,29 @@
 package contact
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type ContactRepository interface {
 	Load(ctx context.Context, id string) (*Contact, error)
 	Create(ctx context.Context, contact *Contact) (int64, error)
-	Update(ctx context.Context, contact *Contact) (int64, error)
-	Patch(ctx context.Context, contact map[string]interface{}) (int64, error)
 	Delete(ctx context.Context, id string) (int64, error)
 	Search(ctx context.Context, filter *ContactFilter, limit int64, offset int64) ([]Contact, int64, error)
+	Assign(ctx context.Context, id string, userId string, assignedAt time.Time) (int64, error)
+	MarkContacted(ctx context.Context, id string, userId string, contactedAt time.Time) (int64, error)
+	ExistUser(ctx context.Context, userId string) (bool, error)
+	CreateNote(ctx context.Context, note *Note) (int64, error)
+	LoadNotes(ctx context.Context, id string) ([]Note, error)
+	Overdue(ctx context.Context, before time.Time) ([]Contact, error)
+	FindDuplicate(ctx context.Context, email string, phone string, since time.Time) (string, error)
+	LoadUnmerged(ctx context.Context) ([]Contact, error)
+	LoadByIds(ctx context.Context, ids []string) ([]Contact, error)
+	Link(ctx context.Context, ids []string, primaryId string) (int64, error)
+	Unlink(ctx context.Context, ids []string, primaryId string) (int64, error)
+	DeleteNotes(ctx context.Context, ids []string) (int64, error)
+	CreateMerge(ctx context.Context, merge *Merge) (int64, error)
+	LoadMerge(ctx context.Context, id string) (*Merge, error)
+	LoadMerges(ctx context.Context, primaryId string) ([]Merge, error)
+	UndoMerge(ctx context.Context, id string, undoneBy string, undoneAt time.Time) (int64, error)
 }
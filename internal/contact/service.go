@@ -3,6 +3,9 @@ package contact
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/core-go/core/tx"
 )
@@ -10,44 +13,273 @@ import (
 type ContactService interface {
 	Load(ctx context.Context, id string) (*Contact, error)
 	Create(ctx context.Context, contact *Contact) (int64, error)
-	Update(ctx context.Context, contact *Contact) (int64, error)
-	Patch(ctx context.Context, contact map[string]interface{}) (int64, error)
 	Delete(ctx context.Context, id string) (int64, error)
 	Search(ctx context.Context, filter *ContactFilter, limit int64, offset int64) ([]Contact, int64, error)
+	Assign(ctx context.Context, id string, userId string) (int64, error)
+	MarkContacted(ctx context.Context, id string) (*Contact, int64, error)
+	AddNote(ctx context.Context, id string, note *Note) (int64, error)
+	GetNotes(ctx context.Context, id string) ([]Note, error)
+	Overdue(ctx context.Context) ([]Contact, error)
+	Submit(ctx context.Context, contact *Contact) (int64, error)
+	GetDuplicates(ctx context.Context) ([]DuplicateGroup, error)
+	Merge(ctx context.Context, primaryId string, ids []string) (*Merge, int64, error)
+	GetMerges(ctx context.Context, primaryId string) ([]Merge, error)
+	UndoMerge(ctx context.Context, id string) (*Merge, int64, error)
 }
 
-func NewContactService(db *sql.DB, repository ContactRepository) *ContactUseCase {
-	return &ContactUseCase{db: db, repository: repository}
+func NewContactService(db *sql.DB, repository ContactRepository, generateId func(context.Context) (string, error), userKey string, conf Config) *ContactUseCase {
+	return &ContactUseCase{db: db, repository: repository, generateId: generateId, userKey: userKey, sla: conf.SLA, duplicateWindow: conf.DuplicateWindow, mergeGrace: conf.MergeGrace}
 }
 
 type ContactUseCase struct {
-	db         *sql.DB
-	repository ContactRepository
+	db              *sql.DB
+	repository      ContactRepository
+	generateId      func(context.Context) (string, error)
+	userKey         string
+	sla             int64
+	duplicateWindow int64
+	mergeGrace      int64
 }
 
 func (s *ContactUseCase) Load(ctx context.Context, id string) (*Contact, error) {
-	return s.repository.Load(ctx, id)
+	contact, err := s.repository.Load(ctx, id)
+	if err != nil || contact == nil {
+		return contact, err
+	}
+	contact.Notes, err = s.repository.LoadNotes(ctx, id)
+	return contact, err
 }
 func (s *ContactUseCase) Create(ctx context.Context, contact *Contact) (int64, error) {
 	return tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
 		return s.repository.Create(ctx, contact)
 	})
 }
-func (s *ContactUseCase) Update(ctx context.Context, contact *Contact) (int64, error) {
+func (s *ContactUseCase) Delete(ctx context.Context, id string) (int64, error) {
 	return tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
-		return s.repository.Update(ctx, contact)
+		return s.repository.Delete(ctx, id)
 	})
 }
-func (s *ContactUseCase) Patch(ctx context.Context, contact map[string]interface{}) (int64, error) {
+func (s *ContactUseCase) Search(ctx context.Context, filter *ContactFilter, limit int64, offset int64) ([]Contact, int64, error) {
+	return s.repository.Search(ctx, filter, limit, offset)
+}
+
+// Assign returns 0 if the inquiry is not found, and -1 if the user does not exist or is not active
+func (s *ContactUseCase) Assign(ctx context.Context, id string, userId string) (int64, error) {
+	exist, err := s.repository.ExistUser(ctx, userId)
+	if err != nil || !exist {
+		return -1, err
+	}
 	return tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
-		return s.repository.Patch(ctx, contact)
+		return s.repository.Assign(ctx, id, userId, time.Now())
 	})
 }
-func (s *ContactUseCase) Delete(ctx context.Context, id string) (int64, error) {
+
+// MarkContacted stamps the time and the current user. It returns 0 if the inquiry is not found, and -1 if it has already been contacted
+func (s *ContactUseCase) MarkContacted(ctx context.Context, id string) (*Contact, int64, error) {
+	contact, err := s.repository.Load(ctx, id)
+	if err != nil || contact == nil {
+		return nil, 0, err
+	}
+	if contact.ContactedAt != nil {
+		return contact, -1, nil
+	}
+	userId, _ := ctx.Value(s.userKey).(string)
+	now := time.Now()
+	res, err := tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
+		res, err := s.repository.MarkContacted(ctx, id, userId, now)
+		if err != nil || res > 0 {
+			return res, err
+		}
+		return -1, nil
+	})
+	if err != nil || res <= 0 {
+		return nil, res, err
+	}
+	contact.ContactedAt = &now
+	contact.ContactedBy = &userId
+	return contact, res, nil
+}
+
+// AddNote returns 0 if the inquiry is not found
+func (s *ContactUseCase) AddNote(ctx context.Context, id string, note *Note) (int64, error) {
+	contact, err := s.repository.Load(ctx, id)
+	if err != nil || contact == nil {
+		return 0, err
+	}
+	noteId, err := s.generateId(ctx)
+	if err != nil {
+		return -1, err
+	}
+	now := time.Now()
+	note.Id = noteId
+	note.ContactId = id
+	note.CreatedAt = &now
+	if userId, ok := ctx.Value(s.userKey).(string); ok && len(userId) > 0 {
+		note.CreatedBy = &userId
+	}
 	return tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
-		return s.repository.Delete(ctx, id)
+		return s.repository.CreateNote(ctx, note)
 	})
 }
-func (s *ContactUseCase) Search(ctx context.Context, filter *ContactFilter, limit int64, offset int64) ([]Contact, int64, error) {
-	return s.repository.Search(ctx, filter, limit, offset)
+func (s *ContactUseCase) GetNotes(ctx context.Context, id string) ([]Note, error) {
+	return s.repository.LoadNotes(ctx, id)
+}
+
+// Overdue loads the open inquiries, which have not been contacted for longer than the SLA in hours
+func (s *ContactUseCase) Overdue(ctx context.Context) ([]Contact, error) {
+	return s.repository.Overdue(ctx, time.Now().Add(-time.Duration(s.sla)*time.Hour))
+}
+
+// Submit saves the inquiry of the public site with a generated id, and flags it as a duplicate of an inquiry with the same email or phone within the window in hours
+func (s *ContactUseCase) Submit(ctx context.Context, contact *Contact) (int64, error) {
+	id, err := s.generateId(ctx)
+	if err != nil {
+		return -1, err
+	}
+	now := time.Now()
+	contact.Id = id
+	contact.SubmittedAt = &now
+	if s.duplicateWindow > 0 {
+		duplicateOf, err := s.repository.FindDuplicate(ctx, contact.Email, contact.Phone, now.Add(-time.Duration(s.duplicateWindow)*time.Hour))
+		if err != nil {
+			return -1, err
+		}
+		if len(duplicateOf) > 0 {
+			contact.DuplicateOf = &duplicateOf
+		}
+	}
+	return tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
+		return s.repository.Create(ctx, contact)
+	})
+}
+
+func (s *ContactUseCase) GetDuplicates(ctx context.Context) ([]DuplicateGroup, error) {
+	contacts, err := s.repository.LoadUnmerged(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return GroupDuplicates(contacts), nil
+}
+
+// Merge keeps the primary contact, copies the messages of the others into its notes and links them to it.
+// It returns 0 if a contact is not found, and -1 if a contact has already been merged.
+func (s *ContactUseCase) Merge(ctx context.Context, primaryId string, ids []string) (*Merge, int64, error) {
+	ids = distinct(ids, primaryId)
+	contacts, err := s.repository.LoadByIds(ctx, append([]string{primaryId}, ids...))
+	if err != nil || len(contacts) != len(ids)+1 {
+		return nil, 0, err
+	}
+	var others []Contact
+	for _, contact := range contacts {
+		if contact.MergedInto != nil {
+			return nil, -1, nil
+		}
+		if contact.Id != primaryId {
+			others = append(others, contact)
+		}
+	}
+	mergeId, err := s.generateId(ctx)
+	if err != nil {
+		return nil, -1, err
+	}
+	userId, _ := ctx.Value(s.userKey).(string)
+	now := time.Now()
+	merge := &Merge{Id: mergeId, PrimaryId: primaryId, MergedIds: ids, MergedAt: &now}
+	if len(userId) > 0 {
+		merge.MergedBy = &userId
+	}
+	res, err := tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
+		for _, other := range others {
+			noteId, err := s.generateId(ctx)
+			if err != nil {
+				return -1, err
+			}
+			note := &Note{Id: noteId, ContactId: primaryId, Note: mergedNote(other), CreatedBy: merge.MergedBy, CreatedAt: &now}
+			if _, err = s.repository.CreateNote(ctx, note); err != nil {
+				return -1, err
+			}
+			merge.NoteIds = append(merge.NoteIds, noteId)
+		}
+		res, err := s.repository.Link(ctx, ids, primaryId)
+		if err != nil {
+			return -1, err
+		}
+		if res != int64(len(ids)) {
+			return -1, errConflict
+		}
+		return s.repository.CreateMerge(ctx, merge)
+	})
+	if err == errConflict {
+		return nil, -1, nil
+	}
+	if err != nil || res <= 0 {
+		return nil, res, err
+	}
+	return merge, res, nil
+}
+func (s *ContactUseCase) GetMerges(ctx context.Context, primaryId string) ([]Merge, error) {
+	return s.repository.LoadMerges(ctx, primaryId)
+}
+
+// UndoMerge unlinks the merged contacts and removes the notes copied by the merge, within the grace period in hours.
+// It returns 0 if the merge is not found, and -1 if it has already been undone or the grace period is over.
+func (s *ContactUseCase) UndoMerge(ctx context.Context, id string) (*Merge, int64, error) {
+	merge, err := s.repository.LoadMerge(ctx, id)
+	if err != nil || merge == nil {
+		return nil, 0, err
+	}
+	now := time.Now()
+	if merge.UndoneAt != nil || merge.MergedAt == nil || now.After(merge.MergedAt.Add(time.Duration(s.mergeGrace)*time.Hour)) {
+		return merge, -1, nil
+	}
+	userId, _ := ctx.Value(s.userKey).(string)
+	res, err := tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
+		res, err := s.repository.UndoMerge(ctx, id, userId, now)
+		if err != nil || res <= 0 {
+			return -1, err
+		}
+		if _, err = s.repository.Unlink(ctx, merge.MergedIds, merge.PrimaryId); err != nil {
+			return -1, err
+		}
+		if len(merge.NoteIds) > 0 {
+			if _, err = s.repository.DeleteNotes(ctx, merge.NoteIds); err != nil {
+				return -1, err
+			}
+		}
+		return res, nil
+	})
+	if err != nil || res <= 0 {
+		return merge, res, err
+	}
+	merge.UndoneAt = &now
+	if len(userId) > 0 {
+		merge.UndoneBy = &userId
+	}
+	return merge, res, nil
+}
+
+// errConflict rolls back the merge when another user has merged one of the contacts at the same time
+var errConflict = errors.New("contact has already been merged")
+
+func mergedNote(contact Contact) string {
+	note := fmt.Sprintf("Merged inquiry '%s' from %s <%s>", contact.Id, contact.Name, contact.Email)
+	if len(contact.Phone) > 0 {
+		note = note + ", " + contact.Phone
+	}
+	if contact.SubmittedAt != nil {
+		note = note + ", submitted at " + contact.SubmittedAt.Format(time.RFC3339)
+	}
+	return note + ":\n" + contact.Message
+}
+
+func distinct(ids []string, exclude string) []string {
+	found := map[string]bool{exclude: true}
+	result := make([]string, 0)
+	for _, id := range ids {
+		if !found[id] {
+			found[id] = true
+			result = append(result, id)
+		}
+	}
+	return result
 }
@@ -1,34 +1,59 @@
 package contact
 
 import (
+	"context"
 	"database/sql"
 	"net/http"
 
 	"github.com/core-go/core"
+	b "github.com/core-go/core/builder"
 	v "github.com/core-go/core/validator"
+	"github.com/core-go/search"
 	"github.com/core-go/sql/query/builder"
+	"github.com/lib/pq"
+
+	"go-service/pkg/export"
+	"go-service/pkg/ratelimit"
 )
 
 type ContactTransport interface {
 	Search(w http.ResponseWriter, r *http.Request)
 	Load(w http.ResponseWriter, r *http.Request)
 	Create(w http.ResponseWriter, r *http.Request)
-	Update(w http.ResponseWriter, r *http.Request)
-	Patch(w http.ResponseWriter, r *http.Request)
 	Delete(w http.ResponseWriter, r *http.Request)
+	Export(w http.ResponseWriter, r *http.Request)
+	Assign(w http.ResponseWriter, r *http.Request)
+	MarkContacted(w http.ResponseWriter, r *http.Request)
+	GetNotes(w http.ResponseWriter, r *http.Request)
+	AddNote(w http.ResponseWriter, r *http.Request)
+	Overdue(w http.ResponseWriter, r *http.Request)
+	Submit(w http.ResponseWriter, r *http.Request)
+	GetDuplicates(w http.ResponseWriter, r *http.Request)
+	Merge(w http.ResponseWriter, r *http.Request)
+	GetMerges(w http.ResponseWriter, r *http.Request)
+	UndoMerge(w http.ResponseWriter, r *http.Request)
 }
 
-func NewContactTransport(db *sql.DB, logError core.Log, writeLog core.WriteLog, action *core.ActionConfig) (ContactTransport, error) {
+func NewContactTransport(db *sql.DB, logError core.Log, conf Config, tracking b.TrackingConfig, generateId func(context.Context) (string, error), writeLog core.WriteLog, action *core.ActionConfig) (ContactTransport, error) {
 	validator, err := v.NewValidator[*Contact]()
 	if err != nil {
 		return nil, err
 	}
+	noteValidator, err := v.NewValidator[*Note]()
+	if err != nil {
+		return nil, err
+	}
 	queryContact := builder.UseQuery[Contact, *ContactFilter](db, "contacts")
-	contactRepository, err := NewContactAdapter(db, queryContact)
+	contactRepository, err := NewContactAdapter(db, queryContact, pq.Array)
 	if err != nil {
 		return nil, err
 	}
-	contactService := NewContactService(db, contactRepository)
-	contactHandler := NewContactHandler(contactService, logError, validator.Validate, writeLog, action)
+	var verify Verify
+	if verifier := NewSiteVerifier(conf.Challenge); verifier != nil {
+		verify = verifier.Verify
+	}
+	contactService := NewContactService(db, contactRepository, generateId, tracking.User, conf)
+	contactExporter := export.NewExporter[Contact, *ContactFilter](db, "contact", func() *ContactFilter { return &ContactFilter{Filter: &search.Filter{}} }, queryContact, logError, writeLog)
+	contactHandler := NewContactHandler(contactService, logError, validator.Validate, noteValidator.Validate, ratelimit.NewLimiter(conf.RateLimit), verify, contactExporter, writeLog, action)
 	return contactHandler, nil
 }
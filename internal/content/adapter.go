@@ -19,7 +19,8 @@ func NewContentAdapter(db *sql.DB, buildQuery func(*ContentFilter) (string, []in
 	if err != nil {
 		return nil, err
 	}
-	return &ContentAdapter{DB: db, Parameters: parameters, BuildQuery: buildQuery, Array: toArray}, nil
+	versionIndex := parameters.Map["version"]
+	return &ContentAdapter{DB: db, Parameters: parameters, VersionIndex: versionIndex, BuildQuery: buildQuery, Array: toArray}, nil
 }
 
 type ContentAdapter struct {
@@ -139,6 +140,34 @@ func (r *ContentAdapter) Search(ctx context.Context, filter *ContentFilter, limi
 	return contents, total, err
 }
 
+func (r *ContentAdapter) LoadLanguages(ctx context.Context, id string) ([]Content, error) {
+	var contents []Content
+	query := fmt.Sprintf("select %s from contents where id = %s order by lang", r.Fields, r.BuildParam(1))
+	err := s.QueryWithArray(ctx, r.DB, r.Map, &contents, r.Array, query, id)
+	return contents, err
+}
+
+func (r *ContentAdapter) LoadKeys(ctx context.Context) ([]Content, error) {
+	var contents []Content
+	err := s.Query(ctx, r.DB, r.Map, &contents, "select id, lang from contents order by id, lang")
+	return contents, err
+}
+
+// LoadStale returns the translations saved before the last save of the source language, based on the revision history
+func (r *ContentAdapter) LoadStale(ctx context.Context, source string, lang string) ([]StaleTranslation, error) {
+	var translations []StaleTranslation
+	query := fmt.Sprintf(`with saved as (
+		select c.id, c.lang, max(v.created_at) as updated_at from contents c
+		inner join content_revisions v on v.id = c.id and v.lang = c.lang
+		group by c.id, c.lang)
+	select t.id, t.lang, s.lang as source, t.updated_at, s.updated_at as source_updated_at from saved t
+	inner join saved s on s.id = t.id and s.lang = %s
+	where t.lang <> %s and s.updated_at > t.updated_at and (%s = '' or t.lang = %s)
+	order by t.id, t.lang`, r.BuildParam(1), r.BuildParam(1), r.BuildParam(2), r.BuildParam(2))
+	err := s.Query(ctx, r.DB, nil, &translations, query, source, lang)
+	return translations, err
+}
+
 func BuildQuery(filter *ContentFilter) (string, []interface{}) {
 	query := "select * from contents"
 	where, params := BuildFilter(filter)
@@ -158,7 +187,7 @@ func BuildFilter(filter *ContentFilter) (string, []interface{}) {
 		i++
 	}
 	if len(filter.Lang) > 0 {
-		params = append(params, filter.Id)
+		params = append(params, filter.Lang)
 		where = append(where, fmt.Sprintf(`lang = %s`, buildParam(i)))
 		i++
 	}
@@ -1,14 +1,22 @@
 package content
 
-import "time"
+import (
+	"time"
+
+	"go-service/pkg/sanitizer"
+)
+
+// StatusScheduled is set when the content is ready, so that the scheduler publishes it at published_at; drafts are never published
+const StatusScheduled = "S"
 
 type Content struct {
-	Id          string     `json:"id" gorm:"primary_key;column:id" bson:"_id" dynamodbav:"id,omitempty" firestore:"-"`
-	Lang        string     `json:"lang,omitempty" gorm:"primary_key;column:lang" bson:"lang,omitempty" dynamodbav:"lang,omitempty" firestore:"lang,omitempty"`
-	Title       string     `json:"title,omitempty" gorm:"column:title" bson:"title,omitempty" dynamodbav:"title,omitempty" firestore:"title,omitempty"`
-	Body        string     `json:"body,omitempty" gorm:"column:body" bson:"body,omitempty" dynamodbav:"body,omitempty" firestore:"body,omitempty"`
-	PublishedAt *time.Time `json:"publishedAt,omitempty" gorm:"column:published_at" bson:"publishedAt,omitempty" dynamodbav:"publishedAt,omitempty" firestore:"publishedAt,omitempty"`
-	Tags        []string   `json:"tags,omitempty" gorm:"column:tags" bson:"tags,omitempty" dynamodbav:"tags,omitempty" firestore:"tags,omitempty"`
-	Status      *string    `json:"status,omitempty" gorm:"column:status" bson:"status" dynamodbav:"status,omitempty" firestore:"status,omitempty"`
-	Version     int64      `yaml:"version" mapstructure:"version" json:"version,omitempty" gorm:"column:version" bson:"version" dynamodbav:"version,omitempty" firestore:"version,omitempty"`
+	Id          string              `json:"id" gorm:"primary_key;column:id" bson:"_id" dynamodbav:"id,omitempty" firestore:"-"`
+	Lang        string              `json:"lang,omitempty" gorm:"primary_key;column:lang" bson:"lang,omitempty" dynamodbav:"lang,omitempty" firestore:"lang,omitempty"`
+	Title       string              `json:"title,omitempty" gorm:"column:title" bson:"title,omitempty" dynamodbav:"title,omitempty" firestore:"title,omitempty"`
+	Body        string              `json:"body,omitempty" gorm:"column:body" bson:"body,omitempty" dynamodbav:"body,omitempty" firestore:"body,omitempty"`
+	PublishedAt *time.Time          `json:"publishedAt,omitempty" gorm:"column:published_at" bson:"publishedAt,omitempty" dynamodbav:"publishedAt,omitempty" firestore:"publishedAt,omitempty"`
+	Tags        []string            `json:"tags,omitempty" gorm:"column:tags" bson:"tags,omitempty" dynamodbav:"tags,omitempty" firestore:"tags,omitempty"`
+	Status      *string             `json:"status,omitempty" gorm:"column:status" bson:"status" dynamodbav:"status,omitempty" firestore:"status,omitempty"`
+	Version     int64               `yaml:"version" mapstructure:"version" json:"version,omitempty" gorm:"column:version" bson:"version" dynamodbav:"version,omitempty" firestore:"version,omitempty"`
+	Sanitized   []sanitizer.Removed `json:"sanitized,omitempty"`
 }
@@ -7,20 +7,24 @@ import (
 
 	"github.com/core-go/core"
 	"github.com/core-go/search"
+
+	"go-service/pkg/export"
 )
 
-func NewContentHandler(service ContentService, logError core.Log, validate core.Validate[*Content], writeLog core.WriteLog, action *core.ActionConfig) *ContentHandler {
+func NewContentHandler(service ContentService, logError core.Log, validate core.Validate[*Content], languages LanguageConfig, exporter *export.Exporter[*ContentFilter], writeLog core.WriteLog, action *core.ActionConfig) *ContentHandler {
 	contentType := reflect.TypeOf(Content{})
 	parameters := search.CreateParameters(reflect.TypeOf(ContentFilter{}), contentType)
 	attributes := core.CreateAttributes(contentType, logError, writeLog, action)
-	return &ContentHandler{service: service, Validate: validate, Attributes: attributes, Parameters: parameters}
+	return &ContentHandler{service: service, Validate: validate, languages: languages, Attributes: attributes, Parameters: parameters, Exporter: exporter}
 }
 
 type ContentHandler struct {
-	service  ContentService
-	Validate core.Validate[*Content]
+	service   ContentService
+	Validate  core.Validate[*Content]
+	languages LanguageConfig
 	*core.Attributes
 	*search.Parameters
+	*export.Exporter[*ContentFilter]
 }
 
 func (h *ContentHandler) Load(w http.ResponseWriter, r *http.Request) {
@@ -90,6 +94,9 @@ func (h *ContentHandler) Patch(w http.ResponseWriter, r *http.Request) {
 	if er1 == nil {
 		errors, er2 := h.Validate(r.Context(), &content)
 		if !core.HasError(w, r, errors, er2, h.Error, jsonContent, h.Log, h.Resource, h.Action.Patch) {
+			if len(content.Sanitized) > 0 {
+				jsonContent["body"] = content.Body
+			}
 			res, err := h.service.Patch(r.Context(), jsonContent)
 			if err != nil {
 				h.Error(r.Context(), err.Error())
@@ -100,6 +107,9 @@ func (h *ContentHandler) Patch(w http.ResponseWriter, r *http.Request) {
 
 			if res > 0 {
 				h.Log(r.Context(), h.Resource, h.Action.Patch, true, fmt.Sprintf("%s '%s' '%s'", h.Action.Patch, content.Id, content.Lang))
+				if len(content.Sanitized) > 0 {
+					jsonContent["sanitized"] = content.Sanitized
+				}
 				core.JSON(w, http.StatusOK, jsonContent)
 			} else if res == 0 {
 				h.Log(r.Context(), h.Resource, h.Action.Patch, false, fmt.Sprintf("not found '%s' '%s'", content.Id, content.Lang))
@@ -151,3 +161,168 @@ func (h *ContentHandler) Search(w http.ResponseWriter, r *http.Request) {
 	}
 	core.JSON(w, http.StatusOK, &search.Result{List: &contents, Total: total})
 }
+func (h *ContentHandler) GetRevisions(w http.ResponseWriter, r *http.Request) {
+	id, er1 := core.GetRequiredString(w, r, 2)
+	lang, er2 := core.GetRequiredString(w, r, 1)
+	if er1 == nil && er2 == nil {
+		revisions, err := h.service.GetRevisions(r.Context(), id, lang)
+		if err != nil {
+			h.Error(r.Context(), fmt.Sprintf("Error to get revisions of content '%s' '%s': %s", id, lang, err.Error()))
+			http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+			return
+		}
+		core.JSON(w, http.StatusOK, revisions)
+	}
+}
+func (h *ContentHandler) GetRevision(w http.ResponseWriter, r *http.Request) {
+	id, er1 := core.GetRequiredString(w, r, 2)
+	lang, er2 := core.GetRequiredString(w, r, 1)
+	if er1 == nil && er2 == nil {
+		version, er3 := core.GetRequiredInt64(w, r)
+		if er3 == nil {
+			revision, err := h.service.GetRevision(r.Context(), id, lang, version)
+			if err != nil {
+				h.Error(r.Context(), fmt.Sprintf("Error to get revision %d of content '%s' '%s': %s", version, id, lang, err.Error()))
+				http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+				return
+			}
+			core.JSON(w, core.IsFound(revision), revision)
+		}
+	}
+}
+func (h *ContentHandler) Diff(w http.ResponseWriter, r *http.Request) {
+	id, er1 := core.GetRequiredString(w, r, 5)
+	lang, er2 := core.GetRequiredString(w, r, 4)
+	if er1 == nil && er2 == nil {
+		from, er3 := core.GetRequiredInt64(w, r, 2)
+		if er3 != nil {
+			return
+		}
+		to, er4 := core.GetRequiredInt64(w, r)
+		if er4 == nil {
+			diff, err := h.service.Diff(r.Context(), id, lang, from, to)
+			if err != nil {
+				h.Error(r.Context(), fmt.Sprintf("Error to diff revisions %d and %d of content '%s' '%s': %s", from, to, id, lang, err.Error()))
+				http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+				return
+			}
+			core.JSON(w, core.IsFound(diff), diff)
+		}
+	}
+}
+func (h *ContentHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	id, er1 := core.GetRequiredString(w, r, 4)
+	lang, er2 := core.GetRequiredString(w, r, 3)
+	if er1 == nil && er2 == nil {
+		revision, er3 := core.GetRequiredInt64(w, r, 1)
+		if er3 != nil {
+			return
+		}
+		req, er4 := core.Decode[RestoreRequest](w, r)
+		if er4 == nil {
+			old, err := h.service.GetRevision(r.Context(), id, lang, revision)
+			if err != nil {
+				h.Error(r.Context(), fmt.Sprintf("Error to get revision %d of content '%s' '%s': %s", revision, id, lang, err.Error()))
+				http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+				return
+			}
+			if old == nil {
+				h.Log(r.Context(), h.Resource, "restore", false, fmt.Sprintf("not found '%s' '%s' revision %d", id, lang, revision))
+				core.JSON(w, http.StatusNotFound, 0)
+				return
+			}
+			// the body of the revision is sanitized and the tags are checked again, like an update
+			content := old.ToContent(req.Version)
+			errors, er5 := h.Validate(r.Context(), content)
+			if core.HasError(w, r, errors, er5, h.Error, content, h.Log, h.Resource, "restore") {
+				return
+			}
+			res, err := h.service.Restore(r.Context(), content)
+			if err != nil {
+				h.Error(r.Context(), err.Error())
+				h.Log(r.Context(), h.Resource, "restore", false, err.Error())
+				http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+				return
+			}
+
+			if res > 0 {
+				h.Log(r.Context(), h.Resource, "restore", true, fmt.Sprintf("restore '%s' '%s' to revision %d", id, lang, revision))
+				core.JSON(w, http.StatusOK, content)
+			} else if res == 0 {
+				h.Log(r.Context(), h.Resource, "restore", false, fmt.Sprintf("not found '%s' '%s' revision %d", id, lang, revision))
+				core.JSON(w, http.StatusNotFound, res)
+			} else {
+				h.Log(r.Context(), h.Resource, "restore", false, fmt.Sprintf("conflict '%s' '%s' revision %d", id, lang, revision))
+				core.JSON(w, http.StatusConflict, res)
+			}
+		}
+	}
+}
+func (h *ContentHandler) GetLanguages(w http.ResponseWriter, r *http.Request) {
+	id, err := core.GetRequiredString(w, r)
+	if err == nil {
+		contents, err := h.service.GetLanguages(r.Context(), id)
+		if err != nil {
+			h.Error(r.Context(), fmt.Sprintf("Error to get languages of content '%s': %s", id, err.Error()))
+			http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+			return
+		}
+		if len(contents) == 0 {
+			core.JSON(w, http.StatusNotFound, nil)
+			return
+		}
+		core.JSON(w, http.StatusOK, contents)
+	}
+}
+func (h *ContentHandler) GetMissingTranslations(w http.ResponseWriter, r *http.Request) {
+	report, err := h.service.GetMissingTranslations(r.Context(), r.URL.Query().Get("lang"))
+	if err != nil {
+		h.Error(r.Context(), fmt.Sprintf("Error to get missing translations: %s", err.Error()))
+		http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+		return
+	}
+	core.JSON(w, http.StatusOK, report)
+}
+func (h *ContentHandler) GetStaleTranslations(w http.ResponseWriter, r *http.Request) {
+	translations, err := h.service.GetStaleTranslations(r.Context(), r.URL.Query().Get("lang"))
+	if err != nil {
+		h.Error(r.Context(), fmt.Sprintf("Error to get stale translations: %s", err.Error()))
+		http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+		return
+	}
+	core.JSON(w, http.StatusOK, translations)
+}
+func (h *ContentHandler) Clone(w http.ResponseWriter, r *http.Request) {
+	id, er1 := core.GetRequiredString(w, r, 3)
+	lang, er2 := core.GetRequiredString(w, r, 2)
+	if er1 == nil && er2 == nil {
+		target, er3 := core.GetRequiredString(w, r)
+		if er3 != nil {
+			return
+		}
+		if !h.languages.Supports(target) {
+			errors := []core.ErrorMessage{{Field: "lang", Code: "lang", Param: target}}
+			h.Log(r.Context(), h.Resource, "clone", false, fmt.Sprintf("unsupported language '%s'", target))
+			core.JSON(w, http.StatusUnprocessableEntity, errors)
+			return
+		}
+		content, res, err := h.service.Clone(r.Context(), id, lang, target)
+		if err != nil {
+			h.Error(r.Context(), err.Error())
+			h.Log(r.Context(), h.Resource, "clone", false, err.Error())
+			http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+			return
+		}
+
+		if res > 0 {
+			h.Log(r.Context(), h.Resource, "clone", true, fmt.Sprintf("clone '%s' '%s' to '%s'", id, lang, target))
+			core.JSON(w, http.StatusCreated, content)
+		} else if res == 0 {
+			h.Log(r.Context(), h.Resource, "clone", false, fmt.Sprintf("not found '%s' '%s'", id, lang))
+			core.JSON(w, http.StatusNotFound, res)
+		} else {
+			h.Log(r.Context(), h.Resource, "clone", false, fmt.Sprintf("conflict '%s' '%s'", id, target))
+			core.JSON(w, http.StatusConflict, res)
+		}
+	}
+}
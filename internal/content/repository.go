@@ -1,6 +1,9 @@
 package content
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type ContentRepository interface {
 	Load(ctx context.Context, id string, lang string) (*Content, error)
@@ -9,4 +12,13 @@ type ContentRepository interface {
 	Patch(ctx context.Context, content map[string]interface{}) (int64, error)
 	Delete(ctx context.Context, id string, lang string) (int64, error)
 	Search(ctx context.Context, filter *ContentFilter, limit int64, offset int64) ([]Content, int64, error)
+	LoadLanguages(ctx context.Context, id string) ([]Content, error)
+	LoadKeys(ctx context.Context) ([]Content, error)
+	LoadStale(ctx context.Context, source string, lang string) ([]StaleTranslation, error)
+}
+
+type RevisionRepository interface {
+	Load(ctx context.Context, id string, lang string) ([]ContentRevision, error)
+	LoadVersion(ctx context.Context, id string, lang string, version int64) (*ContentRevision, error)
+	Create(ctx context.Context, id string, lang string, createdBy string, createdAt time.Time) (int64, error)
 }
@@ -0,0 +1,78 @@
+package content
+
+import (
+	"reflect"
+	"time"
+)
+
+type ContentRevision struct {
+	Id          string     `json:"id" gorm:"primary_key;column:id" bson:"_id" dynamodbav:"id,omitempty" firestore:"-"`
+	Lang        string     `json:"lang,omitempty" gorm:"primary_key;column:lang" bson:"lang,omitempty" dynamodbav:"lang,omitempty" firestore:"lang,omitempty"`
+	Version     int64      `json:"version,omitempty" gorm:"primary_key;column:version" bson:"version" dynamodbav:"version,omitempty" firestore:"version,omitempty"`
+	Title       string     `json:"title,omitempty" gorm:"column:title" bson:"title,omitempty" dynamodbav:"title,omitempty" firestore:"title,omitempty"`
+	Body        string     `json:"body,omitempty" gorm:"column:body" bson:"body,omitempty" dynamodbav:"body,omitempty" firestore:"body,omitempty"`
+	PublishedAt *time.Time `json:"publishedAt,omitempty" gorm:"column:published_at" bson:"publishedAt,omitempty" dynamodbav:"publishedAt,omitempty" firestore:"publishedAt,omitempty"`
+	Tags        []string   `json:"tags,omitempty" gorm:"column:tags" bson:"tags,omitempty" dynamodbav:"tags,omitempty" firestore:"tags,omitempty"`
+	Status      *string    `json:"status,omitempty" gorm:"column:status" bson:"status" dynamodbav:"status,omitempty" firestore:"status,omitempty"`
+	CreatedBy   *string    `json:"createdBy,omitempty" gorm:"column:created_by" bson:"createdBy,omitempty" dynamodbav:"createdBy,omitempty" firestore:"createdBy,omitempty"`
+	CreatedAt   *time.Time `json:"createdAt,omitempty" gorm:"column:created_at" bson:"createdAt,omitempty" dynamodbav:"createdAt,omitempty" firestore:"createdAt,omitempty"`
+}
+
+type FieldDiff struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+type RevisionDiff struct {
+	Id     string      `json:"id"`
+	Lang   string      `json:"lang"`
+	From   int64       `json:"from"`
+	To     int64       `json:"to"`
+	Fields []FieldDiff `json:"fields"`
+}
+
+type RestoreRequest struct {
+	Version int64 `json:"version"`
+}
+
+func Diff(from *ContentRevision, to *ContentRevision) *RevisionDiff {
+	diff := &RevisionDiff{Id: from.Id, Lang: from.Lang, From: from.Version, To: to.Version, Fields: make([]FieldDiff, 0)}
+	if from.Title != to.Title {
+		diff.Fields = append(diff.Fields, FieldDiff{Field: "title", From: from.Title, To: to.Title})
+	}
+	if from.Body != to.Body {
+		diff.Fields = append(diff.Fields, FieldDiff{Field: "body", From: from.Body, To: to.Body})
+	}
+	if !equalTime(from.PublishedAt, to.PublishedAt) {
+		diff.Fields = append(diff.Fields, FieldDiff{Field: "publishedAt", From: from.PublishedAt, To: to.PublishedAt})
+	}
+	if len(from.Tags) != len(to.Tags) || len(from.Tags) > 0 && !reflect.DeepEqual(from.Tags, to.Tags) {
+		diff.Fields = append(diff.Fields, FieldDiff{Field: "tags", From: from.Tags, To: to.Tags})
+	}
+	if !reflect.DeepEqual(from.Status, to.Status) {
+		diff.Fields = append(diff.Fields, FieldDiff{Field: "status", From: from.Status, To: to.Status})
+	}
+	return diff
+}
+
+func equalTime(a *time.Time, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// ToContent builds the content to save when restoring a revision, with the version the editor is currently working on.
+// The status is not restored, it stays the current status of the content.
+func (r *ContentRevision) ToContent(version int64) *Content {
+	return &Content{
+		Id:          r.Id,
+		Lang:        r.Lang,
+		Title:       r.Title,
+		Body:        r.Body,
+		PublishedAt: r.PublishedAt,
+		Tags:        r.Tags,
+		Version:     version,
+	}
+}
@@ -0,0 +1,58 @@
+package content
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"time"
+
+	s "github.com/core-go/sql"
+)
+
+func NewRevisionAdapter(db *sql.DB, toArray s.Array) (*RevisionAdapter, error) {
+	parameters, err := s.CreateParameters(reflect.TypeOf(ContentRevision{}), db)
+	if err != nil {
+		return nil, err
+	}
+	return &RevisionAdapter{DB: db, Parameters: parameters, Array: toArray}, nil
+}
+
+type RevisionAdapter struct {
+	DB *sql.DB
+	*s.Parameters
+	Array s.Array
+}
+
+func (r *RevisionAdapter) Load(ctx context.Context, id string, lang string) ([]ContentRevision, error) {
+	var revisions []ContentRevision
+	query := fmt.Sprintf("select %s from content_revisions where id = %s and lang = %s order by version desc", r.Fields, r.BuildParam(1), r.BuildParam(2))
+	err := s.QueryWithArray(ctx, r.DB, r.Map, &revisions, r.Array, query, id, lang)
+	return revisions, err
+}
+
+func (r *RevisionAdapter) LoadVersion(ctx context.Context, id string, lang string, version int64) (*ContentRevision, error) {
+	var revisions []ContentRevision
+	query := fmt.Sprintf("select %s from content_revisions where id = %s and lang = %s and version = %s limit 1", r.Fields, r.BuildParam(1), r.BuildParam(2), r.BuildParam(3))
+	err := s.QueryWithArray(ctx, r.DB, r.Map, &revisions, r.Array, query, id, lang, version)
+	if err != nil {
+		return nil, err
+	}
+	if len(revisions) > 0 {
+		return &revisions[0], nil
+	}
+	return nil, nil
+}
+
+// Create copies the current row of the content into content_revisions, so it must run in the same transaction as the save
+func (r *RevisionAdapter) Create(ctx context.Context, id string, lang string, createdBy string, createdAt time.Time) (int64, error) {
+	query := fmt.Sprintf(`insert into content_revisions (id, lang, version, title, body, published_at, tags, status, created_by, created_at)
+		select id, lang, version, title, body, published_at, tags, status, %s, %s from contents where id = %s and lang = %s`,
+		r.BuildParam(1), r.BuildParam(2), r.BuildParam(3), r.BuildParam(4))
+	tx := s.GetTx(ctx, r.DB)
+	res, err := tx.ExecContext(ctx, query, createdBy, createdAt, id, lang)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
@@ -3,6 +3,7 @@ package content
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/core-go/core/tx"
 )
@@ -14,15 +15,26 @@ type ContentService interface {
 	Patch(ctx context.Context, content map[string]interface{}) (int64, error)
 	Delete(ctx context.Context, id string, lang string) (int64, error)
 	Search(ctx context.Context, filter *ContentFilter, limit int64, offset int64) ([]Content, int64, error)
+	GetRevisions(ctx context.Context, id string, lang string) ([]ContentRevision, error)
+	GetRevision(ctx context.Context, id string, lang string, version int64) (*ContentRevision, error)
+	Diff(ctx context.Context, id string, lang string, from int64, to int64) (*RevisionDiff, error)
+	Restore(ctx context.Context, content *Content) (int64, error)
+	GetLanguages(ctx context.Context, id string) ([]Content, error)
+	GetMissingTranslations(ctx context.Context, lang string) ([]MissingTranslation, error)
+	GetStaleTranslations(ctx context.Context, lang string) ([]StaleTranslation, error)
+	Clone(ctx context.Context, id string, lang string, target string) (*Content, int64, error)
 }
 
-func NewContentService(db *sql.DB, repository ContentRepository) *ContentUseCase {
-	return &ContentUseCase{db: db, repository: repository}
+func NewContentService(db *sql.DB, repository ContentRepository, revisionRepository RevisionRepository, languages LanguageConfig, userKey string) *ContentUseCase {
+	return &ContentUseCase{db: db, repository: repository, revisionRepository: revisionRepository, languages: languages, userKey: userKey}
 }
 
 type ContentUseCase struct {
-	db         *sql.DB
-	repository ContentRepository
+	db                 *sql.DB
+	repository         ContentRepository
+	revisionRepository RevisionRepository
+	languages          LanguageConfig
+	userKey            string
 }
 
 func (s *ContentUseCase) Load(ctx context.Context, id string, lang string) (*Content, error) {
@@ -30,17 +42,31 @@ func (s *ContentUseCase) Load(ctx context.Context, id string, lang string) (*Con
 }
 func (s *ContentUseCase) Create(ctx context.Context, content *Content) (int64, error) {
 	return tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
-		return s.repository.Create(ctx, content)
+		res, err := s.repository.Create(ctx, content)
+		if err != nil || res <= 0 {
+			return res, err
+		}
+		return s.snapshot(ctx, content.Id, content.Lang, res)
 	})
 }
 func (s *ContentUseCase) Update(ctx context.Context, content *Content) (int64, error) {
 	return tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
-		return s.repository.Update(ctx, content)
+		res, err := s.repository.Update(ctx, content)
+		if err != nil || res <= 0 {
+			return res, err
+		}
+		return s.snapshot(ctx, content.Id, content.Lang, res)
 	})
 }
 func (s *ContentUseCase) Patch(ctx context.Context, content map[string]interface{}) (int64, error) {
 	return tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
-		return s.repository.Patch(ctx, content)
+		res, err := s.repository.Patch(ctx, content)
+		if err != nil || res <= 0 {
+			return res, err
+		}
+		id, _ := content["id"].(string)
+		lang, _ := content["lang"].(string)
+		return s.snapshot(ctx, id, lang, res)
 	})
 }
 func (s *ContentUseCase) Delete(ctx context.Context, id string, lang string) (int64, error) {
@@ -51,3 +77,96 @@ func (s *ContentUseCase) Delete(ctx context.Context, id string, lang string) (in
 func (s *ContentUseCase) Search(ctx context.Context, filter *ContentFilter, limit int64, offset int64) ([]Content, int64, error) {
 	return s.repository.Search(ctx, filter, limit, offset)
 }
+
+func (s *ContentUseCase) GetRevisions(ctx context.Context, id string, lang string) ([]ContentRevision, error) {
+	return s.revisionRepository.Load(ctx, id, lang)
+}
+func (s *ContentUseCase) GetRevision(ctx context.Context, id string, lang string, version int64) (*ContentRevision, error) {
+	return s.revisionRepository.LoadVersion(ctx, id, lang, version)
+}
+func (s *ContentUseCase) Diff(ctx context.Context, id string, lang string, from int64, to int64) (*RevisionDiff, error) {
+	fromRevision, err := s.revisionRepository.LoadVersion(ctx, id, lang, from)
+	if err != nil || fromRevision == nil {
+		return nil, err
+	}
+	toRevision, err := s.revisionRepository.LoadVersion(ctx, id, lang, to)
+	if err != nil || toRevision == nil {
+		return nil, err
+	}
+	return Diff(fromRevision, toRevision), nil
+}
+
+// Restore saves the content of an old revision as a new version with the current status, so that a restore never publishes or unpublishes it.
+// The content has the version the caller is working on, to pass the optimistic lock.
+func (s *ContentUseCase) Restore(ctx context.Context, content *Content) (int64, error) {
+	return tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
+		current, err := s.repository.Load(ctx, content.Id, content.Lang)
+		if err != nil || current == nil {
+			return 0, err
+		}
+		content.Status = current.Status
+		res, err := s.repository.Update(ctx, content)
+		if err != nil || res <= 0 {
+			return res, err
+		}
+		content.Version++
+		return s.snapshot(ctx, content.Id, content.Lang, res)
+	})
+}
+
+func (s *ContentUseCase) GetLanguages(ctx context.Context, id string) ([]Content, error) {
+	return s.repository.LoadLanguages(ctx, id)
+}
+func (s *ContentUseCase) GetMissingTranslations(ctx context.Context, lang string) ([]MissingTranslation, error) {
+	keys, err := s.repository.LoadKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	languages := s.languages.Languages
+	if len(lang) > 0 {
+		languages = []string{lang}
+	}
+	return GetMissingTranslations(keys, languages), nil
+}
+func (s *ContentUseCase) GetStaleTranslations(ctx context.Context, lang string) ([]StaleTranslation, error) {
+	return s.repository.LoadStale(ctx, s.languages.Source, lang)
+}
+
+// Clone copies an existing language as a draft of a new language, returns -1 if the new language already exists
+func (s *ContentUseCase) Clone(ctx context.Context, id string, lang string, target string) (*Content, int64, error) {
+	var content *Content
+	res, err := tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
+		source, err := s.repository.Load(ctx, id, lang)
+		if err != nil || source == nil {
+			return 0, err
+		}
+		existing, err := s.repository.Load(ctx, id, target)
+		if err != nil {
+			return -1, err
+		}
+		if existing != nil {
+			return -1, nil
+		}
+		status := statusDraft
+		content = source
+		content.Lang = target
+		content.Status = &status
+		content.PublishedAt = nil
+		res, err := s.repository.Create(ctx, content)
+		if err != nil || res <= 0 {
+			return res, err
+		}
+		content.Version = 1
+		return s.snapshot(ctx, id, target, res)
+	})
+	return content, res, err
+}
+
+func (s *ContentUseCase) snapshot(ctx context.Context, id string, lang string, res int64) (int64, error) {
+	userId, _ := ctx.Value(s.userKey).(string)
+	_, err := s.revisionRepository.Create(ctx, id, lang, userId, time.Now())
+	if err != nil {
+		return -1, err
+	}
+	return res, nil
+}
@@ -0,0 +1,57 @@
+package content
+
+import "time"
+
+const statusDraft = "D"
+
+type LanguageConfig struct {
+	Source    string   `yaml:"source" mapstructure:"source" json:"source,omitempty"`
+	Languages []string `yaml:"languages" mapstructure:"languages" json:"languages,omitempty"`
+}
+
+func (c LanguageConfig) Supports(lang string) bool {
+	return contains(c.Languages, lang)
+}
+
+type MissingTranslation struct {
+	Id        string   `json:"id"`
+	Languages []string `json:"languages"`
+	Missing   []string `json:"missing"`
+}
+
+type StaleTranslation struct {
+	Id              string     `json:"id" gorm:"column:id"`
+	Lang            string     `json:"lang" gorm:"column:lang"`
+	Source          string     `json:"source" gorm:"column:source"`
+	UpdatedAt       *time.Time `json:"updatedAt,omitempty" gorm:"column:updated_at"`
+	SourceUpdatedAt *time.Time `json:"sourceUpdatedAt,omitempty" gorm:"column:source_updated_at"`
+}
+
+// GetMissingTranslations returns the configured languages which each content id does not have yet, the ids are expected in order
+func GetMissingTranslations(contents []Content, languages []string) []MissingTranslation {
+	report := make([]MissingTranslation, 0)
+	for i := 0; i < len(contents); {
+		item := MissingTranslation{Id: contents[i].Id, Languages: make([]string, 0), Missing: make([]string, 0)}
+		for ; i < len(contents) && contents[i].Id == item.Id; i++ {
+			item.Languages = append(item.Languages, contents[i].Lang)
+		}
+		for _, lang := range languages {
+			if !contains(item.Languages, lang) {
+				item.Missing = append(item.Missing, lang)
+			}
+		}
+		if len(item.Missing) > 0 {
+			report = append(report, item)
+		}
+	}
+	return report
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
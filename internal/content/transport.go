@@ -2,12 +2,18 @@ package content
 
 import (
 	"database/sql"
+	"github.com/core-go/search"
 	"github.com/lib/pq"
 	"net/http"
 
 	"github.com/core-go/core"
+	b "github.com/core-go/core/builder"
 	v "github.com/core-go/core/validator"
 	"github.com/core-go/sql/query/builder"
+
+	"go-service/internal/tag"
+	"go-service/pkg/export"
+	"go-service/pkg/sanitizer"
 )
 
 type ContentTransport interface {
@@ -17,19 +23,39 @@ type ContentTransport interface {
 	Update(w http.ResponseWriter, r *http.Request)
 	Patch(w http.ResponseWriter, r *http.Request)
 	Delete(w http.ResponseWriter, r *http.Request)
+	Export(w http.ResponseWriter, r *http.Request)
+	GetRevisions(w http.ResponseWriter, r *http.Request)
+	GetRevision(w http.ResponseWriter, r *http.Request)
+	Diff(w http.ResponseWriter, r *http.Request)
+	Restore(w http.ResponseWriter, r *http.Request)
+	GetLanguages(w http.ResponseWriter, r *http.Request)
+	GetMissingTranslations(w http.ResponseWriter, r *http.Request)
+	GetStaleTranslations(w http.ResponseWriter, r *http.Request)
+	Clone(w http.ResponseWriter, r *http.Request)
 }
 
-func NewContentTransport(db *sql.DB, logError core.Log, writeLog core.WriteLog, action *core.ActionConfig) (ContentTransport, error) {
+func NewContentTransport(db *sql.DB, logError core.Log, tracking b.TrackingConfig, languages LanguageConfig, htmlSanitizer *sanitizer.Sanitizer, checkTags tag.Check, writeLog core.WriteLog, action *core.ActionConfig) (ContentTransport, error) {
 	validator, err := v.NewValidator[*Content]()
 	if err != nil {
 		return nil, err
 	}
+	tagValidator := tag.NewValidator[Content](checkTags, func(content *Content) []string {
+		return content.Tags
+	}, validator.Validate)
+	validate := sanitizer.NewValidator[Content](htmlSanitizer, "body", func(content *Content) (*string, *[]sanitizer.Removed) {
+		return &content.Body, &content.Sanitized
+	}, tagValidator)
 	queryContent := builder.UseQuery[Content, *ContentFilter](db, "contents")
 	contentRepository, err := NewContentAdapter(db, queryContent, pq.Array)
 	if err != nil {
 		return nil, err
 	}
-	contentService := NewContentService(db, contentRepository)
-	contentHandler := NewContentHandler(contentService, logError, validator.Validate, writeLog, action)
+	revisionRepository, err := NewRevisionAdapter(db, pq.Array)
+	if err != nil {
+		return nil, err
+	}
+	contentService := NewContentService(db, contentRepository, revisionRepository, languages, tracking.User)
+	contentExporter := export.NewExporter[Content, *ContentFilter](db, "content", func() *ContentFilter { return &ContentFilter{Filter: &search.Filter{}} }, queryContent, logError, writeLog)
+	contentHandler := NewContentHandler(contentService, logError, validate, languages, contentExporter, writeLog, action)
 	return contentHandler, nil
 }
@@ -116,15 +116,66 @@ func (r *JobAdapter) Search(ctx context.Context, filter *JobFilter, limit int64,
 	return jobs, total, err
 }
 
-func BuildQuery(filter *JobFilter) (string, []interface{}) {
-	query := "select * from jobs"
-	where, params := BuildFilter(filter)
+func (r *JobAdapter) ExistCompany(ctx context.Context, id string) (bool, error) {
+	return s.Exist(ctx, r.DB, fmt.Sprintf("select id from companies where id = %s limit 1", r.BuildParam(1)), id)
+}
+
+func (r *JobAdapter) LoadCompanies(ctx context.Context, ids []string) ([]Company, error) {
+	var companies []Company
+	query := fmt.Sprintf("select id, name, slogan, image_url from companies where id = any(%s)", r.BuildParam(1))
+	err := s.Query(ctx, r.DB, nil, &companies, query, r.Array(ids))
+	return companies, err
+}
+
+// Facets counts the jobs matching the filter by the values of the facet, the most frequent values first
+func (r *JobAdapter) Facets(ctx context.Context, filter *JobFilter, name string, limit int) ([]Facet, error) {
+	facets := make([]Facet, 0)
+	var query string
+	where, params := BuildFilter(filter, r.Array)
 	if len(where) > 0 {
-		query = query + " where " + where
+		where = " and " + where
+	}
+	switch name {
+	case FacetSkills:
+		query = "select skill as value, count(*) as count from jobs, unnest(skills) as skill where 1 = 1%s group by skill"
+	case FacetLocation:
+		query = "select location as value, count(*) as count from jobs where location is not null and location <> ''%s group by location"
+	case FacetPosition:
+		query = "select position as value, count(*) as count from jobs where position is not null and position <> ''%s group by position"
+	case FacetCompany:
+		query = `select j.company_id as value, min(c.name) as name, count(*) as count from (select company_id from jobs where company_id is not null and company_id <> ''%s) j
+			left join companies c on c.id = j.company_id group by j.company_id`
+	default:
+		return facets, nil
+	}
+	query = fmt.Sprintf(query+" order by count desc, value limit %d", where, limit)
+	err := s.Query(ctx, r.DB, nil, &facets, query, params...)
+	return facets, err
+}
+
+func UseQuery(toArray func(interface{}) interface {
+	driver.Valuer
+	sql.Scanner
+}) func(*JobFilter) (string, []interface{}) {
+	jobType := reflect.TypeOf(Job{})
+	return func(filter *JobFilter) (string, []interface{}) {
+		query := "select * from jobs"
+		where, params := BuildFilter(filter, toArray)
+		if len(where) > 0 {
+			query = query + " where " + where
+		}
+		if sort := s.BuildSort(filter.Sort, jobType); len(sort) > 0 {
+			query = query + sort
+		} else {
+			query = query + " order by published_at desc nulls last, id"
+		}
+		return query, params
 	}
-	return query, params
 }
-func BuildFilter(filter *JobFilter) (string, []interface{}) {
+func BuildFilter(filter *JobFilter, toArray func(interface{}) interface {
+	driver.Valuer
+	sql.Scanner
+}) (string, []interface{}) {
 	buildParam := s.BuildDollarParam
 	var where []string
 	var params []interface{}
@@ -134,6 +185,16 @@ func BuildFilter(filter *JobFilter) (string, []interface{}) {
 		where = append(where, fmt.Sprintf(`id = %s`, buildParam(i)))
 		i++
 	}
+	if len(filter.Title) > 0 {
+		params = append(params, filter.Title+"%")
+		where = append(where, fmt.Sprintf(`title ilike %s`, buildParam(i)))
+		i++
+	}
+	if len(filter.Description) > 0 {
+		params = append(params, "%"+filter.Description+"%")
+		where = append(where, fmt.Sprintf(`description ilike %s`, buildParam(i)))
+		i++
+	}
 	if filter.PublishedAt != nil {
 		if filter.PublishedAt.Min != nil {
 			params = append(params, filter.PublishedAt.Min)
@@ -146,10 +207,66 @@ func BuildFilter(filter *JobFilter) (string, []interface{}) {
 			i++
 		}
 	}
-	if len(filter.Title) > 0 {
-		q := filter.Title + "%"
-		params = append(params, q)
-		where = append(where, fmt.Sprintf(`title like %s`, buildParam(i)))
+	if filter.ExpiredAt != nil {
+		if filter.ExpiredAt.Min != nil {
+			params = append(params, filter.ExpiredAt.Min)
+			where = append(where, fmt.Sprintf(`expired_at >= %s`, buildParam(i)))
+			i++
+		}
+		if filter.ExpiredAt.Max != nil {
+			params = append(params, filter.ExpiredAt.Max)
+			where = append(where, fmt.Sprintf(`expired_at <= %s`, buildParam(i)))
+			i++
+		}
+	}
+	if len(filter.Position) > 0 {
+		params = append(params, "%"+escapeLike(filter.Position)+"%")
+		where = append(where, fmt.Sprintf(`position ilike %s`, buildParam(i)))
+		i++
+	}
+	if len(filter.Location) > 0 {
+		params = append(params, "%"+escapeLike(filter.Location)+"%")
+		where = append(where, fmt.Sprintf(`location ilike %s`, buildParam(i)))
+		i++
+	}
+	if filter.Quantity != nil {
+		params = append(params, *filter.Quantity)
+		where = append(where, fmt.Sprintf(`quantity = %s`, buildParam(i)))
+		i++
+	}
+	if filter.ApplicantCount != nil {
+		params = append(params, *filter.ApplicantCount)
+		where = append(where, fmt.Sprintf(`applicant_count = %s`, buildParam(i)))
+		i++
+	}
+	if len(filter.Skills) > 0 {
+		params = append(params, toArray(filter.Skills))
+		if filter.SkillMatch == MatchAll {
+			where = append(where, fmt.Sprintf(`skills @> %s`, buildParam(i)))
+		} else {
+			where = append(where, fmt.Sprintf(`skills && %s`, buildParam(i)))
+		}
+		i++
+	}
+	// the salary range of the job overlaps the range of the filter; a job with only one bound is treated as a fixed salary
+	if filter.MinSalary != nil {
+		params = append(params, *filter.MinSalary)
+		where = append(where, fmt.Sprintf(`coalesce(max_salary, min_salary) >= %s`, buildParam(i)))
+		i++
+	}
+	if filter.MaxSalary != nil {
+		params = append(params, *filter.MaxSalary)
+		where = append(where, fmt.Sprintf(`coalesce(min_salary, max_salary) <= %s`, buildParam(i)))
+		i++
+	}
+	if len(filter.CompanyId) > 0 {
+		params = append(params, filter.CompanyId)
+		where = append(where, fmt.Sprintf(`company_id = %s`, buildParam(i)))
+		i++
+	}
+	if len(filter.Status) > 0 {
+		params = append(params, toArray(filter.Status))
+		where = append(where, fmt.Sprintf(`status = any(%s)`, buildParam(i)))
 		i++
 	}
 	if len(where) > 0 {
@@ -157,3 +274,7 @@ func BuildFilter(filter *JobFilter) (string, []interface{}) {
 	}
 	return "", params
 }
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
@@ -4,16 +4,19 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"strings"
 
 	"github.com/core-go/core"
 	"github.com/core-go/search"
+
+	"go-service/pkg/export"
 )
 
-func NewJobHandler(service JobService, logError core.Log, validate core.Validate[*Job], writeLog core.WriteLog, action *core.ActionConfig) *JobHandler {
+func NewJobHandler(service JobService, logError core.Log, validate core.Validate[*Job], exporter *export.Exporter[*JobFilter], writeLog core.WriteLog, action *core.ActionConfig) *JobHandler {
 	jobType := reflect.TypeOf(Job{})
 	parameters := search.CreateParameters(reflect.TypeOf(JobFilter{}), jobType)
 	attributes := core.CreateAttributes(jobType, logError, writeLog, action)
-	return &JobHandler{service: service, Validate: validate, Attributes: attributes, Parameters: parameters}
+	return &JobHandler{service: service, Validate: validate, Attributes: attributes, Parameters: parameters, Exporter: exporter}
 }
 
 type JobHandler struct {
@@ -21,12 +24,18 @@ type JobHandler struct {
 	Validate core.Validate[*Job]
 	*core.Attributes
 	*search.Parameters
+	*export.Exporter[*JobFilter]
 }
 
 func (h *JobHandler) Load(w http.ResponseWriter, r *http.Request) {
 	id, err := core.GetRequiredString(w, r)
 	if err == nil {
 		job, err := h.service.Load(r.Context(), id)
+		if err == nil && job != nil && embedCompany(r) {
+			jobs := []Job{*job}
+			err = h.service.EmbedCompanies(r.Context(), jobs)
+			job = &jobs[0]
+		}
 		if err != nil {
 			h.Error(r.Context(), fmt.Sprintf("Error to get job '%s': %s", id, err.Error()))
 			http.Error(w, core.InternalServerError, http.StatusInternalServerError)
@@ -89,6 +98,9 @@ func (h *JobHandler) Patch(w http.ResponseWriter, r *http.Request) {
 	if er1 == nil {
 		errors, er2 := h.Validate(r.Context(), &job)
 		if !core.HasError(w, r, errors, er2, h.Error, jsonJob, h.Log, h.Resource, h.Action.Patch) {
+			if len(job.Sanitized) > 0 {
+				jsonJob["description"] = job.Description
+			}
 			res, err := h.service.Patch(r.Context(), jsonJob)
 			if err != nil {
 				h.Error(r.Context(), err.Error())
@@ -99,6 +111,9 @@ func (h *JobHandler) Patch(w http.ResponseWriter, r *http.Request) {
 
 			if res > 0 {
 				h.Log(r.Context(), h.Resource, h.Action.Patch, true, fmt.Sprintf("%s '%s'", h.Action.Patch, job.Id))
+				if len(job.Sanitized) > 0 {
+					jsonJob["sanitized"] = job.Sanitized
+				}
 				core.JSON(w, http.StatusOK, jsonJob)
 			} else if res == 0 {
 				h.Log(r.Context(), h.Resource, h.Action.Patch, false, fmt.Sprintf("not found '%s'", job.Id))
@@ -141,11 +156,65 @@ func (h *JobHandler) Search(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if len(filter.SkillMatch) > 0 && filter.SkillMatch != MatchAny && filter.SkillMatch != MatchAll {
+		core.JSON(w, http.StatusUnprocessableEntity, []core.ErrorMessage{{Field: "skillMatch", Code: "match", Param: MatchAny + "," + MatchAll}})
+		return
+	}
+	names, err := facetNames(r)
+	if err != nil {
+		core.JSON(w, http.StatusUnprocessableEntity, []core.ErrorMessage{{Field: "facets", Code: "facet", Param: strings.Join(FacetNames, ",")}})
+		return
+	}
+
 	offset := search.GetOffset(filter.Limit, filter.Page)
 	jobs, total, err := h.service.Search(r.Context(), &filter, filter.Limit, offset)
+	if err == nil && embedCompany(r) {
+		err = h.service.EmbedCompanies(r.Context(), jobs)
+	}
+	var facets Facets
+	if err == nil && len(names) > 0 {
+		facets, err = h.service.GetFacets(r.Context(), &filter, names)
+	}
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	core.JSON(w, http.StatusOK, &search.Result{List: &jobs, Total: total})
+	core.JSON(w, http.StatusOK, &JobResult{List: &jobs, Total: total, Facets: facets})
+}
+
+// embedCompany checks the query parameter embed=company, which is used for both GET and POST search
+func embedCompany(r *http.Request) bool {
+	for _, embed := range strings.Split(r.URL.Query().Get("embed"), ",") {
+		if embed == "company" {
+			return true
+		}
+	}
+	return false
+}
+
+// facetNames reads the query parameter facets, such as facets=skills,company, or facets=all for all of them
+func facetNames(r *http.Request) ([]string, error) {
+	var names []string
+	for _, name := range strings.Split(r.URL.Query().Get("facets"), ",") {
+		name = strings.TrimSpace(name)
+		if len(name) == 0 {
+			continue
+		}
+		if name == "all" {
+			return FacetNames, nil
+		}
+		if !isFacet(name) {
+			return nil, fmt.Errorf("unsupported facet '%s'", name)
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+func isFacet(name string) bool {
+	for _, facet := range FacetNames {
+		if facet == name {
+			return true
+		}
+	}
+	return false
 }
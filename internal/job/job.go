@@ -1,20 +1,72 @@
 package job
 
-import "time"
+import (
+	"time"
+
+	"go-service/pkg/sanitizer"
+)
+
+const (
+	StatusDraft = "D"
+	// StatusScheduled is set when the job is ready, so that the scheduler publishes it at published_at; drafts are never published
+	StatusScheduled = "S"
+)
+
+// IsAllowed is the transition check of the users, who can keep the status or set a draft or a scheduled job; only the scheduler publishes and expires the jobs
+func IsAllowed(from *string, to string) bool {
+	if from != nil && *from == to {
+		return true
+	}
+	return to == StatusDraft || to == StatusScheduled
+}
 
 type Job struct {
-	Id             string     `json:"id,omitempty" gorm:"primary_key;column:id" dynamodbav:"id,omitempty" firestore:"id,omitempty" validate:"max=40"`
-	Title          string     `json:"title,omitempty" gorm:"column:title" dynamodbav:"title,omitempty" firestore:"title,omitempty" validate:"omitempty,max=120"`
-	Description    *string    `json:"description,omitempty" gorm:"column:description" dynamodbav:"description,omitempty" firestore:"description,omitempty" validate:"omitempty,max=1000"`
-	PublishedAt    *time.Time `json:"publishedAt,omitempty" gorm:"column:published_at" dynamodbav:"publishedAt,omitempty" firestore:"publishedAt,omitempty"`
-	ExpiredAt      *time.Time `json:"expiredAt,omitempty" gorm:"column:expired_at" dynamodbav:"expiredAt,omitempty" firestore:"expiredAt,omitempty"`
-	Position       *string    `json:"position,omitempty" gorm:"column:position" dynamodbav:"position,omitempty" firestore:"position,omitempty" validate:"omitempty,max=100"`
-	Quantity       int32      `json:"quantity,omitempty" gorm:"column:quantity" dynamodbav:"quantity,omitempty" firestore:"quantity,omitempty"`
-	Location       *string    `json:"location,omitempty" gorm:"column:location" dynamodbav:"location,omitempty" firestore:"location,omitempty" validate:"omitempty,max=100"`
-	ApplicantCount *int32     `json:"applicantCount,omitempty" gorm:"column:applicant_count" dynamodbav:"applicantCount,omitempty" firestore:"applicantCount,omitempty"`
-	Skills         []string   `json:"skills,omitempty" gorm:"column:skills" dynamodbav:"skills,omitempty" firestore:"skills,omitempty"`
-	MinSalary      *int64     `json:"minSalary,omitempty" gorm:"column:min_salary" dynamodbav:"minSalary,omitempty" firestore:"minSalary,omitempty"`
-	MaxSalary      *int64     `json:"maxSalary,omitempty" gorm:"column:max_salary" dynamodbav:"maxSalary,omitempty" firestore:"maxSalary,omitempty"`
-	CompanyId      string     `json:"companyId,omitempty" gorm:"column:company_id" dynamodbav:"companyid,omitempty" firestore:"companyid,omitempty"`
-	// Status         *string    `json:"status,omitempty" gorm:"column:status" bson:"status" dynamodbav:"status,omitempty" firestore:"status,omitempty"`
+	Id             string              `json:"id,omitempty" gorm:"primary_key;column:id" dynamodbav:"id,omitempty" firestore:"id,omitempty" validate:"max=40"`
+	Title          string              `json:"title,omitempty" gorm:"column:title" dynamodbav:"title,omitempty" firestore:"title,omitempty" validate:"omitempty,max=120"`
+	Description    *string             `json:"description,omitempty" gorm:"column:description" dynamodbav:"description,omitempty" firestore:"description,omitempty" validate:"omitempty,max=1000"`
+	PublishedAt    *time.Time          `json:"publishedAt,omitempty" gorm:"column:published_at" dynamodbav:"publishedAt,omitempty" firestore:"publishedAt,omitempty"`
+	ExpiredAt      *time.Time          `json:"expiredAt,omitempty" gorm:"column:expired_at" dynamodbav:"expiredAt,omitempty" firestore:"expiredAt,omitempty"`
+	Position       *string             `json:"position,omitempty" gorm:"column:position" dynamodbav:"position,omitempty" firestore:"position,omitempty" validate:"omitempty,max=100"`
+	Quantity       int32               `json:"quantity,omitempty" gorm:"column:quantity" dynamodbav:"quantity,omitempty" firestore:"quantity,omitempty"`
+	Location       *string             `json:"location,omitempty" gorm:"column:location" dynamodbav:"location,omitempty" firestore:"location,omitempty" validate:"omitempty,max=100"`
+	ApplicantCount *int32              `json:"applicantCount,omitempty" gorm:"column:applicant_count;update:false;insert:false" dynamodbav:"applicantCount,omitempty" firestore:"applicantCount,omitempty"`
+	Skills         []string            `json:"skills,omitempty" gorm:"column:skills" dynamodbav:"skills,omitempty" firestore:"skills,omitempty"`
+	MinSalary      *int64              `json:"minSalary,omitempty" gorm:"column:min_salary" dynamodbav:"minSalary,omitempty" firestore:"minSalary,omitempty"`
+	MaxSalary      *int64              `json:"maxSalary,omitempty" gorm:"column:max_salary" dynamodbav:"maxSalary,omitempty" firestore:"maxSalary,omitempty"`
+	CompanyId      string              `json:"companyId,omitempty" gorm:"column:company_id" dynamodbav:"companyid,omitempty" firestore:"companyid,omitempty"`
+	Status         *string             `json:"status,omitempty" gorm:"column:status" bson:"status" dynamodbav:"status,omitempty" firestore:"status,omitempty"`
+	Sanitized      []sanitizer.Removed `json:"sanitized,omitempty"`
+	Company        *Company            `json:"company,omitempty"`
+}
+
+// Company is the summary of the company of the job, embedded on demand
+type Company struct {
+	Id       string  `json:"id" gorm:"column:id"`
+	Name     string  `json:"name,omitempty" gorm:"column:name"`
+	Slogan   *string `json:"slogan,omitempty" gorm:"column:slogan"`
+	ImageURL *string `json:"imageURL,omitempty" gorm:"column:image_url"`
+}
+
+const (
+	FacetSkills   = "skills"
+	FacetLocation = "location"
+	FacetPosition = "position"
+	FacetCompany  = "company"
+)
+
+var FacetNames = []string{FacetSkills, FacetLocation, FacetPosition, FacetCompany}
+
+type Facet struct {
+	Value string  `json:"value" gorm:"column:value"`
+	Name  *string `json:"name,omitempty" gorm:"column:name"`
+	Count int64   `json:"count" gorm:"column:count"`
+}
+
+// Facets are the counts of the jobs matching the same filter as the search, grouped by skill, location, position and company
+type Facets map[string][]Facet
+
+type JobResult struct {
+	List   *[]Job `json:"list,omitempty"`
+	Total  int64  `json:"total,omitempty"`
+	Facets Facets `json:"facets,omitempty"`
 }
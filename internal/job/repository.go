@@ -9,4 +9,7 @@ type JobRepository interface {
 	Patch(ctx context.Context, job map[string]interface{}) (int64, error)
 	Delete(ctx context.Context, id string) (int64, error)
 	Search(ctx context.Context, filter *JobFilter, limit int64, offset int64) ([]Job, int64, error)
+	ExistCompany(ctx context.Context, id string) (bool, error)
+	LoadCompanies(ctx context.Context, ids []string) ([]Company, error)
+	Facets(ctx context.Context, filter *JobFilter, name string, limit int) ([]Facet, error)
 }
@@ -14,6 +14,8 @@ type JobService interface {
 	Patch(ctx context.Context, job map[string]interface{}) (int64, error)
 	Delete(ctx context.Context, id string) (int64, error)
 	Search(ctx context.Context, filter *JobFilter, limit int64, offset int64) ([]Job, int64, error)
+	EmbedCompanies(ctx context.Context, jobs []Job) error
+	GetFacets(ctx context.Context, filter *JobFilter, names []string) (Facets, error)
 }
 
 func NewJobService(db *sql.DB, repository JobRepository) *JobUseCase {
@@ -28,18 +30,50 @@ type JobUseCase struct {
 func (s *JobUseCase) Load(ctx context.Context, id string) (*Job, error) {
 	return s.repository.Load(ctx, id)
 }
+
+// Create saves a job without status as a draft
 func (s *JobUseCase) Create(ctx context.Context, job *Job) (int64, error) {
+	if job.Status == nil || len(*job.Status) == 0 {
+		status := StatusDraft
+		job.Status = &status
+	}
 	return tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
 		return s.repository.Create(ctx, job)
 	})
 }
+
+// Update keeps the current status if the job has no status; the status is checked again in the transaction, -1 if it cannot be set
 func (s *JobUseCase) Update(ctx context.Context, job *Job) (int64, error) {
 	return tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
+		current, err := s.repository.Load(ctx, job.Id)
+		if err != nil || current == nil {
+			return 0, err
+		}
+		if job.Status == nil || len(*job.Status) == 0 {
+			job.Status = current.Status
+		} else if !IsAllowed(current.Status, *job.Status) {
+			return -1, nil
+		}
 		return s.repository.Update(ctx, job)
 	})
 }
 func (s *JobUseCase) Patch(ctx context.Context, job map[string]interface{}) (int64, error) {
 	return tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
+		if _, ok := job["status"]; ok {
+			status, _ := job["status"].(string)
+			if len(status) == 0 {
+				delete(job, "status")
+			} else {
+				id, _ := job["id"].(string)
+				current, err := s.repository.Load(ctx, id)
+				if err != nil || current == nil {
+					return 0, err
+				}
+				if !IsAllowed(current.Status, status) {
+					return -1, nil
+				}
+			}
+		}
 		return s.repository.Patch(ctx, job)
 	})
 }
@@ -51,3 +85,43 @@ func (s *JobUseCase) Delete(ctx context.Context, id string) (int64, error) {
 func (s *JobUseCase) Search(ctx context.Context, filter *JobFilter, limit int64, offset int64) ([]Job, int64, error) {
 	return s.repository.Search(ctx, filter, limit, offset)
 }
+
+// EmbedCompanies loads the companies of the jobs with one query
+func (s *JobUseCase) EmbedCompanies(ctx context.Context, jobs []Job) error {
+	var ids []string
+	for _, job := range jobs {
+		if len(job.CompanyId) > 0 {
+			ids = append(ids, job.CompanyId)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	companies, err := s.repository.LoadCompanies(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for i := range jobs {
+		for j := range companies {
+			if companies[j].Id == jobs[i].CompanyId {
+				jobs[i].Company = &companies[j]
+				break
+			}
+		}
+	}
+	return nil
+}
+
+const facetLimit = 50
+
+func (s *JobUseCase) GetFacets(ctx context.Context, filter *JobFilter, names []string) (Facets, error) {
+	facets := make(Facets)
+	for _, name := range names {
+		values, err := s.repository.Facets(ctx, filter, name, facetLimit)
+		if err != nil {
+			return nil, err
+		}
+		facets[name] = values
+	}
+	return facets, nil
+}
@@ -6,8 +6,11 @@ import (
 
 	"github.com/core-go/core"
 	v "github.com/core-go/core/validator"
-	"github.com/core-go/sql/query/builder"
+	"github.com/core-go/search"
 	"github.com/lib/pq"
+
+	"go-service/pkg/export"
+	"go-service/pkg/sanitizer"
 )
 
 type JobTransport interface {
@@ -17,19 +20,26 @@ type JobTransport interface {
 	Update(w http.ResponseWriter, r *http.Request)
 	Patch(w http.ResponseWriter, r *http.Request)
 	Delete(w http.ResponseWriter, r *http.Request)
+	Export(w http.ResponseWriter, r *http.Request)
 }
 
-func NewJobTransport(db *sql.DB, logError core.Log, writeLog core.WriteLog, action *core.ActionConfig) (JobTransport, error) {
+func NewJobTransport(db *sql.DB, logError core.Log, htmlSanitizer *sanitizer.Sanitizer, writeLog core.WriteLog, action *core.ActionConfig) (JobTransport, error) {
 	validator, err := v.NewValidator[*Job]()
 	if err != nil {
 		return nil, err
 	}
-	queryJob := builder.UseQuery[Job, *JobFilter](db, "jobs")
+	queryJob := UseQuery(pq.Array)
 	jobRepository, err := NewJobAdapter(db, queryJob, pq.Array)
 	if err != nil {
 		return nil, err
 	}
+	companyValidator := NewCompanyValidator(jobRepository.ExistCompany, validator.Validate)
+	statusValidator := NewStatusValidator(jobRepository.Load, companyValidator.Validate)
+	validate := sanitizer.NewValidator[Job](htmlSanitizer, "description", func(job *Job) (*string, *[]sanitizer.Removed) {
+		return job.Description, &job.Sanitized
+	}, statusValidator.Validate)
 	jobService := NewJobService(db, jobRepository)
-	jobHandler := NewJobHandler(jobService, logError, validator.Validate, writeLog, action)
+	jobExporter := export.NewExporter[Job, *JobFilter](db, "job", func() *JobFilter { return &JobFilter{Filter: &search.Filter{}} }, queryJob, logError, writeLog)
+	jobHandler := NewJobHandler(jobService, logError, validate, jobExporter, writeLog, action)
 	return jobHandler, nil
 }
@@ -0,0 +1,61 @@
+package job
+
+import (
+	"context"
+
+	"github.com/core-go/core"
+)
+
+func NewCompanyValidator(exist func(ctx context.Context, id string) (bool, error), validate core.Validate[*Job]) *CompanyValidator {
+	return &CompanyValidator{exist: exist, validate: validate}
+}
+
+// CompanyValidator rejects the job of a company which does not exist
+type CompanyValidator struct {
+	exist    func(ctx context.Context, id string) (bool, error)
+	validate core.Validate[*Job]
+}
+
+func (v *CompanyValidator) Validate(ctx context.Context, job *Job) ([]core.ErrorMessage, error) {
+	errors, err := v.validate(ctx, job)
+	if err != nil || len(job.CompanyId) == 0 {
+		return errors, err
+	}
+	exist, err := v.exist(ctx, job.CompanyId)
+	if err != nil {
+		return errors, err
+	}
+	if !exist {
+		errors = append(errors, core.ErrorMessage{Field: "companyId", Code: "exist", Param: job.CompanyId})
+	}
+	return errors, nil
+}
+
+func NewStatusValidator(load func(ctx context.Context, id string) (*Job, error), validate core.Validate[*Job]) *StatusValidator {
+	return &StatusValidator{load: load, validate: validate}
+}
+
+// StatusValidator rejects the status which the users cannot set, see IsAllowed
+type StatusValidator struct {
+	load     func(ctx context.Context, id string) (*Job, error)
+	validate core.Validate[*Job]
+}
+
+func (v *StatusValidator) Validate(ctx context.Context, job *Job) ([]core.ErrorMessage, error) {
+	errors, err := v.validate(ctx, job)
+	if err != nil || job.Status == nil || len(*job.Status) == 0 {
+		return errors, err
+	}
+	current, err := v.load(ctx, job.Id)
+	if err != nil {
+		return errors, err
+	}
+	var status *string
+	if current != nil {
+		status = current.Status
+	}
+	if !IsAllowed(status, *job.Status) {
+		errors = append(errors, core.ErrorMessage{Field: "status", Code: "transition"})
+	}
+	return errors, nil
+}
@@ -0,0 +1,54 @@
+package ldap
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	s "github.com/core-go/sql"
+)
+
+func NewUserAdapter(db *sql.DB, generateId func(context.Context) (string, error), status string) *UserAdapter {
+	return &UserAdapter{DB: db, BuildParam: s.GetBuild(db), generateId: generateId, status: status}
+}
+
+type UserAdapter struct {
+	DB         *sql.DB
+	BuildParam func(int) string
+	generateId func(context.Context) (string, error)
+	status     string
+}
+
+// Save updates the user of the username with the attributes of the directory, or creates the user on the first login.
+// The users created here have the auth source of the directory; a local account of the same username is never linked, the id is empty then.
+func (r *UserAdapter) Save(ctx context.Context, entry Entry) (string, error) {
+	var userId, source string
+	query := fmt.Sprintf("select user_id, auth_source from users where username = %s limit 1", r.BuildParam(1))
+	err := r.DB.QueryRowContext(ctx, query, entry.Username).Scan(&userId, &source)
+	if err != nil && err != sql.ErrNoRows {
+		return "", err
+	}
+	now := time.Now()
+	if len(userId) > 0 {
+		if source != Source {
+			return "", nil
+		}
+		query = fmt.Sprintf(`update users set
+			display_name = coalesce(nullif(%s, ''), display_name),
+			email = coalesce(nullif(%s, ''), email),
+			phone = coalesce(nullif(%s, ''), phone)
+			where user_id = %s`, r.BuildParam(1), r.BuildParam(2), r.BuildParam(3), r.BuildParam(4))
+		_, err = r.DB.ExecContext(ctx, query, entry.DisplayName, entry.Email, entry.Phone, userId)
+		return userId, err
+	}
+	userId, err = r.generateId(ctx)
+	if err != nil {
+		return "", err
+	}
+	query = fmt.Sprintf(`insert into users (user_id, username, email, display_name, phone, status, auth_source, created_by, created_at, updated_by, updated_at)
+		values (%s, %s, %s, nullif(%s, ''), nullif(%s, ''), %s, %s, %s, %s, %s, %s)`,
+		r.BuildParam(1), r.BuildParam(2), r.BuildParam(3), r.BuildParam(4), r.BuildParam(5), r.BuildParam(6), r.BuildParam(7), r.BuildParam(8), r.BuildParam(9), r.BuildParam(10), r.BuildParam(11))
+	_, err = r.DB.ExecContext(ctx, query, userId, entry.Username, entry.Email, entry.DisplayName, entry.Phone, r.status, Source, userId, now, userId, now)
+	return userId, err
+}
@@ -0,0 +1,75 @@
+package ldap
+
+import (
+	"context"
+	"strings"
+
+	auth "github.com/core-go/authentication"
+	"github.com/core-go/core"
+)
+
+type UserRepository interface {
+	Save(ctx context.Context, entry Entry) (string, error)
+}
+
+func NewLDAPChecker(bind func(string, string) (*Entry, error), repository UserRepository, status auth.Status) *LDAPChecker {
+	return &LDAPChecker{bind: bind, repository: repository, status: status}
+}
+
+type LDAPChecker struct {
+	bind       func(string, string) (*Entry, error)
+	repository UserRepository
+	status     auth.Status
+}
+
+// Check binds to the directory as the user, then keeps the users row in sync so that roles, privileges and status still come from SQL.
+// It fails when the username belongs to a local account, so that the account is only authenticated by its own password.
+func (c *LDAPChecker) Check(ctx context.Context, info auth.AuthInfo) (auth.AuthResult, error) {
+	result := auth.AuthResult{Status: c.status.Fail}
+	entry, err := c.bind(info.Username, info.Password)
+	if err != nil || entry == nil {
+		return result, err
+	}
+	userId, err := c.repository.Save(ctx, *entry)
+	if err != nil || len(userId) == 0 {
+		return result, err
+	}
+	result.Status = c.status.Success
+	result.User = &auth.UserAccount{Id: userId, Username: entry.Username, DisplayName: &entry.DisplayName, Email: &entry.Email}
+	return result, nil
+}
+
+type Authenticate func(ctx context.Context, info auth.AuthInfo) (auth.AuthResult, error)
+
+func NewAuthenticator(directory Authenticate, local Authenticate, users string, status auth.Status, logError core.Log) *Authenticator {
+	allowed := make(map[string]bool)
+	for _, user := range strings.Split(users, ",") {
+		user = strings.TrimSpace(user)
+		if len(user) > 0 {
+			allowed[user] = true
+		}
+	}
+	return &Authenticator{directory: directory, local: local, users: allowed, status: status, logError: logError}
+}
+
+type Authenticator struct {
+	directory Authenticate
+	local     Authenticate
+	users     map[string]bool
+	status    auth.Status
+	logError  core.Log
+}
+
+// Authenticate tries the directory for the users of the allowlist (or everyone when the allowlist is empty), and SQL for local accounts,
+// or when the directory does not know the user or is not reachable
+func (a *Authenticator) Authenticate(ctx context.Context, info auth.AuthInfo) (auth.AuthResult, error) {
+	if info.Step <= 0 && (len(a.users) == 0 || a.users[info.Username]) {
+		result, err := a.directory(ctx, info)
+		if err != nil {
+			a.logError(ctx, "LDAP authentication of "+info.Username+": "+err.Error())
+		} else if result.Status != a.status.Fail && result.Status != a.status.NotFound && result.Status != a.status.WrongPassword {
+			return result, nil
+		}
+	}
+	return a.local(ctx, info)
+}
@@ -0,0 +1,114 @@
+package ldap
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	auth "github.com/core-go/authentication"
+)
+
+var status = auth.Status{Success: 1, Fail: 2, NotFound: 3, WrongPassword: 4, Locked: 5}
+
+type fakeUsers struct {
+	ids   map[string]string
+	saved []Entry
+	err   error
+}
+
+func (r *fakeUsers) Save(ctx context.Context, entry Entry) (string, error) {
+	r.saved = append(r.saved, entry)
+	return r.ids[entry.Username], r.err
+}
+
+func bind(username string, password string) (*Entry, error) {
+	switch {
+	case password == "down":
+		return nil, errors.New("connection refused")
+	case password == "secret":
+		return &Entry{Username: username, DisplayName: "John Doe", Email: username + "@example.com"}, nil
+	}
+	return nil, nil
+}
+
+func TestLDAPCheckerCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		username string
+		password string
+		ids      map[string]string
+		err      error
+		status   int
+		saved    int
+		fails    bool
+	}{
+		{"provisioned", "john.doe", "secret", map[string]string{"john.doe": "u1"}, nil, status.Success, 1, false},
+		{"invalid credentials", "john.doe", "wrong", map[string]string{"john.doe": "u1"}, nil, status.Fail, 0, false},
+		{"local account", "admin", "secret", map[string]string{}, nil, status.Fail, 1, false},
+		{"directory error", "john.doe", "down", nil, nil, status.Fail, 0, true},
+		{"repository error", "john.doe", "secret", nil, errors.New("db"), status.Fail, 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := &fakeUsers{ids: tt.ids, err: tt.err}
+			checker := NewLDAPChecker(bind, users, status)
+			result, err := checker.Check(context.Background(), auth.AuthInfo{Username: tt.username, Password: tt.password})
+			if (err != nil) != tt.fails {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if result.Status != tt.status || len(users.saved) != tt.saved {
+				t.Errorf("got status %d and %d saves, expected %d and %d", result.Status, len(users.saved), tt.status, tt.saved)
+			}
+			if tt.status == status.Success {
+				if result.User == nil || result.User.Id != "u1" || *result.User.Email != "john.doe@example.com" {
+					t.Errorf("unexpected user %+v", result.User)
+				}
+				if users.saved[0].DisplayName != "John Doe" {
+					t.Errorf("the attributes of the directory are not saved: %+v", users.saved[0])
+				}
+			}
+		})
+	}
+}
+
+func TestAuthenticatorAuthenticate(t *testing.T) {
+	directory := func(result int, err error) Authenticate {
+		return func(ctx context.Context, info auth.AuthInfo) (auth.AuthResult, error) {
+			return auth.AuthResult{Status: result, Message: "directory"}, err
+		}
+	}
+	local := func(ctx context.Context, info auth.AuthInfo) (auth.AuthResult, error) {
+		return auth.AuthResult{Status: status.Success, Message: "local"}, nil
+	}
+	tests := []struct {
+		name      string
+		directory Authenticate
+		users     string
+		info      auth.AuthInfo
+		expected  string
+		logged    bool
+	}{
+		{"directory", directory(status.Success, nil), "", auth.AuthInfo{Username: "john.doe"}, "directory", false},
+		{"allowlist", directory(status.Success, nil), "jane.doe, john.doe", auth.AuthInfo{Username: "john.doe"}, "directory", false},
+		{"not in allowlist", directory(status.Success, nil), "jane.doe", auth.AuthInfo{Username: "john.doe"}, "local", false},
+		{"wrong password", directory(status.Fail, nil), "", auth.AuthInfo{Username: "john.doe"}, "local", false},
+		{"not found", directory(status.NotFound, nil), "", auth.AuthInfo{Username: "john.doe"}, "local", false},
+		{"directory error", directory(status.Fail, errors.New("timeout")), "", auth.AuthInfo{Username: "john.doe"}, "local", true},
+		{"locked in SQL", directory(status.Locked, nil), "", auth.AuthInfo{Username: "john.doe"}, "directory", false},
+		{"second step", directory(status.Success, nil), "", auth.AuthInfo{Username: "john.doe", Step: 1}, "local", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logged := false
+			logError := func(ctx context.Context, msg string, fields ...map[string]interface{}) { logged = true }
+			a := NewAuthenticator(tt.directory, local, tt.users, status, logError)
+			result, err := a.Authenticate(context.Background(), tt.info)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Message != tt.expected || logged != tt.logged {
+				t.Errorf("got %s (logged %v), expected %s (logged %v)", result.Message, logged, tt.expected, tt.logged)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package ldap
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	la "github.com/core-go/authentication/ldap"
+	ld "github.com/go-ldap/ldap/v3"
+)
+
+func NewClient(conf la.LDAPConfig) *Client {
+	timeout := time.Duration(conf.Timeout) * time.Millisecond
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	filter := conf.Filter
+	if len(filter) == 0 {
+		filter = "uid"
+	}
+	startTLS := conf.StartTLS != nil && *conf.StartTLS
+	useTLS := conf.TLS != nil && *conf.TLS
+	insecure := conf.InsecureSkipVerify != nil && *conf.InsecureSkipVerify
+	server := conf.Server
+	if !strings.Contains(server, "://") {
+		if useTLS && !startTLS {
+			server = "ldaps://" + server
+		} else {
+			server = "ldap://" + server
+		}
+	}
+	var host string
+	if u, err := url.Parse(server); err == nil {
+		host = u.Hostname()
+	}
+	var attributes []string
+	for _, attribute := range []string{conf.DisplayName, conf.Email, conf.Phone} {
+		if len(attribute) > 0 {
+			attributes = append(attributes, attribute)
+		}
+	}
+	return &Client{
+		Server:     server,
+		BaseDN:     conf.BaseDN,
+		Domain:     conf.Domain,
+		Filter:     filter,
+		StartTLS:   startTLS,
+		TLSConfig:  &tls.Config{ServerName: host, InsecureSkipVerify: insecure},
+		Timeout:    timeout,
+		Conf:       conf,
+		attributes: attributes,
+	}
+}
+
+type Client struct {
+	Server     string
+	BaseDN     string
+	Domain     string
+	Filter     string
+	StartTLS   bool
+	TLSConfig  *tls.Config
+	Timeout    time.Duration
+	Conf       la.LDAPConfig
+	attributes []string
+}
+
+// Bind binds as the user and reads the attributes of the user, it returns nil when the directory refuses the credentials
+func (c *Client) Bind(username string, password string) (*Entry, error) {
+	// an empty password would be an unauthenticated bind, which most directories accept
+	if len(password) == 0 {
+		return nil, nil
+	}
+	conn, err := ld.DialURL(c.Server, ld.DialWithDialer(&net.Dialer{Timeout: c.Timeout}), ld.DialWithTLSConfig(c.TLSConfig))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	conn.SetTimeout(c.Timeout)
+	if c.StartTLS {
+		if err = conn.StartTLS(c.TLSConfig); err != nil {
+			return nil, err
+		}
+	}
+	if err = conn.Bind(c.bindName(username), password); err != nil {
+		if ld.IsErrorWithCode(err, ld.LDAPResultInvalidCredentials) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	entry := &Entry{Username: username}
+	if len(c.attributes) == 0 {
+		return entry, nil
+	}
+	filter := fmt.Sprintf("(%s=%s)", c.Filter, ld.EscapeFilter(username))
+	request := ld.NewSearchRequest(c.BaseDN, ld.ScopeWholeSubtree, ld.NeverDerefAliases, 1, int(c.Timeout/time.Second), false, filter, c.attributes, nil)
+	res, err := conn.Search(request)
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Entries) > 0 {
+		e := res.Entries[0]
+		entry.DisplayName = attribute(e, c.Conf.DisplayName)
+		entry.Email = attribute(e, c.Conf.Email)
+		entry.Phone = attribute(e, c.Conf.Phone)
+	}
+	return entry, nil
+}
+
+// bindName is user@domain for Active Directory, otherwise the DN of the user under base_dn
+func (c *Client) bindName(username string) string {
+	if len(c.Domain) > 0 {
+		return username + "@" + c.Domain
+	}
+	return fmt.Sprintf("%s=%s,%s", c.Filter, ld.EscapeDN(username), c.BaseDN)
+}
+
+func attribute(entry *ld.Entry, name string) string {
+	if len(name) == 0 {
+		return ""
+	}
+	return entry.GetAttributeValue(name)
+}
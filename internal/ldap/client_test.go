@@ -0,0 +1,119 @@
+package ldap
+
+import (
+	"net"
+	"testing"
+
+	la "github.com/core-go/authentication/ldap"
+	ber "github.com/go-asn1-ber/asn1-ber"
+	ld "github.com/go-ldap/ldap/v3"
+)
+
+// serve is a small LDAP server, which answers the simple binds with the passwords of the DNs and the searches with the attributes of the bound DN
+func serve(t *testing.T, passwords map[string]string, attributes map[string]map[string]string) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go handle(conn, passwords, attributes)
+		}
+	}()
+	return "ldap://" + listener.Addr().String()
+}
+
+func handle(conn net.Conn, passwords map[string]string, attributes map[string]map[string]string) {
+	defer conn.Close()
+	var dn string
+	for {
+		p, err := ber.ReadPacket(conn)
+		if err != nil || len(p.Children) < 2 {
+			return
+		}
+		id := p.Children[0].Value.(int64)
+		op := p.Children[1]
+		switch op.Tag {
+		case ld.ApplicationBindRequest:
+			name := op.Children[1].Data.String()
+			code := uint16(ld.LDAPResultInvalidCredentials)
+			if password, ok := passwords[name]; ok && password == op.Children[2].Data.String() {
+				code, dn = ld.LDAPResultSuccess, name
+			}
+			conn.Write(response(id, ld.ApplicationBindResponse, code).Bytes())
+		case ld.ApplicationSearchRequest:
+			entry := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ld.ApplicationSearchResultEntry, nil, "")
+			entry.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, dn, ""))
+			list := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+			for name, value := range attributes[dn] {
+				attribute := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+				attribute.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, ""))
+				values := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "")
+				values.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, value, ""))
+				attribute.AppendChild(values)
+				list.AppendChild(attribute)
+			}
+			entry.AppendChild(list)
+			message := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+			message.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, ""))
+			message.AppendChild(entry)
+			conn.Write(message.Bytes())
+			conn.Write(response(id, ld.ApplicationSearchResultDone, ld.LDAPResultSuccess).Bytes())
+		default:
+			return
+		}
+	}
+}
+
+func response(id int64, tag ber.Tag, code uint16) *ber.Packet {
+	message := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+	message.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, ""))
+	res := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "")
+	res.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(code), ""))
+	res.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""))
+	res.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""))
+	message.AppendChild(res)
+	return message
+}
+
+func TestClientBind(t *testing.T) {
+	dn := "uid=john.doe,dc=example,dc=com"
+	server := serve(t, map[string]string{dn: "secret"}, map[string]map[string]string{dn: {"cn": "John Doe", "mail": "john.doe@example.com"}})
+	client := NewClient(la.LDAPConfig{Server: server, BaseDN: "dc=example,dc=com", DisplayName: "cn", Email: "mail", Timeout: 2000})
+
+	entry, err := client.Bind("john.doe", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry == nil || entry.Username != "john.doe" || entry.DisplayName != "John Doe" || entry.Email != "john.doe@example.com" {
+		t.Errorf("unexpected entry %+v", entry)
+	}
+
+	entry, err = client.Bind("john.doe", "wrong")
+	if err != nil || entry != nil {
+		t.Errorf("expected no entry for invalid credentials, got %+v, %v", entry, err)
+	}
+
+	entry, err = client.Bind("john.doe", "")
+	if err != nil || entry != nil {
+		t.Errorf("expected no entry for an empty password, got %+v, %v", entry, err)
+	}
+}
+
+func TestClientBindUnreachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := "ldap://" + listener.Addr().String()
+	listener.Close()
+	client := NewClient(la.LDAPConfig{Server: server, BaseDN: "dc=example,dc=com", Timeout: 2000})
+	if _, err = client.Bind("john.doe", "secret"); err == nil {
+		t.Error("expected an error when the directory is not reachable")
+	}
+}
@@ -0,0 +1,31 @@
+package ldap
+
+import (
+	"context"
+	"database/sql"
+
+	auth "github.com/core-go/authentication"
+	la "github.com/core-go/authentication/ldap"
+	"github.com/core-go/core"
+)
+
+// Source is the auth source of the users provisioned from the directory; the other users are local, see users.auth_source
+const Source = "ldap"
+
+type Entry struct {
+	Username    string
+	DisplayName string
+	Email       string
+	Phone       string
+}
+
+// NewLDAPAuthenticator builds the LDAP authenticator on the same user repository, token and privileges as the SQL one, and falls back to local
+func NewLDAPAuthenticator(db *sql.DB, conf la.LDAPConfig, status auth.Status, userStatus auth.UserStatusConfig, repository auth.UserRepository,
+	generateToken func(interface{}, string, int64) (string, error), tokenConfig auth.TokenConfig, payloadConfig auth.PayloadConfig,
+	loadPrivileges func(context.Context, string) ([]auth.Privilege, error), local Authenticate,
+	generateId func(context.Context) (string, error), logError core.Log) *Authenticator {
+	userAdapter := NewUserAdapter(db, generateId, userStatus.Activated)
+	checker := NewLDAPChecker(NewClient(conf).Bind, userAdapter, status)
+	directory := auth.NewBasicAuthenticator(status, checker.Check, repository, generateToken, tokenConfig, payloadConfig, loadPrivileges)
+	return NewAuthenticator(directory.Authenticate, local, conf.Users, status, logError)
+}
@@ -0,0 +1,143 @@
+package media
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+
+	s "github.com/core-go/sql"
+)
+
+func NewMediaAdapter(db *sql.DB, buildQuery func(*MediaFilter) (string, []interface{}), toArray s.Array) (*MediaAdapter, error) {
+	parameters, err := s.CreateParameters(reflect.TypeOf(Media{}), db)
+	if err != nil {
+		return nil, err
+	}
+	variantParameters, err := s.CreateParameters(reflect.TypeOf(Variant{}), db)
+	if err != nil {
+		return nil, err
+	}
+	return &MediaAdapter{DB: db, Parameters: parameters, VariantParameters: variantParameters, BuildQuery: buildQuery, Array: toArray}, nil
+}
+
+type MediaAdapter struct {
+	DB         *sql.DB
+	BuildQuery func(*MediaFilter) (string, []interface{})
+	*s.Parameters
+	VariantParameters *s.Parameters
+	Array             s.Array
+}
+
+func (r *MediaAdapter) Load(ctx context.Context, id string) (*Media, error) {
+	var media []Media
+	query := fmt.Sprintf("select %s from media where id = %s limit 1", r.Fields, r.BuildParam(1))
+	err := s.Query(ctx, r.DB, r.Map, &media, query, id)
+	if err != nil || len(media) == 0 {
+		return nil, err
+	}
+	err = r.loadVariants(ctx, media)
+	return &media[0], err
+}
+
+func (r *MediaAdapter) Create(ctx context.Context, media *Media) (int64, error) {
+	query, args := s.BuildToInsert("media", media, r.BuildParam, r.Schema)
+	tx := s.GetTx(ctx, r.DB)
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return -1, err
+	}
+	for i := range media.Variants {
+		media.Variants[i].MediaId = media.Id
+		query, args := s.BuildToInsert("media_variants", &media.Variants[i], r.BuildParam, r.VariantParameters.Schema)
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return -1, err
+		}
+	}
+	return res.RowsAffected()
+}
+
+func (r *MediaAdapter) Patch(ctx context.Context, media map[string]interface{}) (int64, error) {
+	colMap := s.JSONToColumns(media, r.JsonColumnMap)
+	query, args := s.BuildToPatch("media", colMap, r.Keys, r.BuildParam)
+	tx := s.GetTx(ctx, r.DB)
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *MediaAdapter) Delete(ctx context.Context, id string) (int64, error) {
+	tx := s.GetTx(ctx, r.DB)
+	_, err := tx.ExecContext(ctx, fmt.Sprintf("delete from media_variants where media_id = %s", r.BuildParam(1)), id)
+	if err != nil {
+		return -1, err
+	}
+	res, err := tx.ExecContext(ctx, fmt.Sprintf("delete from media where id = %s", r.BuildParam(1)), id)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *MediaAdapter) Search(ctx context.Context, filter *MediaFilter, limit int64, offset int64) ([]Media, int64, error) {
+	var media []Media
+	if limit <= 0 {
+		return media, 0, nil
+	}
+	query, params := r.BuildQuery(filter)
+	pagingQuery := s.BuildPagingQuery(query, limit, offset)
+	countQuery := s.BuildCountQuery(query)
+
+	total, err := s.Count(ctx, r.DB, countQuery, params...)
+	if err != nil || total == 0 {
+		return media, total, err
+	}
+	err = s.Query(ctx, r.DB, r.Map, &media, pagingQuery, params...)
+	if err != nil {
+		return media, total, err
+	}
+	err = r.loadVariants(ctx, media)
+	return media, total, err
+}
+
+// GetUsages finds the records linking to any of the urls, or embedding a url matching the pattern in their rich text
+func (r *MediaAdapter) GetUsages(ctx context.Context, urls []string, pattern string) ([]Usage, error) {
+	var usages []Usage
+	query := `
+		select 'article' as resource, id, 'thumbnail' as field from articles where thumbnail = any($1)
+		union all select 'article', id, 'highThumbnail' from articles where high_thumbnail = any($1)
+		union all select 'article', id, 'content' from articles where content like $2
+		union all select 'content', id || '/' || lang, 'body' from contents where body like $2
+		union all select 'job', id, 'description' from jobs where description like $2
+		union all select 'user', user_id, 'imageURL' from users where image_url = any($1)
+		union all select 'company', id, 'imageURL' from companies where image_url = any($1)
+		union all select 'company', id, 'coverURL' from companies where cover_url = any($1)`
+	err := s.Query(ctx, s.GetTx(ctx, r.DB), nil, &usages, query, r.Array(urls), pattern)
+	return usages, err
+}
+
+func (r *MediaAdapter) loadVariants(ctx context.Context, media []Media) error {
+	if len(media) == 0 {
+		return nil
+	}
+	ids := make([]string, len(media))
+	for i := range media {
+		ids[i] = media[i].Id
+	}
+	var variants []Variant
+	query := fmt.Sprintf("select %s from media_variants where media_id = any(%s) order by media_id, width", r.VariantParameters.Fields, r.BuildParam(1))
+	err := s.Query(ctx, r.DB, r.VariantParameters.Map, &variants, query, r.Array(ids))
+	if err != nil {
+		return err
+	}
+	for i := range media {
+		for _, variant := range variants {
+			if variant.MediaId == media[i].Id {
+				media[i].Variants = append(media[i].Variants, variant)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+package media
+
+import "github.com/core-go/search"
+
+type MediaFilter struct {
+	*search.Filter
+	Id         string            `json:"id" gorm:"primary_key;column:id" bson:"_id" dynamodbav:"id,omitempty" firestore:"-" match:"equal"`
+	Name       string            `json:"name,omitempty" gorm:"column:name" bson:"name,omitempty" dynamodbav:"name,omitempty" firestore:"name,omitempty"`
+	MimeType   []string          `json:"mimeType,omitempty" gorm:"column:mime_type" bson:"mimeType,omitempty" dynamodbav:"mimeType,omitempty" firestore:"mimeType,omitempty" match:"equal"`
+	Alt        string            `json:"alt,omitempty" gorm:"column:alt" bson:"alt,omitempty" dynamodbav:"alt,omitempty" firestore:"alt,omitempty"`
+	UploadedBy string            `json:"uploadedBy,omitempty" gorm:"column:uploaded_by" bson:"uploadedBy,omitempty" dynamodbav:"uploadedBy,omitempty" firestore:"uploadedBy,omitempty" match:"equal"`
+	UploadedAt *search.TimeRange `json:"uploadedAt,omitempty" gorm:"column:uploaded_at" bson:"uploadedAt,omitempty" dynamodbav:"uploadedAt,omitempty" firestore:"uploadedAt,omitempty"`
+}
@@ -0,0 +1,159 @@
+package media
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/core-go/core"
+	"github.com/core-go/search"
+
+	"go-service/pkg/export"
+)
+
+func NewMediaHandler(service MediaService, logError core.Log, validate core.Validate[*Media], maxSize int64, types []string, exporter *export.Exporter[*MediaFilter], writeLog core.WriteLog, action *core.ActionConfig) *MediaHandler {
+	mediaType := reflect.TypeOf(Media{})
+	parameters := search.CreateParameters(reflect.TypeOf(MediaFilter{}), mediaType)
+	attributes := core.CreateAttributes(mediaType, logError, writeLog, action)
+	return &MediaHandler{service: service, Validate: validate, maxSize: maxSize, types: types, Attributes: attributes, Parameters: parameters, Exporter: exporter}
+}
+
+type MediaHandler struct {
+	service  MediaService
+	Validate core.Validate[*Media]
+	maxSize  int64
+	types    []string
+	*core.Attributes
+	*search.Parameters
+	*export.Exporter[*MediaFilter]
+}
+
+func (h *MediaHandler) Load(w http.ResponseWriter, r *http.Request) {
+	id, err := core.GetRequiredString(w, r)
+	if err == nil {
+		media, err := h.service.Load(r.Context(), id)
+		if err != nil {
+			h.Error(r.Context(), fmt.Sprintf("Error to get media '%s': %s", id, err.Error()))
+			http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+			return
+		}
+		core.JSON(w, core.IsFound(media), media)
+	}
+}
+func (h *MediaHandler) Upload(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, h.maxSize)
+	if err := r.ParseMultipartForm(h.maxSize); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "file is required", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	mimeType := strings.Split(http.DetectContentType(data), ";")[0]
+	if !h.isAllowed(mimeType) {
+		h.Log(r.Context(), h.Resource, h.Action.Create, false, fmt.Sprintf("unsupported type '%s' of '%s'", mimeType, header.Filename))
+		core.JSON(w, http.StatusUnprocessableEntity, []core.ErrorMessage{{Field: "file", Code: "type", Param: mimeType}})
+		return
+	}
+	alt := r.FormValue("alt")
+	if len(alt) > 255 {
+		core.JSON(w, http.StatusUnprocessableEntity, []core.ErrorMessage{{Field: "alt", Code: "max", Param: "255"}})
+		return
+	}
+	if width, height, ok := GetDimensions(data); ok && IsResizable(mimeType) && IsTooLarge(width, height) {
+		h.Log(r.Context(), h.Resource, h.Action.Create, false, fmt.Sprintf("too large image '%s' of %dx%d", header.Filename, width, height))
+		core.JSON(w, http.StatusUnprocessableEntity, []core.ErrorMessage{{Field: "file", Code: "max", Param: strconv.Itoa(MaxPixels)}})
+		return
+	}
+	media, err := h.service.Upload(r.Context(), header.Filename, mimeType, alt, data)
+	if err != nil {
+		h.Error(r.Context(), err.Error())
+		h.Log(r.Context(), h.Resource, h.Action.Create, false, err.Error())
+		http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+		return
+	}
+	h.Log(r.Context(), h.Resource, h.Action.Create, true, fmt.Sprintf("%s '%s' '%s'", h.Action.Create, media.Id, media.Name))
+	core.JSON(w, http.StatusCreated, media)
+}
+func (h *MediaHandler) Patch(w http.ResponseWriter, r *http.Request) {
+	r, media, jsonMedia, er1 := core.BuildMapAndCheckId[Media](w, r, h.Keys, h.Indexes)
+	if er1 == nil {
+		errors, er2 := h.Validate(r.Context(), &media)
+		if !core.HasError(w, r, errors, er2, h.Error, jsonMedia, h.Log, h.Resource, h.Action.Patch) {
+			res, err := h.service.Patch(r.Context(), jsonMedia)
+			if err != nil {
+				h.Error(r.Context(), err.Error())
+				h.Log(r.Context(), h.Resource, h.Action.Patch, false, err.Error())
+				http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+				return
+			}
+
+			if res > 0 {
+				h.Log(r.Context(), h.Resource, h.Action.Patch, true, fmt.Sprintf("%s '%s'", h.Action.Patch, media.Id))
+				core.JSON(w, http.StatusOK, jsonMedia)
+			} else {
+				h.Log(r.Context(), h.Resource, h.Action.Patch, false, fmt.Sprintf("not found '%s'", media.Id))
+				core.JSON(w, http.StatusNotFound, res)
+			}
+		}
+	}
+}
+func (h *MediaHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := core.GetRequiredString(w, r)
+	if err == nil {
+		usages, res, err := h.service.Delete(r.Context(), id)
+		if err != nil {
+			h.Error(r.Context(), err.Error())
+			h.Log(r.Context(), h.Resource, h.Action.Delete, false, err.Error())
+			http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+			return
+		}
+
+		if res > 0 {
+			h.Log(r.Context(), h.Resource, h.Action.Delete, true, fmt.Sprintf("%s '%s'", h.Action.Delete, id))
+			core.JSON(w, http.StatusOK, res)
+		} else if res == 0 {
+			h.Log(r.Context(), h.Resource, h.Action.Delete, false, fmt.Sprintf("not found '%s'", id))
+			core.JSON(w, http.StatusNotFound, res)
+		} else {
+			h.Log(r.Context(), h.Resource, h.Action.Delete, false, fmt.Sprintf("in use '%s'", id))
+			core.JSON(w, http.StatusConflict, usages)
+		}
+	}
+}
+func (h *MediaHandler) Search(w http.ResponseWriter, r *http.Request) {
+	filter := MediaFilter{Filter: &search.Filter{}}
+	err := search.Decode(r, &filter, h.ParamIndex, h.FilterIndex)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	offset := search.GetOffset(filter.Limit, filter.Page)
+	media, total, err := h.service.Search(r.Context(), &filter, filter.Limit, offset)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	core.JSON(w, http.StatusOK, &search.Result{List: &media, Total: total})
+}
+
+func (h *MediaHandler) isAllowed(mimeType string) bool {
+	for _, t := range h.types {
+		if t == mimeType {
+			return true
+		}
+	}
+	return false
+}
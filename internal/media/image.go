@@ -0,0 +1,102 @@
+package media
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+)
+
+var extensions = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/gif":       ".gif",
+	"application/pdf": ".pdf",
+}
+
+// MaxPixels is the maximum width×height of the images to decode, so that a small file cannot expand into gigabytes of memory
+const MaxPixels = 40000000
+
+var ErrTooLarge = errors.New("image is too large")
+
+// IsResizable returns true for the image types which can be decoded and encoded with the standard library
+func IsResizable(mimeType string) bool {
+	return mimeType == "image/jpeg" || mimeType == "image/png" || mimeType == "image/gif"
+}
+
+// Resize scales the image down to the width, keeping the aspect ratio, images smaller than the width are not enlarged
+func Resize(data []byte, mimeType string, width int) ([]byte, int, int, error) {
+	conf, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	if IsTooLarge(conf.Width, conf.Height) {
+		return nil, 0, 0, ErrTooLarge
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	bounds := src.Bounds()
+	if width <= 0 || width > bounds.Dx() {
+		width = bounds.Dx()
+	}
+	height := bounds.Dy() * width / bounds.Dx()
+	if height < 1 {
+		height = 1
+	}
+	dst := scale(src, width, height)
+	var buf bytes.Buffer
+	switch mimeType {
+	case "image/png":
+		err = png.Encode(&buf, dst)
+	case "image/gif":
+		err = gif.Encode(&buf, dst, nil)
+	default:
+		err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 85})
+	}
+	return buf.Bytes(), width, height, err
+}
+
+func IsTooLarge(width int, height int) bool {
+	return int64(width)*int64(height) > MaxPixels
+}
+
+// scale averages the source pixels covered by each destination pixel
+func scale(src image.Image, width int, height int) *image.RGBA {
+	bounds := src.Bounds()
+	sw, sh := bounds.Dx(), bounds.Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0, y1 := y*sh/height, (y+1)*sh/height
+		if y1 <= y0 {
+			y1 = y0 + 1
+		}
+		for x := 0; x < width; x++ {
+			x0, x1 := x*sw/width, (x+1)*sw/width
+			if x1 <= x0 {
+				x1 = x0 + 1
+			}
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(bounds.Min.X+sx, bounds.Min.Y+sy).RGBA()
+					r, g, b, a, n = r+uint64(cr), g+uint64(cg), b+uint64(cb), a+uint64(ca), n+1
+				}
+			}
+			dst.SetRGBA64(x, y, color.RGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(b / n), A: uint16(a / n)})
+		}
+	}
+	return dst
+}
+
+func GetDimensions(data []byte) (int, int, bool) {
+	conf, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return 0, 0, false
+	}
+	return conf.Width, conf.Height, true
+}
@@ -0,0 +1,48 @@
+package media
+
+import "time"
+
+type Media struct {
+	Id         string     `json:"id" gorm:"primary_key;column:id" bson:"_id" dynamodbav:"id,omitempty" firestore:"-"`
+	Name       string     `json:"name,omitempty" gorm:"column:name" bson:"name,omitempty" dynamodbav:"name,omitempty" firestore:"name,omitempty" validate:"max=255"`
+	Url        string     `json:"url,omitempty" gorm:"column:url;update:false" bson:"url,omitempty" dynamodbav:"url,omitempty" firestore:"url,omitempty"`
+	Path       string     `json:"path,omitempty" gorm:"column:path;update:false" bson:"path,omitempty" dynamodbav:"path,omitempty" firestore:"path,omitempty"`
+	MimeType   string     `json:"mimeType,omitempty" gorm:"column:mime_type;update:false" bson:"mimeType,omitempty" dynamodbav:"mimeType,omitempty" firestore:"mimeType,omitempty"`
+	Size       int64      `json:"size,omitempty" gorm:"column:size;update:false" bson:"size,omitempty" dynamodbav:"size,omitempty" firestore:"size,omitempty"`
+	Width      *int       `json:"width,omitempty" gorm:"column:width;update:false" bson:"width,omitempty" dynamodbav:"width,omitempty" firestore:"width,omitempty"`
+	Height     *int       `json:"height,omitempty" gorm:"column:height;update:false" bson:"height,omitempty" dynamodbav:"height,omitempty" firestore:"height,omitempty"`
+	Alt        *string    `json:"alt,omitempty" gorm:"column:alt" bson:"alt,omitempty" dynamodbav:"alt,omitempty" firestore:"alt,omitempty" validate:"omitempty,max=255"`
+	UploadedBy *string    `json:"uploadedBy,omitempty" gorm:"column:uploaded_by;update:false" bson:"uploadedBy,omitempty" dynamodbav:"uploadedBy,omitempty" firestore:"uploadedBy,omitempty"`
+	UploadedAt *time.Time `json:"uploadedAt,omitempty" gorm:"column:uploaded_at;update:false" bson:"uploadedAt,omitempty" dynamodbav:"uploadedAt,omitempty" firestore:"uploadedAt,omitempty"`
+	Variants   []Variant  `json:"variants,omitempty"`
+}
+
+type Variant struct {
+	MediaId string `json:"-" gorm:"primary_key;column:media_id"`
+	Name    string `json:"name" gorm:"primary_key;column:name"`
+	Url     string `json:"url" gorm:"column:url"`
+	Path    string `json:"path,omitempty" gorm:"column:path"`
+	Width   int    `json:"width,omitempty" gorm:"column:width"`
+	Height  int    `json:"height,omitempty" gorm:"column:height"`
+	Size    int64  `json:"size,omitempty" gorm:"column:size"`
+}
+
+// Usage is a record which still links to the media, so the media cannot be deleted
+type Usage struct {
+	Resource string `json:"resource" gorm:"column:resource"`
+	Id       string `json:"id" gorm:"column:id"`
+	Field    string `json:"field" gorm:"column:field"`
+}
+
+type Config struct {
+	Directory string          `yaml:"directory" mapstructure:"directory" json:"directory,omitempty"`
+	Url       string          `yaml:"url" mapstructure:"url" json:"url,omitempty"`
+	MaxSize   int64           `yaml:"max_size" mapstructure:"max_size" json:"maxSize,omitempty"`
+	Types     []string        `yaml:"types" mapstructure:"types" json:"types,omitempty"`
+	Variants  []VariantConfig `yaml:"variants" mapstructure:"variants" json:"variants,omitempty"`
+}
+
+type VariantConfig struct {
+	Name  string `yaml:"name" mapstructure:"name" json:"name,omitempty"`
+	Width int    `yaml:"width" mapstructure:"width" json:"width,omitempty"`
+}
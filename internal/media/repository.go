@@ -0,0 +1,12 @@
+package media
+
+import "context"
+
+type MediaRepository interface {
+	Load(ctx context.Context, id string) (*Media, error)
+	Create(ctx context.Context, media *Media) (int64, error)
+	Patch(ctx context.Context, media map[string]interface{}) (int64, error)
+	Delete(ctx context.Context, id string) (int64, error)
+	Search(ctx context.Context, filter *MediaFilter, limit int64, offset int64) ([]Media, int64, error)
+	GetUsages(ctx context.Context, urls []string, pattern string) ([]Usage, error)
+}
@@ -0,0 +1,148 @@
+package media
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/core-go/core/tx"
+)
+
+type MediaService interface {
+	Load(ctx context.Context, id string) (*Media, error)
+	Upload(ctx context.Context, name string, mimeType string, alt string, data []byte) (*Media, error)
+	Patch(ctx context.Context, media map[string]interface{}) (int64, error)
+	Delete(ctx context.Context, id string) ([]Usage, int64, error)
+	Search(ctx context.Context, filter *MediaFilter, limit int64, offset int64) ([]Media, int64, error)
+}
+
+func NewMediaService(db *sql.DB, repository MediaRepository, storage Storage, variants []VariantConfig, generateId func(context.Context) (string, error), userKey string, logError func(context.Context, string, ...map[string]interface{})) *MediaUseCase {
+	return &MediaUseCase{db: db, repository: repository, storage: storage, variants: variants, generateId: generateId, userKey: userKey, logError: logError}
+}
+
+type MediaUseCase struct {
+	db         *sql.DB
+	repository MediaRepository
+	storage    Storage
+	variants   []VariantConfig
+	generateId func(context.Context) (string, error)
+	userKey    string
+	logError   func(context.Context, string, ...map[string]interface{})
+}
+
+func (s *MediaUseCase) Load(ctx context.Context, id string) (*Media, error) {
+	return s.repository.Load(ctx, id)
+}
+
+// Upload stores the file and its image variants first, then saves the metadata; the stored files are removed if the metadata cannot be saved
+func (s *MediaUseCase) Upload(ctx context.Context, name string, mimeType string, alt string, data []byte) (*Media, error) {
+	id, err := s.generateId(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ext, ok := extensions[mimeType]
+	if !ok {
+		ext = strings.ToLower(filepath.Ext(name))
+	}
+	now := time.Now()
+	media := &Media{Id: id, Name: name, Path: id + ext, MimeType: mimeType, Size: int64(len(data)), UploadedAt: &now}
+	if len(alt) > 0 {
+		media.Alt = &alt
+	}
+	if userId, ok := ctx.Value(s.userKey).(string); ok && len(userId) > 0 {
+		media.UploadedBy = &userId
+	}
+	if width, height, ok := GetDimensions(data); ok {
+		media.Width, media.Height = &width, &height
+	}
+	media.Url, err = s.storage.Upload(ctx, media.Path, data, mimeType)
+	if err != nil {
+		return nil, err
+	}
+	if IsResizable(mimeType) {
+		for _, conf := range s.variants {
+			resized, width, height, err := Resize(data, mimeType, conf.Width)
+			if err != nil {
+				s.remove(ctx, media)
+				return nil, err
+			}
+			variant := Variant{Name: conf.Name, Path: id + "-" + conf.Name + ext, Width: width, Height: height, Size: int64(len(resized))}
+			variant.Url, err = s.storage.Upload(ctx, variant.Path, resized, mimeType)
+			if err != nil {
+				s.remove(ctx, media)
+				return nil, err
+			}
+			media.Variants = append(media.Variants, variant)
+		}
+	}
+	_, err = tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
+		return s.repository.Create(ctx, media)
+	})
+	if err != nil {
+		s.remove(ctx, media)
+		return nil, err
+	}
+	return media, nil
+}
+
+// Patch only changes the metadata, the file cannot be replaced
+func (s *MediaUseCase) Patch(ctx context.Context, media map[string]interface{}) (int64, error) {
+	m := make(map[string]interface{})
+	for _, key := range []string{"id", "name", "alt"} {
+		if v, ok := media[key]; ok {
+			m[key] = v
+		}
+	}
+	return tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
+		return s.repository.Patch(ctx, m)
+	})
+}
+
+// Delete returns the usages and -1 when the media is still linked by other records
+func (s *MediaUseCase) Delete(ctx context.Context, id string) ([]Usage, int64, error) {
+	media, err := s.repository.Load(ctx, id)
+	if err != nil || media == nil {
+		return nil, 0, err
+	}
+	urls := []string{media.Url}
+	for _, variant := range media.Variants {
+		urls = append(urls, variant.Url)
+	}
+	prefix := strings.TrimSuffix(media.Url, filepath.Ext(media.Url))
+	var usages []Usage
+	res, err := tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
+		var err error
+		usages, err = s.repository.GetUsages(ctx, urls, "%"+escapeLike(prefix)+"%")
+		if err != nil || len(usages) > 0 {
+			return -1, err
+		}
+		return s.repository.Delete(ctx, id)
+	})
+	if err != nil || res <= 0 {
+		return usages, res, err
+	}
+	s.remove(ctx, media)
+	return nil, res, nil
+}
+
+func (s *MediaUseCase) Search(ctx context.Context, filter *MediaFilter, limit int64, offset int64) ([]Media, int64, error) {
+	return s.repository.Search(ctx, filter, limit, offset)
+}
+
+func (s *MediaUseCase) remove(ctx context.Context, media *Media) {
+	paths := []string{media.Path}
+	for _, variant := range media.Variants {
+		paths = append(paths, variant.Path)
+	}
+	for _, path := range paths {
+		if _, err := s.storage.Delete(ctx, path); err != nil && s.logError != nil {
+			s.logError(ctx, "Error to delete media file '"+path+"': "+err.Error())
+		}
+	}
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
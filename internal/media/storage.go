@@ -0,0 +1,71 @@
+package media
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type Storage interface {
+	Upload(ctx context.Context, name string, data []byte, contentType string) (string, error)
+	Delete(ctx context.Context, name string) (bool, error)
+}
+
+func NewLocalStorage(directory string, url string) *LocalStorage {
+	return &LocalStorage{Directory: directory, Url: strings.TrimSuffix(url, "/")}
+}
+
+// LocalStorage keeps the files in a directory, which is served under Url
+type LocalStorage struct {
+	Directory string
+	Url       string
+}
+
+func (s *LocalStorage) Upload(ctx context.Context, name string, data []byte, contentType string) (string, error) {
+	if err := os.MkdirAll(s.Directory, 0755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filepath.Join(s.Directory, filepath.Base(name)), data, 0644); err != nil {
+		return "", err
+	}
+	return s.Url + "/" + filepath.Base(name), nil
+}
+
+func (s *LocalStorage) Delete(ctx context.Context, name string) (bool, error) {
+	err := os.Remove(filepath.Join(s.Directory, filepath.Base(name)))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *LocalStorage) Load(ctx context.Context, name string) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Join(s.Directory, filepath.Base(name)))
+	if err != nil && os.IsNotExist(err) {
+		return nil, nil
+	}
+	return data, err
+}
+
+// Files serves the files of the directory for http.FileServer; a directory is not found, so that the uploads are never listed
+type Files struct {
+	Dir http.Dir
+}
+
+func (f Files) Open(name string) (http.File, error) {
+	file, err := f.Dir.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil || info.IsDir() {
+		file.Close()
+		return nil, os.ErrNotExist
+	}
+	return file, nil
+}
@@ -0,0 +1,41 @@
+package media
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/core-go/core"
+	b "github.com/core-go/core/builder"
+	v "github.com/core-go/core/validator"
+	"github.com/core-go/search"
+	"github.com/core-go/sql/query/builder"
+	"github.com/lib/pq"
+
+	"go-service/pkg/export"
+)
+
+type MediaTransport interface {
+	Search(w http.ResponseWriter, r *http.Request)
+	Load(w http.ResponseWriter, r *http.Request)
+	Upload(w http.ResponseWriter, r *http.Request)
+	Patch(w http.ResponseWriter, r *http.Request)
+	Delete(w http.ResponseWriter, r *http.Request)
+	Export(w http.ResponseWriter, r *http.Request)
+}
+
+func NewMediaTransport(db *sql.DB, logError core.Log, storage Storage, conf Config, tracking b.TrackingConfig, generateId func(context.Context) (string, error), writeLog core.WriteLog, action *core.ActionConfig) (MediaTransport, error) {
+	validator, err := v.NewValidator[*Media]()
+	if err != nil {
+		return nil, err
+	}
+	queryMedia := builder.UseQuery[Media, *MediaFilter](db, "media")
+	mediaRepository, err := NewMediaAdapter(db, queryMedia, pq.Array)
+	if err != nil {
+		return nil, err
+	}
+	mediaService := NewMediaService(db, mediaRepository, storage, conf.Variants, generateId, tracking.User, logError)
+	mediaExporter := export.NewExporter[Media, *MediaFilter](db, "media", func() *MediaFilter { return &MediaFilter{Filter: &search.Filter{}} }, queryMedia, logError, writeLog)
+	mediaHandler := NewMediaHandler(mediaService, logError, validator.Validate, conf.MaxSize, conf.Types, mediaExporter, writeLog, action)
+	return mediaHandler, nil
+}
@@ -0,0 +1,109 @@
+package password
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"time"
+
+	s "github.com/core-go/sql"
+)
+
+const selectPassword = `select u.user_id, u.username, u.email, p.password, p.history, p.changed_time, u.status, p.fail_count, p.locked_until_time
+	from users u left join passwords p on u.user_id = p.user_id`
+
+func NewPasswordAdapter(db *sql.DB, toArray s.Array) (*PasswordAdapter, error) {
+	parameters, err := s.CreateParameters(reflect.TypeOf(UserPassword{}), db)
+	if err != nil {
+		return nil, err
+	}
+	return &PasswordAdapter{DB: db, Parameters: parameters, Array: toArray}, nil
+}
+
+type PasswordAdapter struct {
+	DB *sql.DB
+	*s.Parameters
+	Array s.Array
+}
+
+func (r *PasswordAdapter) Load(ctx context.Context, userId string) (*UserPassword, error) {
+	return r.load(ctx, fmt.Sprintf("%s where u.user_id = %s", selectPassword, r.BuildParam(1)), userId)
+}
+func (r *PasswordAdapter) LoadByUsername(ctx context.Context, username string) (*UserPassword, error) {
+	return r.load(ctx, fmt.Sprintf("%s where u.username = %s", selectPassword, r.BuildParam(1)), username)
+}
+func (r *PasswordAdapter) LoadByContact(ctx context.Context, contact string) (*UserPassword, error) {
+	query := fmt.Sprintf("%s where u.username = %s or lower(u.email) = lower(%s) order by u.username = %s desc", selectPassword, r.BuildParam(1), r.BuildParam(2), r.BuildParam(3))
+	return r.load(ctx, query, contact, contact, contact)
+}
+func (r *PasswordAdapter) load(ctx context.Context, query string, args ...interface{}) (*UserPassword, error) {
+	var users []UserPassword
+	err := s.QueryWithArray(ctx, r.DB, r.Map, &users, r.Array, query+" limit 1", args...)
+	if err != nil {
+		return nil, err
+	}
+	if len(users) > 0 {
+		return &users[0], nil
+	}
+	return nil, nil
+}
+
+// Update saves the password and clears the failed logins, because the user has proved to own the account
+func (r *PasswordAdapter) Update(ctx context.Context, userId string, password string, history []string, changedTime time.Time) (int64, error) {
+	query := fmt.Sprintf(`insert into passwords (user_id, password, history, changed_time, fail_count) values (%s, %s, %s, %s, 0)
+		on conflict (user_id) do update set password = excluded.password, history = excluded.history, changed_time = excluded.changed_time, fail_count = 0, locked_until_time = null`,
+		r.BuildParam(1), r.BuildParam(2), r.BuildParam(3), r.BuildParam(4))
+	tx := s.GetTx(ctx, r.DB)
+	res, err := tx.ExecContext(ctx, query, userId, password, r.Array(history), changedTime)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+// Fail counts a wrong current password like a failed login, and locks the user until lockedUntil if it is not nil
+func (r *PasswordAdapter) Fail(ctx context.Context, userId string, failTime time.Time, lockedUntil *time.Time) (int64, error) {
+	query := fmt.Sprintf(`update passwords set fail_count = coalesce(fail_count, 0) + 1, fail_time = %s, locked_until_time = coalesce(%s, locked_until_time)
+		where user_id = %s`, r.BuildParam(1), r.BuildParam(2), r.BuildParam(3))
+	res, err := r.DB.ExecContext(ctx, query, failTime, lockedUntil, userId)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+// SavePasscode keeps the attempts of the passcode which is not expired, so that a new passcode does not allow more attempts
+func (r *PasswordAdapter) SavePasscode(ctx context.Context, id string, code string, expiredAt time.Time) (int64, error) {
+	query := fmt.Sprintf(`insert into passcodes (id, code, expired_at, attempts) values (%s, %s, %s, 0)
+		on conflict (id) do update set code = excluded.code, expired_at = excluded.expired_at,
+		attempts = case when passcodes.expired_at > %s then passcodes.attempts else 0 end`, r.BuildParam(1), r.BuildParam(2), r.BuildParam(3), r.BuildParam(4))
+	res, err := r.DB.ExecContext(ctx, query, id, code, expiredAt, time.Now())
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+// UsePasscode counts the attempt before the passcode is compared, so that parallel requests cannot pass the max attempts;
+// it returns the passcode, or an empty string if there is no passcode, it is expired or it has no attempt left
+func (r *PasswordAdapter) UsePasscode(ctx context.Context, id string, maxAttempts int, now time.Time) (string, error) {
+	query := fmt.Sprintf("update passcodes set attempts = attempts + 1 where id = %s and attempts < %s and expired_at > %s returning code",
+		r.BuildParam(1), r.BuildParam(2), r.BuildParam(3))
+	var code string
+	err := r.DB.QueryRowContext(ctx, query, id, maxAttempts, now).Scan(&code)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return code, err
+}
+
+func (r *PasswordAdapter) DeletePasscode(ctx context.Context, id string) (int64, error) {
+	query := fmt.Sprintf("delete from passcodes where id = %s", r.BuildParam(1))
+	tx := s.GetTx(ctx, r.DB)
+	res, err := tx.ExecContext(ctx, query, id)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
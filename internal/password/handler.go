@@ -0,0 +1,180 @@
+package password
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/core-go/core"
+
+	"go-service/pkg/ratelimit"
+)
+
+// NewPasswordHandler limits the requests by ip with limiter, and the passcodes of each user with userLimiter
+func NewPasswordHandler(service PasswordService, logError core.Log, limiter *ratelimit.Limiter, userLimiter *ratelimit.Limiter, conf Config, writeLog core.WriteLog, action *core.ActionConfig) *PasswordHandler {
+	attributes := core.CreateAttributes(reflect.TypeOf(UserPassword{}), logError, writeLog, action)
+	return &PasswordHandler{service: service, limiter: limiter, userLimiter: userLimiter, minLength: conf.MinLength, history: conf.History, Attributes: attributes}
+}
+
+type PasswordHandler struct {
+	service     PasswordService
+	limiter     *ratelimit.Limiter
+	userLimiter *ratelimit.Limiter
+	minLength   int
+	history     int
+	*core.Attributes
+}
+
+func (h *PasswordHandler) Change(w http.ResponseWriter, r *http.Request) {
+	if !h.allow(w, r, "change") {
+		return
+	}
+	req, er1 := core.Decode[ChangeRequest](w, r)
+	if er1 == nil {
+		if errors := h.validate(req.Password, required("username", req.Username), required("currentPassword", req.CurrentPassword)); len(errors) > 0 {
+			core.JSON(w, http.StatusUnprocessableEntity, errors)
+			return
+		}
+		res, er2 := h.service.Change(r.Context(), &req)
+		h.respond(w, r, "change", req.Username, res, er2, core.ErrorMessage{Field: "currentPassword", Code: "invalid"})
+	}
+}
+
+// Forgot always returns 200, so that the client cannot find which usernames or emails exist
+func (h *PasswordHandler) Forgot(w http.ResponseWriter, r *http.Request) {
+	if !h.allow(w, r, "forgot") {
+		return
+	}
+	req, er1 := core.Decode[ForgotRequest](w, r)
+	if er1 == nil {
+		contact := strings.TrimSpace(req.Contact)
+		if len(contact) == 0 {
+			core.JSON(w, http.StatusUnprocessableEntity, []core.ErrorMessage{{Field: "contact", Code: "required"}})
+			return
+		}
+		if !h.allowUser(w, r, "forgot", strings.ToLower(contact)) {
+			return
+		}
+		sent, er2 := h.service.Forgot(r.Context(), contact)
+		if er2 != nil {
+			h.Error(r.Context(), fmt.Sprintf("Error to send passcode to '%s': %s", contact, er2.Error()))
+			h.Log(r.Context(), h.Resource, "forgot", false, er2.Error())
+			http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+			return
+		}
+		h.Log(r.Context(), h.Resource, "forgot", sent, fmt.Sprintf("passcode for '%s'", contact))
+		core.JSON(w, http.StatusOK, true)
+	}
+}
+
+func (h *PasswordHandler) Reset(w http.ResponseWriter, r *http.Request) {
+	if !h.allow(w, r, "reset") {
+		return
+	}
+	req, er1 := core.Decode[ResetRequest](w, r)
+	if er1 == nil {
+		if errors := h.validate(req.Password, required("username", req.Username), required("passcode", req.Passcode)); len(errors) > 0 {
+			core.JSON(w, http.StatusUnprocessableEntity, errors)
+			return
+		}
+		if !h.allowUser(w, r, "reset", req.Username) {
+			return
+		}
+		res, er2 := h.service.Reset(r.Context(), &req)
+		h.respond(w, r, "reset", req.Username, res, er2, core.ErrorMessage{Field: "passcode", Code: "invalid"})
+	}
+}
+
+// Set is the reset of the password of a user by an admin
+func (h *PasswordHandler) Set(w http.ResponseWriter, r *http.Request) {
+	userId, err := core.GetRequiredString(w, r, 1)
+	if err == nil {
+		req, er1 := core.Decode[SetRequest](w, r)
+		if er1 == nil {
+			if errors := h.validate(req.Password); len(errors) > 0 {
+				core.JSON(w, http.StatusUnprocessableEntity, errors)
+				return
+			}
+			res, er2 := h.service.Set(r.Context(), userId, req.Password)
+			if er2 == nil && res == Invalid {
+				h.Log(r.Context(), h.Resource, "set", false, fmt.Sprintf("not found '%s'", userId))
+				core.JSON(w, http.StatusNotFound, res)
+				return
+			}
+			h.respond(w, r, "set", userId, res, er2, core.ErrorMessage{})
+		}
+	}
+}
+
+func (h *PasswordHandler) respond(w http.ResponseWriter, r *http.Request, action string, user string, res int64, err error, invalid core.ErrorMessage) {
+	if err != nil {
+		h.Error(r.Context(), fmt.Sprintf("Error to %s password of '%s': %s", action, user, err.Error()))
+		h.Log(r.Context(), h.Resource, action, false, err.Error())
+		http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+		return
+	}
+	switch res {
+	case Success:
+		h.Log(r.Context(), h.Resource, action, true, fmt.Sprintf("%s password of '%s'", action, user))
+		core.JSON(w, http.StatusOK, res)
+	case Reused:
+		h.Log(r.Context(), h.Resource, action, false, fmt.Sprintf("reused password of '%s'", user))
+		core.JSON(w, http.StatusUnprocessableEntity, []core.ErrorMessage{{Field: "password", Code: "history", Param: strconv.Itoa(h.history)}})
+	case Locked:
+		h.Log(r.Context(), h.Resource, action, false, fmt.Sprintf("locked '%s'", user))
+		core.JSON(w, http.StatusForbidden, []core.ErrorMessage{{Field: "username", Code: "locked"}})
+	case Disabled:
+		h.Log(r.Context(), h.Resource, action, false, fmt.Sprintf("suspended or disabled '%s'", user))
+		core.JSON(w, http.StatusForbidden, []core.ErrorMessage{{Field: "username", Code: "disabled"}})
+	default:
+		h.Log(r.Context(), h.Resource, action, false, fmt.Sprintf("invalid %s of '%s'", invalid.Field, user))
+		core.JSON(w, http.StatusUnprocessableEntity, []core.ErrorMessage{invalid})
+	}
+}
+
+func (h *PasswordHandler) allow(w http.ResponseWriter, r *http.Request, action string) bool {
+	ip := core.GetRemoteIp(r)
+	ok, wait := h.limiter.Allow(ip)
+	if !ok {
+		h.Log(r.Context(), h.Resource, action, false, fmt.Sprintf("too many requests from '%s'", ip))
+		w.Header().Set("Retry-After", strconv.FormatInt(int64(wait/time.Second)+1, 10))
+		core.JSON(w, http.StatusTooManyRequests, nil)
+	}
+	return ok
+}
+
+// allowUser limits the requests of each user whatever the ip is, so that the passcodes cannot be requested or guessed from many ips
+func (h *PasswordHandler) allowUser(w http.ResponseWriter, r *http.Request, action string, user string) bool {
+	ok, wait := h.userLimiter.Allow(action + ":" + user)
+	if !ok {
+		h.Log(r.Context(), h.Resource, action, false, fmt.Sprintf("too many requests for '%s'", user))
+		w.Header().Set("Retry-After", strconv.FormatInt(int64(wait/time.Second)+1, 10))
+		core.JSON(w, http.StatusTooManyRequests, nil)
+	}
+	return ok
+}
+
+func (h *PasswordHandler) validate(password string, errors ...core.ErrorMessage) []core.ErrorMessage {
+	var res []core.ErrorMessage
+	for _, e := range errors {
+		if len(e.Field) > 0 {
+			res = append(res, e)
+		}
+	}
+	if len(password) == 0 {
+		res = append(res, core.ErrorMessage{Field: "password", Code: "required"})
+	} else if len(password) < h.minLength {
+		res = append(res, core.ErrorMessage{Field: "password", Code: "minlength", Param: strconv.Itoa(h.minLength)})
+	}
+	return res
+}
+
+func required(field string, value string) core.ErrorMessage {
+	if len(strings.TrimSpace(value)) == 0 {
+		return core.ErrorMessage{Field: field, Code: "required"}
+	}
+	return core.ErrorMessage{}
+}
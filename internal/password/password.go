@@ -0,0 +1,62 @@
+package password
+
+import (
+	"time"
+
+	"go-service/pkg/ratelimit"
+	"go-service/pkg/sender"
+)
+
+const (
+	Success  = 1
+	Invalid  = 0
+	Reused   = -1
+	Locked   = -3
+	Disabled = -4
+)
+
+type Config struct {
+	History       int              `yaml:"history" mapstructure:"history" json:"history,omitempty"`
+	MinLength     int              `yaml:"min_length" mapstructure:"min_length" json:"minLength,omitempty"`
+	CodeLength    int              `yaml:"code_length" mapstructure:"code_length" json:"codeLength,omitempty"`
+	CodeExpires   int64            `yaml:"code_expires" mapstructure:"code_expires" json:"codeExpires,omitempty"`
+	MaxAttempts   int              `yaml:"max_attempts" mapstructure:"max_attempts" json:"maxAttempts,omitempty"`
+	MaxFailed     int              `yaml:"max_failed" mapstructure:"max_failed" json:"maxFailed,omitempty"`
+	LockedMinutes int              `yaml:"locked_minutes" mapstructure:"locked_minutes" json:"lockedMinutes,omitempty"`
+	RateLimit     ratelimit.Config `yaml:"rate_limit" mapstructure:"rate_limit" json:"rateLimit,omitempty"`
+	UserRateLimit ratelimit.Config `yaml:"user_rate_limit" mapstructure:"user_rate_limit" json:"userRateLimit,omitempty"`
+	Sender        sender.Config    `yaml:"sender" mapstructure:"sender" json:"sender,omitempty"`
+}
+
+type UserPassword struct {
+	UserId      string     `json:"userId" gorm:"column:user_id;primary_key"`
+	Username    string     `json:"username,omitempty" gorm:"column:username"`
+	Email       string     `json:"email,omitempty" gorm:"column:email"`
+	Password    *string    `json:"-" gorm:"column:password"`
+	History     []string   `json:"-" gorm:"column:history"`
+	ChangedTime *time.Time `json:"changedTime,omitempty" gorm:"column:changed_time"`
+	Status      *string    `json:"-" gorm:"column:status"`
+	FailCount   *int       `json:"-" gorm:"column:fail_count"`
+	LockedUntil *time.Time `json:"-" gorm:"column:locked_until_time"`
+}
+
+type ChangeRequest struct {
+	Username        string `json:"username"`
+	CurrentPassword string `json:"currentPassword"`
+	Password        string `json:"password"`
+}
+
+// ForgotRequest has the username or the email of the user
+type ForgotRequest struct {
+	Contact string `json:"contact"`
+}
+
+type ResetRequest struct {
+	Username string `json:"username"`
+	Passcode string `json:"passcode"`
+	Password string `json:"password"`
+}
+
+type SetRequest struct {
+	Password string `json:"password"`
+}
@@ -0,0 +1,17 @@
+package password
+
+import (
+	"context"
+	"time"
+)
+
+type PasswordRepository interface {
+	Load(ctx context.Context, userId string) (*UserPassword, error)
+	LoadByUsername(ctx context.Context, username string) (*UserPassword, error)
+	LoadByContact(ctx context.Context, contact string) (*UserPassword, error)
+	Update(ctx context.Context, userId string, password string, history []string, changedTime time.Time) (int64, error)
+	Fail(ctx context.Context, userId string, failTime time.Time, lockedUntil *time.Time) (int64, error)
+	SavePasscode(ctx context.Context, id string, code string, expiredAt time.Time) (int64, error)
+	UsePasscode(ctx context.Context, id string, maxAttempts int, now time.Time) (string, error)
+	DeletePasscode(ctx context.Context, id string) (int64, error)
+}
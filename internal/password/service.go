@@ -0,0 +1,199 @@
+package password
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"time"
+
+	auth "github.com/core-go/authentication"
+	"github.com/core-go/core/tx"
+
+	"go-service/pkg/sender"
+)
+
+type Comparator interface {
+	Compare(plaintext string, hashed string) (bool, error)
+	Hash(plaintext string) (string, error)
+}
+
+type PasswordService interface {
+	Change(ctx context.Context, req *ChangeRequest) (int64, error)
+	Forgot(ctx context.Context, contact string) (bool, error)
+	Reset(ctx context.Context, req *ResetRequest) (int64, error)
+	Set(ctx context.Context, userId string, password string) (int64, error)
+}
+
+// NewPasswordService revokes the refresh tokens of the user when the password changes, if revoke is not nil
+func NewPasswordService(db *sql.DB, repository PasswordRepository, comparator Comparator, send sender.Send, conf Config, status auth.UserStatusConfig, revoke func(context.Context, string) (int64, error)) *PasswordUseCase {
+	maxAttempts := conf.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 5
+	}
+	return &PasswordUseCase{db: db, repository: repository, comparator: comparator, send: send, history: conf.History, codeLength: conf.CodeLength, codeExpires: conf.CodeExpires, maxAttempts: maxAttempts,
+		maxFailed: conf.MaxFailed, lockedMinutes: conf.LockedMinutes, status: status, revoke: revoke}
+}
+
+type PasswordUseCase struct {
+	db            *sql.DB
+	repository    PasswordRepository
+	comparator    Comparator
+	send          sender.Send
+	history       int
+	codeLength    int
+	codeExpires   int64
+	maxAttempts   int
+	maxFailed     int
+	lockedMinutes int
+	status        auth.UserStatusConfig
+	revoke        func(context.Context, string) (int64, error)
+}
+
+// Change checks the current password, so that it works without a token, when the login returns that the password is expired.
+// It has the checks of the login: a locked user is refused before the password is compared, a wrong password is counted
+// as a failed login, and a suspended or disabled user cannot change the password.
+func (s *PasswordUseCase) Change(ctx context.Context, req *ChangeRequest) (int64, error) {
+	user, err := s.repository.LoadByUsername(ctx, req.Username)
+	if err != nil || user == nil || user.Password == nil {
+		return Invalid, err
+	}
+	now := time.Now()
+	if user.LockedUntil != nil && user.LockedUntil.After(now) {
+		return Locked, nil
+	}
+	valid, err := s.comparator.Compare(req.CurrentPassword, *user.Password)
+	if err != nil {
+		return Invalid, err
+	}
+	if !valid {
+		var lockedUntil *time.Time
+		if s.maxFailed > 0 && s.lockedMinutes > 0 && user.FailCount != nil && *user.FailCount+1 >= s.maxFailed {
+			t := now.Add(time.Duration(s.lockedMinutes) * time.Minute)
+			lockedUntil = &t
+		}
+		_, err = s.repository.Fail(ctx, user.UserId, now, lockedUntil)
+		return Invalid, err
+	}
+	if user.Status != nil && (*user.Status == s.status.Suspended || *user.Status == s.status.Disable) {
+		return Disabled, nil
+	}
+	return tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
+		return s.save(ctx, user, req.Password)
+	})
+}
+
+// Forgot returns false if there is no user with the username or the email. The handler must not tell it to the client.
+func (s *PasswordUseCase) Forgot(ctx context.Context, contact string) (bool, error) {
+	user, err := s.repository.LoadByContact(ctx, contact)
+	if err != nil || user == nil {
+		return false, err
+	}
+	code, err := generateCode(s.codeLength)
+	if err != nil {
+		return false, err
+	}
+	expiredAt := time.Now().Add(time.Duration(s.codeExpires) * time.Second)
+	if _, err = s.repository.SavePasscode(ctx, user.UserId, hashCode(code), expiredAt); err != nil {
+		return false, err
+	}
+	body := fmt.Sprintf("Your code to reset the password of '%s' is %s. It expires at %s.", user.Username, code, expiredAt.Format(time.RFC1123))
+	return true, s.send(ctx, user.Email, "Reset password", body)
+}
+
+// Reset counts the attempt before the passcode is compared, the passcode cannot be used after the max attempts, so that it cannot be guessed from many ips
+func (s *PasswordUseCase) Reset(ctx context.Context, req *ResetRequest) (int64, error) {
+	user, err := s.repository.LoadByUsername(ctx, req.Username)
+	if err != nil || user == nil {
+		return Invalid, err
+	}
+	code, err := s.repository.UsePasscode(ctx, user.UserId, s.maxAttempts, time.Now())
+	if err != nil || len(code) == 0 {
+		return Invalid, err
+	}
+	if subtle.ConstantTimeCompare([]byte(hashCode(req.Passcode)), []byte(code)) != 1 {
+		return Invalid, nil
+	}
+	return tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
+		res, err := s.save(ctx, user, req.Password)
+		if err != nil || res != Success {
+			return res, err
+		}
+		_, err = s.repository.DeletePasscode(ctx, user.UserId)
+		return res, err
+	})
+}
+
+// Set is the reset by an admin, which does not need the current password, but still checks the history
+func (s *PasswordUseCase) Set(ctx context.Context, userId string, password string) (int64, error) {
+	user, err := s.repository.Load(ctx, userId)
+	if err != nil || user == nil {
+		return Invalid, err
+	}
+	return tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
+		return s.save(ctx, user, password)
+	})
+}
+
+// save rejects the password if it is one of the last passwords, the current one included, and keeps the history for the next change
+func (s *PasswordUseCase) save(ctx context.Context, user *UserPassword, password string) (int64, error) {
+	var hashes []string
+	if user.Password != nil && len(*user.Password) > 0 {
+		hashes = append(hashes, *user.Password)
+	}
+	hashes = append(hashes, user.History...)
+	if len(hashes) > s.history {
+		hashes = hashes[:s.history]
+	}
+	for _, hash := range hashes {
+		used, err := s.comparator.Compare(password, hash)
+		if err != nil {
+			return -2, err
+		}
+		if used {
+			return Reused, nil
+		}
+	}
+	hashed, err := s.comparator.Hash(password)
+	if err != nil {
+		return -2, err
+	}
+	if len(hashes) > 0 && len(hashes) >= s.history {
+		hashes = hashes[:s.history-1]
+	}
+	res, err := s.repository.Update(ctx, user.UserId, hashed, hashes, time.Now())
+	if err != nil || res <= 0 {
+		return res, err
+	}
+	if s.revoke != nil {
+		if _, err = s.revoke(ctx, user.UserId); err != nil {
+			return -2, err
+		}
+	}
+	return Success, nil
+}
+
+// hashCode hashes the passcode like the recovery codes; the passcode expires soon and has a few attempts only
+func hashCode(code string) string {
+	h := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(h[:])
+}
+
+func generateCode(length int) (string, error) {
+	if length <= 0 {
+		length = 6
+	}
+	code := make([]byte, length)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+		code[i] = byte('0' + n.Int64())
+	}
+	return string(code), nil
+}
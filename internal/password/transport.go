@@ -0,0 +1,31 @@
+package password
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	auth "github.com/core-go/authentication"
+	"github.com/core-go/core"
+	"github.com/lib/pq"
+
+	"go-service/pkg/ratelimit"
+	"go-service/pkg/sender"
+)
+
+type PasswordTransport interface {
+	Change(w http.ResponseWriter, r *http.Request)
+	Forgot(w http.ResponseWriter, r *http.Request)
+	Reset(w http.ResponseWriter, r *http.Request)
+	Set(w http.ResponseWriter, r *http.Request)
+}
+
+func NewPasswordTransport(db *sql.DB, comparator Comparator, send sender.Send, conf Config, status auth.UserStatusConfig, revoke func(context.Context, string) (int64, error), logError core.Log, writeLog core.WriteLog, action *core.ActionConfig) (PasswordTransport, error) {
+	passwordRepository, err := NewPasswordAdapter(db, pq.Array)
+	if err != nil {
+		return nil, err
+	}
+	passwordService := NewPasswordService(db, passwordRepository, comparator, send, conf, status, revoke)
+	passwordHandler := NewPasswordHandler(passwordService, logError, ratelimit.NewLimiter(conf.RateLimit), ratelimit.NewLimiter(conf.UserRateLimit), conf, writeLog, action)
+	return passwordHandler, nil
+}
@@ -0,0 +1,89 @@
+package public
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	s "github.com/core-go/sql"
+)
+
+const (
+	statusActive = "A"
+
+	// updated_at is the last change of the row, or the publish time when a scheduled row is published later than its last change
+	articleFields = "id, title, slug, description, published_at, greatest(updated_at, published_at) as updated_at, thumbnail, high_thumbnail, tags"
+	jobFields     = "id, title, description, published_at, greatest(updated_at, published_at) as updated_at, expired_at, position, quantity, location, skills, min_salary, max_salary, company_id"
+)
+
+func NewPublicAdapter(db *sql.DB, toArray s.Array) *PublicAdapter {
+	return &PublicAdapter{DB: db, Array: toArray, BuildParam: s.BuildDollarParam}
+}
+
+// PublicAdapter only reads active and published rows, every query must keep the status and publish time conditions
+type PublicAdapter struct {
+	DB         *sql.DB
+	Array      s.Array
+	BuildParam func(int) string
+}
+
+func (r *PublicAdapter) LoadCategories(ctx context.Context) ([]Category, error) {
+	var categories []Category
+	query := fmt.Sprintf("select id, name, path, resource_key, icon, sequence, type, parent, updated_at from categories where status = '%s' order by sequence, id", statusActive)
+	err := s.Query(ctx, r.DB, nil, &categories, query)
+	return categories, err
+}
+
+func (r *PublicAdapter) LoadContent(ctx context.Context, path string, lang string, now time.Time) (*Content, error) {
+	var contents []Content
+	query := fmt.Sprintf(`select c.id, c.lang, c.title, c.body, c.published_at, c.tags,
+		(select max(v.created_at) from content_revisions v where v.id = c.id and v.lang = c.lang) as updated_at
+	from contents c
+	inner join categories g on g.id = c.id
+	where g.path = %s and g.status = '%s' and c.lang = %s and c.status = '%s' and (c.published_at is null or c.published_at <= %s)
+	limit 1`, r.BuildParam(1), statusActive, r.BuildParam(2), statusActive, r.BuildParam(3))
+	err := s.QueryWithArray(ctx, r.DB, nil, &contents, r.Array, query, path, lang, now)
+	if err != nil {
+		return nil, err
+	}
+	if len(contents) > 0 {
+		return &contents[0], nil
+	}
+	return nil, nil
+}
+
+func (r *PublicAdapter) SearchArticles(ctx context.Context, now time.Time, limit int64, offset int64) ([]Article, int64, error) {
+	var articles []Article
+	query := fmt.Sprintf("select %s from articles where status = '%s' and (published_at is null or published_at <= %s) order by published_at desc, id", articleFields, statusActive, r.BuildParam(1))
+	total, err := s.Count(ctx, r.DB, s.BuildCountQuery(query), now)
+	if err != nil || total == 0 {
+		return articles, total, err
+	}
+	err = s.QueryWithArray(ctx, r.DB, nil, &articles, r.Array, s.BuildPagingQuery(query, limit, offset), now)
+	return articles, total, err
+}
+
+func (r *PublicAdapter) LoadArticle(ctx context.Context, id string, now time.Time) (*Article, error) {
+	var articles []Article
+	query := fmt.Sprintf("select %s, content from articles where id = %s and status = '%s' and (published_at is null or published_at <= %s) limit 1", articleFields, r.BuildParam(1), statusActive, r.BuildParam(2))
+	err := s.QueryWithArray(ctx, r.DB, nil, &articles, r.Array, query, id, now)
+	if err != nil {
+		return nil, err
+	}
+	if len(articles) > 0 {
+		return &articles[0], nil
+	}
+	return nil, nil
+}
+
+func (r *PublicAdapter) SearchJobs(ctx context.Context, now time.Time, limit int64, offset int64) ([]Job, int64, error) {
+	var jobs []Job
+	query := fmt.Sprintf(`select %s from jobs where status = '%s' and (published_at is null or published_at <= %s) and (expired_at is null or expired_at > %s) order by published_at desc, id`, jobFields, statusActive, r.BuildParam(1), r.BuildParam(1))
+	total, err := s.Count(ctx, r.DB, s.BuildCountQuery(query), now)
+	if err != nil || total == 0 {
+		return jobs, total, err
+	}
+	err = s.QueryWithArray(ctx, r.DB, nil, &jobs, r.Array, s.BuildPagingQuery(query, limit, offset), now)
+	return jobs, total, err
+}
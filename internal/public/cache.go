@@ -0,0 +1,57 @@
+package public
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Write sends the value with ETag and Last-Modified, and answers 304 to a conditional GET when the client copy is still valid
+func Write(w http.ResponseWriter, r *http.Request, value interface{}, lastModified *time.Time, maxAge int64) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(data)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	w.Header().Set("ETag", etag)
+	if lastModified != nil {
+		w.Header().Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+	if maxAge > 0 {
+		w.Header().Set("Cache-Control", "public, max-age="+strconv.FormatInt(maxAge, 10))
+	}
+	if IsNotModified(r, etag, lastModified) {
+		w.WriteHeader(http.StatusNotModified)
+		return nil
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(data)
+	return err
+}
+
+// IsNotModified checks If-None-Match first, If-Modified-Since is only used when there is no If-None-Match, as RFC 7232 requires
+func IsNotModified(r *http.Request, etag string, lastModified *time.Time) bool {
+	if match := r.Header.Get("If-None-Match"); len(match) > 0 {
+		for _, tag := range strings.Split(match, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == etag || tag == "*" {
+				return true
+			}
+		}
+		return false
+	}
+	if lastModified == nil {
+		return false
+	}
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	return !lastModified.Truncate(time.Second).After(since)
+}
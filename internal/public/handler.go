@@ -0,0 +1,134 @@
+package public
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/core-go/core"
+	"github.com/core-go/search"
+)
+
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+)
+
+func NewPublicHandler(service PublicService, maxAge int64, logError core.Log) *PublicHandler {
+	return &PublicHandler{service: service, maxAge: maxAge, Error: logError}
+}
+
+type PublicHandler struct {
+	service PublicService
+	maxAge  int64
+	Error   core.Log
+}
+
+func (h *PublicHandler) GetCategories(w http.ResponseWriter, r *http.Request) {
+	categories, err := h.service.GetCategories(r.Context())
+	if err != nil {
+		h.Error(r.Context(), fmt.Sprintf("Error to get public categories: %s", err.Error()))
+		http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+		return
+	}
+	h.write(w, r, categories, nil)
+}
+func (h *PublicHandler) GetContent(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.Query().Get("path")
+	if len(path) == 0 {
+		http.Error(w, "path is required", http.StatusBadRequest)
+		return
+	}
+	content, err := h.service.GetContent(r.Context(), path, r.URL.Query().Get("lang"))
+	if err != nil {
+		h.Error(r.Context(), fmt.Sprintf("Error to get public content '%s': %s", path, err.Error()))
+		http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+		return
+	}
+	if content == nil {
+		core.JSON(w, http.StatusNotFound, nil)
+		return
+	}
+	h.write(w, r, content, content.UpdatedAt)
+}
+func (h *PublicHandler) GetArticles(w http.ResponseWriter, r *http.Request) {
+	limit, offset := getPaging(r)
+	articles, total, err := h.service.GetArticles(r.Context(), limit, offset)
+	if err != nil {
+		h.Error(r.Context(), fmt.Sprintf("Error to get public articles: %s", err.Error()))
+		http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+		return
+	}
+	h.write(w, r, &search.Result{List: &articles, Total: total}, nil)
+}
+func (h *PublicHandler) GetArticle(w http.ResponseWriter, r *http.Request) {
+	id, err := core.GetRequiredString(w, r)
+	if err == nil {
+		article, err := h.service.GetArticle(r.Context(), id)
+		if err != nil {
+			h.Error(r.Context(), fmt.Sprintf("Error to get public article '%s': %s", id, err.Error()))
+			http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+			return
+		}
+		if article == nil {
+			core.JSON(w, http.StatusNotFound, nil)
+			return
+		}
+		h.write(w, r, article, article.UpdatedAt)
+	}
+}
+func (h *PublicHandler) GetArticleBySlug(w http.ResponseWriter, r *http.Request) {
+	slug, err := core.GetRequiredString(w, r)
+	if err == nil {
+		article, redirect, err := h.service.GetArticleBySlug(r.Context(), slug)
+		if err != nil {
+			h.Error(r.Context(), fmt.Sprintf("Error to get public article by slug '%s': %s", slug, err.Error()))
+			http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+			return
+		}
+		if len(redirect) > 0 {
+			http.Redirect(w, r, path.Join(path.Dir(r.URL.Path), url.PathEscape(redirect)), http.StatusMovedPermanently)
+			return
+		}
+		if article == nil {
+			core.JSON(w, http.StatusNotFound, nil)
+			return
+		}
+		h.write(w, r, article, article.UpdatedAt)
+	}
+}
+func (h *PublicHandler) GetJobs(w http.ResponseWriter, r *http.Request) {
+	limit, offset := getPaging(r)
+	jobs, total, err := h.service.GetJobs(r.Context(), limit, offset)
+	if err != nil {
+		h.Error(r.Context(), fmt.Sprintf("Error to get public jobs: %s", err.Error()))
+		http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+		return
+	}
+	h.write(w, r, &search.Result{List: &jobs, Total: total}, nil)
+}
+
+// write sends Last-Modified for a single item only; the lists have the ETag only, because their latest updated time does not change when an item is archived, expired or dropped off the page
+func (h *PublicHandler) write(w http.ResponseWriter, r *http.Request, value interface{}, lastModified *time.Time) {
+	if err := Write(w, r, value, lastModified, h.maxAge); err != nil {
+		h.Error(r.Context(), err.Error())
+	}
+}
+
+func getPaging(r *http.Request) (int64, int64) {
+	query := r.URL.Query()
+	limit, err := strconv.ParseInt(query.Get("limit"), 10, 64)
+	if err != nil || limit <= 0 {
+		limit = defaultLimit
+	} else if limit > maxLimit {
+		limit = maxLimit
+	}
+	page, err := strconv.ParseInt(query.Get("page"), 10, 64)
+	if err != nil || page <= 0 {
+		page = 1
+	}
+	return limit, search.GetOffset(limit, page)
+}
@@ -0,0 +1,78 @@
+package public
+
+import "time"
+
+type Config struct {
+	Lang   string `yaml:"lang" mapstructure:"lang" json:"lang,omitempty"`
+	MaxAge int64  `yaml:"max_age" mapstructure:"max_age" json:"maxAge,omitempty"`
+}
+
+type Category struct {
+	Id        string      `json:"id" gorm:"column:id"`
+	Name      string      `json:"name,omitempty" gorm:"column:name"`
+	Path      string      `json:"path,omitempty" gorm:"column:path"`
+	Resource  string      `json:"resource,omitempty" gorm:"column:resource_key"`
+	Icon      string      `json:"icon,omitempty" gorm:"column:icon"`
+	Sequence  int         `json:"sequence,omitempty" gorm:"column:sequence"`
+	Type      string      `json:"type,omitempty" gorm:"column:type"`
+	Parent    string      `json:"-" gorm:"column:parent"`
+	UpdatedAt *time.Time  `json:"-" gorm:"column:updated_at"`
+	Children  []*Category `json:"children,omitempty"`
+}
+
+type Content struct {
+	Id          string     `json:"id" gorm:"column:id"`
+	Lang        string     `json:"lang" gorm:"column:lang"`
+	Title       string     `json:"title,omitempty" gorm:"column:title"`
+	Body        string     `json:"body,omitempty" gorm:"column:body"`
+	PublishedAt *time.Time `json:"publishedAt,omitempty" gorm:"column:published_at"`
+	Tags        []string   `json:"tags,omitempty" gorm:"column:tags"`
+	UpdatedAt   *time.Time `json:"-" gorm:"column:updated_at"`
+}
+
+type Article struct {
+	Id            string     `json:"id" gorm:"column:id"`
+	Title         string     `json:"title,omitempty" gorm:"column:title"`
+	Slug          string     `json:"slug,omitempty" gorm:"column:slug"`
+	Description   string     `json:"description,omitempty" gorm:"column:description"`
+	PublishedAt   *time.Time `json:"publishedAt,omitempty" gorm:"column:published_at"`
+	UpdatedAt     *time.Time `json:"updatedAt,omitempty" gorm:"column:updated_at"`
+	Content       string     `json:"content,omitempty" gorm:"column:content"`
+	Thumbnail     *string    `json:"thumbnail,omitempty" gorm:"column:thumbnail"`
+	HighThumbnail *string    `json:"highThumbnail,omitempty" gorm:"column:high_thumbnail"`
+	Tags          []string   `json:"tags,omitempty" gorm:"column:tags"`
+}
+
+type Job struct {
+	Id          string     `json:"id" gorm:"column:id"`
+	Title       string     `json:"title,omitempty" gorm:"column:title"`
+	Description *string    `json:"description,omitempty" gorm:"column:description"`
+	PublishedAt *time.Time `json:"publishedAt,omitempty" gorm:"column:published_at"`
+	UpdatedAt   *time.Time `json:"updatedAt,omitempty" gorm:"column:updated_at"`
+	ExpiredAt   *time.Time `json:"expiredAt,omitempty" gorm:"column:expired_at"`
+	Position    *string    `json:"position,omitempty" gorm:"column:position"`
+	Quantity    int32      `json:"quantity,omitempty" gorm:"column:quantity"`
+	Location    *string    `json:"location,omitempty" gorm:"column:location"`
+	Skills      []string   `json:"skills,omitempty" gorm:"column:skills"`
+	MinSalary   *int64     `json:"minSalary,omitempty" gorm:"column:min_salary"`
+	MaxSalary   *int64     `json:"maxSalary,omitempty" gorm:"column:max_salary"`
+	CompanyId   *string    `json:"companyId,omitempty" gorm:"column:company_id"`
+}
+
+// BuildTree links the active categories to their parents, categories with an inactive or unknown parent are dropped, so they never leak
+func BuildTree(categories []Category) []*Category {
+	nodes := make(map[string]*Category, len(categories))
+	for i := range categories {
+		nodes[categories[i].Id] = &categories[i]
+	}
+	roots := make([]*Category, 0)
+	for i := range categories {
+		node := &categories[i]
+		if len(node.Parent) == 0 {
+			roots = append(roots, node)
+		} else if parent, ok := nodes[node.Parent]; ok {
+			parent.Children = append(parent.Children, node)
+		}
+	}
+	return roots
+}
@@ -0,0 +1,14 @@
+package public
+
+import (
+	"context"
+	"time"
+)
+
+type PublicRepository interface {
+	LoadCategories(ctx context.Context) ([]Category, error)
+	LoadContent(ctx context.Context, path string, lang string, now time.Time) (*Content, error)
+	SearchArticles(ctx context.Context, now time.Time, limit int64, offset int64) ([]Article, int64, error)
+	LoadArticle(ctx context.Context, id string, now time.Time) (*Article, error)
+	SearchJobs(ctx context.Context, now time.Time, limit int64, offset int64) ([]Job, int64, error)
+}
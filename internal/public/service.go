@@ -0,0 +1,72 @@
+package public
+
+import (
+	"context"
+	"time"
+
+	"go-service/internal/article"
+)
+
+type PublicService interface {
+	GetCategories(ctx context.Context) ([]*Category, error)
+	GetContent(ctx context.Context, path string, lang string) (*Content, error)
+	GetArticles(ctx context.Context, limit int64, offset int64) ([]Article, int64, error)
+	GetArticle(ctx context.Context, id string) (*Article, error)
+	GetArticleBySlug(ctx context.Context, slug string) (*Article, string, error)
+	GetJobs(ctx context.Context, limit int64, offset int64) ([]Job, int64, error)
+}
+
+func NewPublicService(repository PublicRepository, resolveSlug article.ResolveSlug, lang string) *PublicUseCase {
+	return &PublicUseCase{repository: repository, resolveSlug: resolveSlug, lang: lang}
+}
+
+type PublicUseCase struct {
+	repository  PublicRepository
+	resolveSlug article.ResolveSlug
+	lang        string
+}
+
+func (s *PublicUseCase) GetCategories(ctx context.Context) ([]*Category, error) {
+	categories, err := s.repository.LoadCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return BuildTree(categories), nil
+}
+
+// GetContent falls back to the default language when the content is not translated to the requested language yet
+func (s *PublicUseCase) GetContent(ctx context.Context, path string, lang string) (*Content, error) {
+	now := time.Now()
+	if len(lang) > 0 && lang != s.lang {
+		content, err := s.repository.LoadContent(ctx, path, lang, now)
+		if err != nil || content != nil {
+			return content, err
+		}
+	}
+	return s.repository.LoadContent(ctx, path, s.lang, now)
+}
+func (s *PublicUseCase) GetArticles(ctx context.Context, limit int64, offset int64) ([]Article, int64, error) {
+	return s.repository.SearchArticles(ctx, time.Now(), limit, offset)
+}
+func (s *PublicUseCase) GetArticle(ctx context.Context, id string) (*Article, error) {
+	return s.repository.LoadArticle(ctx, id, time.Now())
+}
+
+// GetArticleBySlug resolves the slug like the articles do, and returns the published article, or its current slug when the given one has been changed
+func (s *PublicUseCase) GetArticleBySlug(ctx context.Context, slug string) (*Article, string, error) {
+	resolution, err := s.resolveSlug(ctx, slug)
+	if err != nil || resolution == nil {
+		return nil, "", err
+	}
+	article, err := s.repository.LoadArticle(ctx, resolution.Id, time.Now())
+	if err != nil || article == nil {
+		return nil, "", err
+	}
+	if resolution.Redirect {
+		return nil, article.Slug, nil
+	}
+	return article, "", nil
+}
+func (s *PublicUseCase) GetJobs(ctx context.Context, limit int64, offset int64) ([]Job, int64, error) {
+	return s.repository.SearchJobs(ctx, time.Now(), limit, offset)
+}
@@ -0,0 +1,26 @@
+package public
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/core-go/core"
+	"github.com/lib/pq"
+
+	"go-service/internal/article"
+)
+
+type PublicTransport interface {
+	GetCategories(w http.ResponseWriter, r *http.Request)
+	GetContent(w http.ResponseWriter, r *http.Request)
+	GetArticles(w http.ResponseWriter, r *http.Request)
+	GetArticle(w http.ResponseWriter, r *http.Request)
+	GetArticleBySlug(w http.ResponseWriter, r *http.Request)
+	GetJobs(w http.ResponseWriter, r *http.Request)
+}
+
+func NewPublicTransport(db *sql.DB, conf Config, resolveSlug article.ResolveSlug, logError core.Log) PublicTransport {
+	publicRepository := NewPublicAdapter(db, pq.Array)
+	publicService := NewPublicService(publicRepository, resolveSlug, conf.Lang)
+	return NewPublicHandler(publicService, conf.MaxAge, logError)
+}
@@ -235,3 +235,21 @@ func (s *RoleAdapter) AssignRole(ctx context.Context, roleId string, users []str
 
 	return sts.Exec(ctx, s.db)
 }
+
+func (s *RoleAdapter) LoadUsers(ctx context.Context, roleId string) ([]string, error) {
+	query := fmt.Sprintf("select user_id from user_roles where role_id = %s", s.BuildParam(1))
+	rows, err := s.db.QueryContext(ctx, query, roleId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var users []string
+	for rows.Next() {
+		var userId string
+		if err = rows.Scan(&userId); err != nil {
+			return nil, err
+		}
+		users = append(users, userId)
+	}
+	return users, rows.Err()
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/core-go/core"
 	"github.com/core-go/core/builder"
 	search "github.com/core-go/search/handler"
+
+	"go-service/pkg/export"
 )
 
 func NewRoleHandler(
@@ -16,6 +18,7 @@ func NewRoleHandler(
 	logError core.Log,
 	validate core.Validate[*Role],
 	tracking builder.TrackingConfig,
+	exporter *export.Exporter[*RoleFilter],
 	writeLog core.WriteLog,
 	action *core.ActionConfig,
 ) *RoleHandler {
@@ -23,12 +26,13 @@ func NewRoleHandler(
 	builder := builder.NewBuilderByConfig[Role](nil, tracking)
 	params := core.CreateAttributes(roleType, logError, writeLog, action)
 	searchHandler := search.NewSearchHandler[Role, *RoleFilter](find, logError, nil)
-	return &RoleHandler{SearchHandler: searchHandler, service: roleService, validate: validate, builder: builder, Attributes: params}
+	return &RoleHandler{SearchHandler: searchHandler, service: roleService, validate: validate, builder: builder, Attributes: params, Exporter: exporter}
 }
 
 type RoleHandler struct {
-	service RoleRepository
+	service RoleService
 	*search.SearchHandler[Role, *RoleFilter]
+	*export.Exporter[*RoleFilter]
 	*core.Attributes
 	validate core.Validate[*Role]
 	builder  core.Builder[Role]
@@ -9,4 +9,5 @@ type RoleRepository interface {
 	Patch(ctx context.Context, obj map[string]interface{}) (int64, error)
 	Delete(ctx context.Context, id string) (int64, error)
 	AssignRole(ctx context.Context, roleId string, users []string) (int64, error)
+	LoadUsers(ctx context.Context, roleId string) ([]string, error)
 }
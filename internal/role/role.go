@@ -7,6 +7,7 @@ type Role struct {
 	RoleName   string     `json:"roleName,omitempty" gorm:"column:role_name" bson:"roleName,omitempty" dynamodbav:"roleName,omitempty" firestore:"roleName,omitempty" validate:"required,max=255"`
 	Status     string     `json:"status,omitempty" gorm:"column:status" bson:"status,omitempty" dynamodbav:"status,omitempty" firestore:"status,omitempty" match:"equal" validate:"required,max=1,code"`
 	Remark     *string    `json:"remark,omitempty" gorm:"column:remark" bson:"remark,omitempty" dynamodbav:"remark,omitempty" firestore:"remark,omitempty"`
+	TwoFactor  *bool      `json:"twoFactor,omitempty" gorm:"column:two_factor" bson:"twoFactor,omitempty" dynamodbav:"twoFactor,omitempty" firestore:"twoFactor,omitempty"`
 	CreatedBy  *string    `json:"createdBy,omitempty" gorm:"column:created_by" bson:"createdBy,omitempty" dynamodbav:"createdBy,omitempty" firestore:"createdBy,omitempty"`
 	CreatedAt  *time.Time `json:"createdAt,omitempty" gorm:"column:created_at" bson:"createdAt,omitempty" dynamodbav:"createdAt,omitempty" firestore:"createdAt,omitempty"`
 	UpdatedBy  *string    `json:"updatedBy,omitempty" gorm:"column:updated_by" bson:"updatedBy,omitempty" dynamodbav:"updatedBy,omitempty" firestore:"updatedBy,omitempty"`
@@ -11,12 +11,14 @@ type RoleService interface {
 	AssignRole(ctx context.Context, roleId string, users []string) (int64, error)
 }
 
-func NewRoleService(repository RoleRepository) RoleService {
-	return &RoleUseCase{repository: repository}
+// NewRoleService revokes the refresh tokens of the users added to or removed from the role, if revoke is not nil
+func NewRoleService(repository RoleRepository, revoke func(context.Context, string) (int64, error)) RoleService {
+	return &RoleUseCase{repository: repository, revoke: revoke}
 }
 
 type RoleUseCase struct {
 	repository RoleRepository
+	revoke     func(context.Context, string) (int64, error)
 }
 
 func (s *RoleUseCase) Load(ctx context.Context, id string) (*Role, error) {
@@ -35,5 +37,32 @@ func (s *RoleUseCase) Delete(ctx context.Context, id string) (int64, error) {
 	return s.repository.Delete(ctx, id)
 }
 func (s *RoleUseCase) AssignRole(ctx context.Context, roleId string, users []string) (int64, error) {
-	return s.repository.AssignRole(ctx, roleId, users)
+	if s.revoke == nil {
+		return s.repository.AssignRole(ctx, roleId, users)
+	}
+	existing, err := s.repository.LoadUsers(ctx, roleId)
+	if err != nil {
+		return -1, err
+	}
+	res, err := s.repository.AssignRole(ctx, roleId, users)
+	if err != nil || res <= 0 {
+		return res, err
+	}
+	changed := make(map[string]bool)
+	for _, userId := range existing {
+		changed[userId] = true
+	}
+	for _, userId := range users {
+		if changed[userId] {
+			delete(changed, userId)
+		} else {
+			changed[userId] = true
+		}
+	}
+	for userId := range changed {
+		if _, err = s.revoke(ctx, userId); err != nil {
+			return res, err
+		}
+	}
+	return res, nil
 }
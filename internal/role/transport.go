@@ -1,6 +1,7 @@
 package role
 
 import (
+	"context"
 	"database/sql"
 	"net/http"
 	"reflect"
@@ -9,11 +10,14 @@ import (
 	"github.com/core-go/core/builder"
 	"github.com/core-go/core/unique"
 	v "github.com/core-go/core/validator"
+	"github.com/core-go/search"
 	"github.com/core-go/search/convert"
 	q "github.com/core-go/sql"
 	"github.com/core-go/sql/query"
 	"github.com/core-go/sql/template"
 	tb "github.com/core-go/sql/template/builder"
+
+	"go-service/pkg/export"
 )
 
 type RoleTransport interface {
@@ -23,10 +27,11 @@ type RoleTransport interface {
 	Update(w http.ResponseWriter, r *http.Request)
 	Patch(w http.ResponseWriter, r *http.Request)
 	Delete(w http.ResponseWriter, r *http.Request)
+	Export(w http.ResponseWriter, r *http.Request)
 	AssignRole(w http.ResponseWriter, r *http.Request)
 }
 
-func NewRoleTransport(db *sql.DB, logError core.Log, templates map[string]*template.Template, tracking builder.TrackingConfig, writeLog core.WriteLog, action *core.ActionConfig) (RoleTransport, error) {
+func NewRoleTransport(db *sql.DB, logError core.Log, templates map[string]*template.Template, tracking builder.TrackingConfig, revoke func(context.Context, string) (int64, error), writeLog core.WriteLog, action *core.ActionConfig) (RoleTransport, error) {
 	validator, err := v.NewValidator[*Role]()
 	if err != nil {
 		return nil, err
@@ -50,7 +55,8 @@ func NewRoleTransport(db *sql.DB, logError core.Log, templates map[string]*templ
 	if er6 != nil {
 		return nil, er6
 	}
-	roleService := NewRoleService(roleRepository)
-	roleHandler := NewRoleHandler(roleSearchBuilder.Search, roleService, logError, roleValidator.Validate, tracking, writeLog, action)
+	roleService := NewRoleService(roleRepository, revoke)
+	roleExporter := export.NewExporter[Role, *RoleFilter](db, "role", func() *RoleFilter { return &RoleFilter{Filter: &search.Filter{}} }, queryRole, logError, writeLog)
+	roleHandler := NewRoleHandler(roleSearchBuilder.Search, roleService, logError, roleValidator.Validate, tracking, roleExporter, writeLog, action)
 	return roleHandler, nil
 }
@@ -0,0 +1,106 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type Config struct {
+	Interval int64  `yaml:"interval" mapstructure:"interval" json:"interval,omitempty"`
+	Lock     int64  `yaml:"lock" mapstructure:"lock" json:"lock,omitempty"`
+	User     string `yaml:"user" mapstructure:"user" json:"user,omitempty"`
+}
+
+type Task struct {
+	Resource string
+	Action   string
+	Query    string // update statement, which takes the current time as parameter and returns the keys of the changed rows
+}
+
+func NewScheduler(db *sql.DB, conf Config, tasks []Task, userKey string, logError func(context.Context, string, ...map[string]interface{}), writeLog func(context.Context, string, string, bool, string) error) *Scheduler {
+	return &Scheduler{db: db, Config: conf, tasks: tasks, userKey: userKey, logError: logError, writeLog: writeLog}
+}
+
+type Scheduler struct {
+	db       *sql.DB
+	Config   Config
+	tasks    []Task
+	userKey  string
+	logError func(context.Context, string, ...map[string]interface{})
+	writeLog func(context.Context, string, string, bool, string) error
+}
+
+func (s *Scheduler) Start(ctx context.Context) {
+	if s.Config.Interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(time.Duration(s.Config.Interval) * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.Run(ctx); err != nil && s.logError != nil {
+					s.logError(ctx, "error to run scheduler: "+err.Error())
+				}
+			}
+		}
+	}()
+}
+
+// Run executes all tasks in one transaction, guarded by a transaction level advisory lock, so only one instance does the work at a time
+func (s *Scheduler) Run(ctx context.Context) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var locked bool
+	err = tx.QueryRowContext(ctx, "select pg_try_advisory_xact_lock($1)", s.Config.Lock).Scan(&locked)
+	if err != nil || !locked {
+		return err
+	}
+	now := time.Now()
+	changes := make([][]string, len(s.tasks))
+	for i, task := range s.tasks {
+		keys, err := query(ctx, tx, task.Query, now)
+		if err != nil {
+			return fmt.Errorf("%s %s: %w", task.Action, task.Resource, err)
+		}
+		changes[i] = keys
+	}
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+	if s.writeLog != nil {
+		ctx = context.WithValue(ctx, s.userKey, s.Config.User)
+		for i, task := range s.tasks {
+			for _, key := range changes[i] {
+				s.writeLog(ctx, task.Resource, task.Action, true, fmt.Sprintf("%s '%s'", task.Action, key))
+			}
+		}
+	}
+	return nil
+}
+
+func query(ctx context.Context, tx *sql.Tx, query string, now time.Time) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, query, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
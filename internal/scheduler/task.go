@@ -0,0 +1,42 @@
+package scheduler
+
+import (
+	"fmt"
+
+	a "go-service/internal/article"
+	c "go-service/internal/content"
+	j "go-service/internal/job"
+)
+
+const (
+	statusActive   = "A"
+	statusInactive = "I"
+
+	actionPublish = "publish"
+	actionExpire  = "expire"
+)
+
+func NewPublishTasks() []Task {
+	return []Task{
+		{
+			Resource: "article",
+			Action:   actionPublish,
+			Query:    fmt.Sprintf("update articles set status = '%s' where status = '%s' and published_at <= $1 returning id", a.StatusPublished, a.StatusApproved),
+		},
+		{
+			Resource: "content",
+			Action:   actionPublish,
+			Query:    fmt.Sprintf("update contents set status = '%s' where status = '%s' and published_at <= $1 returning id || '/' || lang", statusActive, c.StatusScheduled),
+		},
+		{
+			Resource: "job",
+			Action:   actionPublish,
+			Query:    fmt.Sprintf("update jobs set status = '%s' where status = '%s' and published_at <= $1 and (expired_at is null or expired_at > $1) returning id", statusActive, j.StatusScheduled),
+		},
+		{
+			Resource: "job",
+			Action:   actionExpire,
+			Query:    fmt.Sprintf("update jobs set status = '%s' where status = '%s' and expired_at <= $1 returning id", statusInactive, statusActive),
+		},
+	}
+}
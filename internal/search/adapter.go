@@ -0,0 +1,54 @@
+package search
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	s "github.com/core-go/sql"
+)
+
+const headline = "StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=30, MinWords=10"
+
+func NewSearchAdapter(db *sql.DB) *SearchAdapter {
+	return &SearchAdapter{DB: db, BuildParam: s.BuildDollarParam}
+}
+
+type SearchAdapter struct {
+	DB         *sql.DB
+	BuildParam func(int) string
+}
+
+// Search ranks the rows of all sources, then builds the highlighted snippets of the current page only, because ts_headline is slow
+func (r *SearchAdapter) Search(ctx context.Context, q string, sources []Source, limit int64, offset int64) ([]Result, int64, error) {
+	var results []Result
+	if len(sources) == 0 {
+		return results, 0, nil
+	}
+	queries := make([]string, len(sources))
+	for i, source := range sources {
+		queries[i] = buildQuery(source, r.BuildParam(1))
+	}
+	union := strings.Join(queries, " union all ")
+	var total int64
+	row := r.DB.QueryRowContext(ctx, fmt.Sprintf("select count(*) from (%s) t", union), q)
+	if err := row.Scan(&total); err != nil || total == 0 {
+		return results, total, err
+	}
+	query := fmt.Sprintf(`select module, id, lang, title, status, rank,
+		ts_headline(search_config(lang), strip_html(body), websearch_to_tsquery(search_config(lang), %s), '%s') as snippet
+	from (%s order by rank desc, id limit %d offset %d) t order by rank desc, id`, r.BuildParam(1), headline, union, limit, offset)
+	err := s.Query(ctx, r.DB, nil, &results, query, q)
+	return results, total, err
+}
+
+func buildQuery(source Source, param string) string {
+	lang := source.Lang
+	if source.Fixed {
+		lang = "'" + source.Lang + "'"
+	}
+	query := fmt.Sprintf("websearch_to_tsquery(search_config(%s), %s)", lang, param)
+	return fmt.Sprintf("select '%s' as module, %s as id, %s::varchar as lang, %s as title, status, %s as body, ts_rank(search_vector, %s) as rank from %s where search_vector @@ %s",
+		source.Module, source.Key, lang, source.Title, source.Body, query, source.Table, query)
+}
@@ -0,0 +1,56 @@
+package search
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/core-go/core"
+	cs "github.com/core-go/search"
+)
+
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+)
+
+func NewSearchHandler(service SearchService, logError core.Log) *SearchHandler {
+	return &SearchHandler{service: service, Error: logError}
+}
+
+type SearchHandler struct {
+	service SearchService
+	Error   core.Log
+}
+
+func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	q := strings.TrimSpace(query.Get("q"))
+	if len(q) == 0 {
+		errors := []core.ErrorMessage{{Field: "q", Code: "required"}}
+		core.JSON(w, http.StatusUnprocessableEntity, errors)
+		return
+	}
+	var modules []string
+	if m := query.Get("modules"); len(m) > 0 {
+		modules = strings.Split(m, ",")
+	}
+	limit, err := strconv.ParseInt(query.Get("limit"), 10, 64)
+	if err != nil || limit <= 0 {
+		limit = defaultLimit
+	} else if limit > maxLimit {
+		limit = maxLimit
+	}
+	page, err := strconv.ParseInt(query.Get("page"), 10, 64)
+	if err != nil || page <= 0 {
+		page = 1
+	}
+	results, total, err := h.service.Search(r.Context(), q, modules, limit, cs.GetOffset(limit, page))
+	if err != nil {
+		h.Error(r.Context(), fmt.Sprintf("Error to search '%s': %s", q, err.Error()))
+		http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+		return
+	}
+	core.JSON(w, http.StatusOK, &cs.Result{List: &results, Total: total})
+}
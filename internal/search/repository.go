@@ -0,0 +1,7 @@
+package search
+
+import "context"
+
+type SearchRepository interface {
+	Search(ctx context.Context, q string, sources []Source, limit int64, offset int64) ([]Result, int64, error)
+}
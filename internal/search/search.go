@@ -0,0 +1,28 @@
+package search
+
+type Result struct {
+	Module  string  `json:"module" gorm:"column:module"`
+	Id      string  `json:"id" gorm:"column:id"`
+	Lang    *string `json:"lang,omitempty" gorm:"column:lang"`
+	Title   string  `json:"title" gorm:"column:title"`
+	Status  *string `json:"status,omitempty" gorm:"column:status"`
+	Rank    float64 `json:"rank" gorm:"column:rank"`
+	Snippet string  `json:"snippet" gorm:"column:snippet"`
+}
+
+// Source is a table indexed by the search_vector column; Lang is the column of the language of the row, or the language of all rows when Fixed
+type Source struct {
+	Module string
+	Table  string
+	Key    string
+	Lang   string
+	Fixed  bool
+	Title  string
+	Body   string
+}
+
+var Sources = []Source{
+	{Module: "article", Table: "articles", Key: "id", Lang: "en", Fixed: true, Title: "coalesce(title, '')", Body: "coalesce(description, '') || ' ' || coalesce(content, '')"},
+	{Module: "content", Table: "contents", Key: "id", Lang: "lang", Title: "coalesce(title, '')", Body: "body"},
+	{Module: "job", Table: "jobs", Key: "id", Lang: "en", Fixed: true, Title: "coalesce(title, '')", Body: "description"},
+}
@@ -0,0 +1,46 @@
+package search
+
+import (
+	"context"
+
+	c "github.com/core-go/core/constants"
+)
+
+type SearchService interface {
+	Search(ctx context.Context, q string, modules []string, limit int64, offset int64) ([]Result, int64, error)
+}
+
+func NewSearchService(repository SearchRepository, privilege func(ctx context.Context, userId string, privilegeId string) int32, userKey string) *SearchUseCase {
+	return &SearchUseCase{repository: repository, privilege: privilege, userKey: userKey}
+}
+
+type SearchUseCase struct {
+	repository SearchRepository
+	privilege  func(ctx context.Context, userId string, privilegeId string) int32
+	userKey    string
+}
+
+// Search only looks into the requested modules which the user can read; all modules are searched when no module is requested
+func (s *SearchUseCase) Search(ctx context.Context, q string, modules []string, limit int64, offset int64) ([]Result, int64, error) {
+	var sources []Source
+	userId, _ := ctx.Value(s.userKey).(string)
+	for _, source := range Sources {
+		if len(modules) > 0 && !contains(modules, source.Module) {
+			continue
+		}
+		if s.privilege != nil && s.privilege(ctx, userId, source.Module)&c.ActionRead != c.ActionRead {
+			continue
+		}
+		sources = append(sources, source)
+	}
+	return s.repository.Search(ctx, q, sources, limit, offset)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
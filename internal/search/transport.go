@@ -0,0 +1,19 @@
+package search
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/core-go/core"
+)
+
+type SearchTransport interface {
+	Search(w http.ResponseWriter, r *http.Request)
+}
+
+func NewSearchTransport(db *sql.DB, privilege func(ctx context.Context, userId string, privilegeId string) int32, userKey string, logError core.Log) SearchTransport {
+	searchRepository := NewSearchAdapter(db)
+	searchService := NewSearchService(searchRepository, privilege, userKey)
+	return NewSearchHandler(searchService, logError)
+}
@@ -0,0 +1,101 @@
+package session
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"time"
+
+	s "github.com/core-go/sql"
+)
+
+const selectSession = "select id, user_id, ip, user_agent, created_at, last_seen_at, expires_at, revoked_at, revoked_by from sessions"
+
+func NewSessionAdapter(db *sql.DB) (*SessionAdapter, error) {
+	parameters, err := s.CreateParameters(reflect.TypeOf(Session{}), db)
+	if err != nil {
+		return nil, err
+	}
+	return &SessionAdapter{DB: db, Parameters: parameters}, nil
+}
+
+type SessionAdapter struct {
+	DB *sql.DB
+	*s.Parameters
+}
+
+func (r *SessionAdapter) Create(ctx context.Context, session *Session) (int64, error) {
+	query := fmt.Sprintf("insert into sessions (id, user_id, ip, user_agent, created_at, last_seen_at, expires_at) values (%s, %s, %s, %s, %s, %s, %s)",
+		r.BuildParam(1), r.BuildParam(2), r.BuildParam(3), r.BuildParam(4), r.BuildParam(5), r.BuildParam(6), r.BuildParam(7))
+	tx := s.GetTx(ctx, r.DB)
+	res, err := tx.ExecContext(ctx, query, session.Id, session.UserId, session.Ip, session.UserAgent, session.CreatedAt, session.LastSeenAt, session.ExpiresAt)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *SessionAdapter) Load(ctx context.Context, id string) (*Session, error) {
+	var sessions []Session
+	query := fmt.Sprintf("%s where id = %s limit 1", selectSession, r.BuildParam(1))
+	err := s.Query(ctx, r.DB, r.Map, &sessions, query, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(sessions) > 0 {
+		return &sessions[0], nil
+	}
+	return nil, nil
+}
+
+// LoadByUser returns the sessions which are not revoked and not expired, the last seen first
+func (r *SessionAdapter) LoadByUser(ctx context.Context, userId string, now time.Time) ([]Session, error) {
+	sessions := make([]Session, 0)
+	query := fmt.Sprintf("%s where user_id = %s and revoked_at is null and expires_at > %s order by last_seen_at desc", selectSession, r.BuildParam(1), r.BuildParam(2))
+	err := s.Query(ctx, r.DB, r.Map, &sessions, query, userId, now)
+	return sessions, err
+}
+
+// Touch updates the last seen time, and the expiry when the refresh token is rotated
+func (r *SessionAdapter) Touch(ctx context.Context, id string, lastSeenAt time.Time, expiresAt *time.Time) (int64, error) {
+	query := fmt.Sprintf("update sessions set last_seen_at = %s, expires_at = coalesce(%s, expires_at) where id = %s and revoked_at is null",
+		r.BuildParam(1), r.BuildParam(2), r.BuildParam(3))
+	tx := s.GetTx(ctx, r.DB)
+	res, err := tx.ExecContext(ctx, query, lastSeenAt, expiresAt, id)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+// Revoke revokes the session and its refresh tokens, so that it cannot be refreshed either
+func (r *SessionAdapter) Revoke(ctx context.Context, userId string, id string, revokedBy string, revokedAt time.Time) (int64, error) {
+	query := fmt.Sprintf("update sessions set revoked_at = %s, revoked_by = %s where id = %s and user_id = %s and revoked_at is null",
+		r.BuildParam(1), r.BuildParam(2), r.BuildParam(3), r.BuildParam(4))
+	tx := s.GetTx(ctx, r.DB)
+	res, err := tx.ExecContext(ctx, query, revokedAt, revokedBy, id, userId)
+	if err != nil {
+		return -1, err
+	}
+	query = fmt.Sprintf("update refresh_tokens set revoked_at = %s where session_id = %s and revoked_at is null", r.BuildParam(1), r.BuildParam(2))
+	if _, err = tx.ExecContext(ctx, query, revokedAt, id); err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *SessionAdapter) RevokeAll(ctx context.Context, userId string, revokedBy string, revokedAt time.Time) (int64, error) {
+	query := fmt.Sprintf("update sessions set revoked_at = %s, revoked_by = %s where user_id = %s and revoked_at is null",
+		r.BuildParam(1), r.BuildParam(2), r.BuildParam(3))
+	tx := s.GetTx(ctx, r.DB)
+	res, err := tx.ExecContext(ctx, query, revokedAt, revokedBy, userId)
+	if err != nil {
+		return -1, err
+	}
+	query = fmt.Sprintf("update refresh_tokens set revoked_at = %s where user_id = %s and revoked_at is null", r.BuildParam(1), r.BuildParam(2))
+	if _, err = tx.ExecContext(ctx, query, revokedAt, userId); err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
@@ -0,0 +1,42 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/core-go/core"
+)
+
+func NewSessionChecker(verifyToken func(string, string) (map[string]interface{}, int64, int64, error), secret string, service SessionService, logError core.Log) *SessionChecker {
+	return &SessionChecker{verifyToken: verifyToken, secret: secret, service: service, logError: logError}
+}
+
+// SessionChecker refuses the access tokens of the revoked sessions, and when the session cannot be checked
+type SessionChecker struct {
+	verifyToken func(string, string) (map[string]interface{}, int64, int64, error)
+	secret      string
+	service     SessionService
+	logError    core.Log
+}
+
+func (c *SessionChecker) Check(id string, token string, issuedAt time.Time) string {
+	payload, _, _, err := c.verifyToken(token, c.secret)
+	if err != nil {
+		return "invalid token"
+	}
+	sessionId, _ := payload[Key].(string)
+	if len(id) == 0 || len(sessionId) == 0 {
+		return ""
+	}
+	ctx := context.Background()
+	active, err := c.service.IsActive(ctx, id, sessionId)
+	if err != nil {
+		c.logError(ctx, fmt.Sprintf("Error to check the session '%s' of '%s': %s", sessionId, id, err.Error()))
+		return "session not checked"
+	}
+	if !active {
+		return "session revoked"
+	}
+	return ""
+}
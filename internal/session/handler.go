@@ -0,0 +1,98 @@
+package session
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/core-go/core"
+)
+
+func NewSessionHandler(service SessionService, userKey string, logError core.Log, writeLog core.WriteLog, action *core.ActionConfig) *SessionHandler {
+	attributes := core.CreateAttributes(reflect.TypeOf(Session{}), logError, writeLog, action)
+	return &SessionHandler{service: service, userKey: userKey, Attributes: attributes}
+}
+
+type SessionHandler struct {
+	service SessionService
+	userKey string
+	*core.Attributes
+}
+
+// Mine lists the sessions of the current user, the session of the request is marked as current
+func (h *SessionHandler) Mine(w http.ResponseWriter, r *http.Request) {
+	userId, _ := r.Context().Value(h.userKey).(string)
+	current, _ := r.Context().Value(Key).(string)
+	sessions, err := h.service.List(r.Context(), userId)
+	if err != nil {
+		h.Error(r.Context(), fmt.Sprintf("Error to get the sessions of '%s': %s", userId, err.Error()))
+		http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+		return
+	}
+	for i := range sessions {
+		sessions[i].Current = sessions[i].Id == current
+	}
+	core.JSON(w, http.StatusOK, sessions)
+}
+
+func (h *SessionHandler) RevokeMine(w http.ResponseWriter, r *http.Request) {
+	id, err := core.GetRequiredString(w, r)
+	if err == nil {
+		userId, _ := r.Context().Value(h.userKey).(string)
+		h.revoke(w, r, userId, id)
+	}
+}
+
+func (h *SessionHandler) List(w http.ResponseWriter, r *http.Request) {
+	userId, err := core.GetRequiredString(w, r, 1)
+	if err == nil {
+		sessions, err := h.service.List(r.Context(), userId)
+		if err != nil {
+			h.Error(r.Context(), fmt.Sprintf("Error to get the sessions of '%s': %s", userId, err.Error()))
+			http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+			return
+		}
+		core.JSON(w, http.StatusOK, sessions)
+	}
+}
+
+func (h *SessionHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	id, err := core.GetRequiredString(w, r)
+	if err == nil {
+		userId, err := core.GetRequiredString(w, r, 2)
+		if err == nil {
+			h.revoke(w, r, userId, id)
+		}
+	}
+}
+
+func (h *SessionHandler) RevokeAll(w http.ResponseWriter, r *http.Request) {
+	userId, err := core.GetRequiredString(w, r, 1)
+	if err == nil {
+		res, err := h.service.RevokeAll(r.Context(), userId)
+		if err != nil {
+			h.Error(r.Context(), fmt.Sprintf("Error to revoke the sessions of '%s': %s", userId, err.Error()))
+			h.Log(r.Context(), h.Resource, "revoke", false, err.Error())
+			http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+			return
+		}
+		h.Log(r.Context(), h.Resource, "revoke", true, fmt.Sprintf("%d sessions of '%s'", res, userId))
+		core.JSON(w, http.StatusOK, res)
+	}
+}
+
+func (h *SessionHandler) revoke(w http.ResponseWriter, r *http.Request, userId string, id string) {
+	res, err := h.service.Revoke(r.Context(), userId, id)
+	if err != nil {
+		h.Error(r.Context(), fmt.Sprintf("Error to revoke the session '%s' of '%s': %s", id, userId, err.Error()))
+		h.Log(r.Context(), h.Resource, "revoke", false, err.Error())
+		http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+		return
+	}
+	if res > 0 {
+		h.Log(r.Context(), h.Resource, "revoke", true, fmt.Sprintf("session '%s' of '%s'", id, userId))
+		core.JSON(w, http.StatusOK, res)
+	} else {
+		core.JSON(w, http.StatusNotFound, res)
+	}
+}
@@ -0,0 +1,15 @@
+package session
+
+import (
+	"context"
+	"time"
+)
+
+type SessionRepository interface {
+	Create(ctx context.Context, session *Session) (int64, error)
+	Load(ctx context.Context, id string) (*Session, error)
+	LoadByUser(ctx context.Context, userId string, now time.Time) ([]Session, error)
+	Touch(ctx context.Context, id string, lastSeenAt time.Time, expiresAt *time.Time) (int64, error)
+	Revoke(ctx context.Context, userId string, id string, revokedBy string, revokedAt time.Time) (int64, error)
+	RevokeAll(ctx context.Context, userId string, revokedBy string, revokedAt time.Time) (int64, error)
+}
@@ -0,0 +1,86 @@
+package session
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/core-go/core/tx"
+)
+
+type SessionService interface {
+	Start(ctx context.Context, userId string, ip string, userAgent string, expiresAt time.Time) (string, error)
+	Load(ctx context.Context, id string) (*Session, error)
+	Touch(ctx context.Context, id string, expiresAt *time.Time) (int64, error)
+	List(ctx context.Context, userId string) ([]Session, error)
+	Revoke(ctx context.Context, userId string, id string) (int64, error)
+	RevokeAll(ctx context.Context, userId string) (int64, error)
+	IsActive(ctx context.Context, userId string, id string) (bool, error)
+}
+
+func NewSessionService(db *sql.DB, repository SessionRepository, generateId func(context.Context) (string, error), userKey string) *SessionUseCase {
+	return &SessionUseCase{db: db, repository: repository, generateId: generateId, userKey: userKey}
+}
+
+type SessionUseCase struct {
+	db         *sql.DB
+	repository SessionRepository
+	generateId func(context.Context) (string, error)
+	userKey    string
+}
+
+// Start records the login; it joins the transaction of the context if any, with the refresh token of the session
+func (s *SessionUseCase) Start(ctx context.Context, userId string, ip string, userAgent string, expiresAt time.Time) (string, error) {
+	id, err := s.generateId(ctx)
+	if err != nil {
+		return "", err
+	}
+	if len(userAgent) > 500 {
+		userAgent = userAgent[:500]
+	}
+	now := time.Now()
+	_, err = s.repository.Create(ctx, &Session{Id: id, UserId: userId, Ip: ip, UserAgent: userAgent, CreatedAt: now, LastSeenAt: now, ExpiresAt: expiresAt})
+	return id, err
+}
+
+func (s *SessionUseCase) Load(ctx context.Context, id string) (*Session, error) {
+	return s.repository.Load(ctx, id)
+}
+
+func (s *SessionUseCase) Touch(ctx context.Context, id string, expiresAt *time.Time) (int64, error) {
+	return s.repository.Touch(ctx, id, time.Now(), expiresAt)
+}
+
+func (s *SessionUseCase) List(ctx context.Context, userId string) ([]Session, error) {
+	return s.repository.LoadByUser(ctx, userId, time.Now())
+}
+
+func (s *SessionUseCase) Revoke(ctx context.Context, userId string, id string) (int64, error) {
+	revokedBy, _ := ctx.Value(s.userKey).(string)
+	return tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
+		return s.repository.Revoke(ctx, userId, id, revokedBy, time.Now())
+	})
+}
+
+func (s *SessionUseCase) RevokeAll(ctx context.Context, userId string) (int64, error) {
+	revokedBy, _ := ctx.Value(s.userKey).(string)
+	return tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
+		return s.repository.RevokeAll(ctx, userId, revokedBy, time.Now())
+	})
+}
+
+// IsActive is false if the session is revoked or expired. It updates the last seen time at most once a minute, to save the writes.
+func (s *SessionUseCase) IsActive(ctx context.Context, userId string, id string) (bool, error) {
+	session, err := s.repository.Load(ctx, id)
+	if err != nil || session == nil {
+		return false, err
+	}
+	now := time.Now()
+	if session.UserId != userId || session.RevokedAt != nil || session.ExpiresAt.Before(now) {
+		return false, nil
+	}
+	if now.Sub(session.LastSeenAt) > time.Minute {
+		_, err = s.repository.Touch(ctx, id, now, nil)
+	}
+	return true, err
+}
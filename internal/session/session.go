@@ -0,0 +1,19 @@
+package session
+
+import "time"
+
+// Key is the claim of the access token with the id of the session
+const Key = "sid"
+
+type Session struct {
+	Id         string     `json:"id" gorm:"column:id;primary_key"`
+	UserId     string     `json:"userId" gorm:"column:user_id"`
+	Ip         string     `json:"ip,omitempty" gorm:"column:ip"`
+	UserAgent  string     `json:"userAgent,omitempty" gorm:"column:user_agent"`
+	CreatedAt  time.Time  `json:"createdAt" gorm:"column:created_at"`
+	LastSeenAt time.Time  `json:"lastSeenAt" gorm:"column:last_seen_at"`
+	ExpiresAt  time.Time  `json:"expiresAt" gorm:"column:expires_at"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty" gorm:"column:revoked_at"`
+	RevokedBy  *string    `json:"revokedBy,omitempty" gorm:"column:revoked_by"`
+	Current    bool       `json:"current,omitempty"`
+}
@@ -0,0 +1,27 @@
+package session
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/core-go/core"
+)
+
+type SessionTransport interface {
+	Mine(w http.ResponseWriter, r *http.Request)
+	RevokeMine(w http.ResponseWriter, r *http.Request)
+	List(w http.ResponseWriter, r *http.Request)
+	Revoke(w http.ResponseWriter, r *http.Request)
+	RevokeAll(w http.ResponseWriter, r *http.Request)
+}
+
+func NewSessionTransport(db *sql.DB, generateId func(context.Context) (string, error), userKey string, logError core.Log, writeLog core.WriteLog, action *core.ActionConfig) (SessionTransport, SessionService, error) {
+	sessionRepository, err := NewSessionAdapter(db)
+	if err != nil {
+		return nil, nil, err
+	}
+	sessionService := NewSessionService(db, sessionRepository, generateId, userKey)
+	sessionHandler := NewSessionHandler(sessionService, userKey, logError, writeLog, action)
+	return sessionHandler, sessionService, nil
+}
@@ -0,0 +1,197 @@
+package tag
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	s "github.com/core-go/sql"
+)
+
+// usedBy lists the array columns which keep the tags of each module; the changes of a versioned module bump the version and are kept as revisions
+var usedBy = []struct {
+	module    string
+	table     string
+	column    string
+	versioned bool
+}{
+	{"article", "articles", "tags", false},
+	{"content", "contents", "tags", true},
+	{"job", "jobs", "skills", false},
+}
+
+func NewTagAdapter(db *sql.DB, toArray s.Array) (*TagAdapter, error) {
+	parameters, err := s.CreateParameters(reflect.TypeOf(Tag{}), db)
+	if err != nil {
+		return nil, err
+	}
+	return &TagAdapter{DB: db, Parameters: parameters, Array: toArray}, nil
+}
+
+type TagAdapter struct {
+	DB *sql.DB
+	*s.Parameters
+	Array s.Array
+}
+
+func (r *TagAdapter) Load(ctx context.Context, id string) (*Tag, error) {
+	var tags []Tag
+	query := fmt.Sprintf("select %s from tags where id = %s limit 1", r.Fields, r.BuildParam(1))
+	err := s.Query(ctx, r.DB, r.Map, &tags, query, id)
+	if err != nil || len(tags) == 0 {
+		return nil, err
+	}
+	return &tags[0], nil
+}
+
+func (r *TagAdapter) Create(ctx context.Context, tag *Tag) (int64, error) {
+	query := fmt.Sprintf("insert into tags (id, description, created_by, created_at) values (%s, %s, %s, %s) on conflict (id) do nothing",
+		r.BuildParam(1), r.BuildParam(2), r.BuildParam(3), r.BuildParam(4))
+	tx := s.GetTx(ctx, r.DB)
+	res, err := tx.ExecContext(ctx, query, tag.Id, tag.Description, tag.CreatedBy, tag.CreatedAt)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+// Delete removes the tag from the registry, but not when it is still used
+func (r *TagAdapter) Delete(ctx context.Context, id string) (int64, error) {
+	tx := s.GetTx(ctx, r.DB)
+	exist, err := s.Exist(ctx, tx, r.buildUsedQuery(r.BuildParam(1)), id)
+	if err != nil || exist {
+		return -1, err
+	}
+	query := fmt.Sprintf("delete from tags where id = %s", r.BuildParam(1))
+	res, err := tx.ExecContext(ctx, query, id)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *TagAdapter) DeleteUnused(ctx context.Context) (int64, error) {
+	query := fmt.Sprintf("delete from tags t where not exists (%s)", r.buildUsedQuery("t.id"))
+	tx := s.GetTx(ctx, r.DB)
+	res, err := tx.ExecContext(ctx, query)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *TagAdapter) buildUsedQuery(tag string) string {
+	queries := make([]string, len(usedBy))
+	for i, u := range usedBy {
+		queries[i] = fmt.Sprintf("select 1 from %s where %s = any(%s)", u.table, tag, u.column)
+	}
+	return strings.Join(queries, " union all ")
+}
+
+func (r *TagAdapter) GetUsages(ctx context.Context, q string) ([]TagUsage, error) {
+	var usages []TagUsage
+	counts := make([]string, len(usedBy))
+	queries := []string{"select id, true as registered, '' as module from tags"}
+	for i, u := range usedBy {
+		counts[i] = fmt.Sprintf("count(*) filter (where module = '%s') as %s", u.module, u.module)
+		queries = append(queries, fmt.Sprintf("select unnest(%s) as id, false as registered, '%s' as module from %s", u.column, u.module, u.table))
+	}
+	query := fmt.Sprintf("select id, bool_or(registered) as registered, %s from (%s) t", strings.Join(counts, ", "), strings.Join(queries, " union all "))
+	var params []interface{}
+	if len(q) > 0 {
+		query = query + fmt.Sprintf(" where id ilike %s", r.BuildParam(1))
+		params = append(params, q+"%")
+	}
+	query = query + " group by id order by id"
+	err := s.Query(ctx, r.DB, nil, &usages, query, params...)
+	return usages, err
+}
+
+// Merge replaces the tags by the target tag in all modules, keeping the order and removing the duplicates, then updates the registry.
+// It must run in a transaction, so that the tags are changed everywhere or nowhere.
+func (r *TagAdapter) Merge(ctx context.Context, tags []string, into string, createdBy string) (int64, error) {
+	tx := s.GetTx(ctx, r.DB)
+	now := time.Now()
+	var total int64
+	for _, u := range usedBy {
+		merged := fmt.Sprintf(`(
+			select array_agg(tag order by o) from (
+				select case when t = any(%s) then %s else t end as tag, min(o) as o from unnest(%s) with ordinality u(t, o) group by 1
+			) x)`, r.BuildParam(1), r.BuildParam(2), u.column)
+		query := fmt.Sprintf("update %s set %s = %s where %s && %s", u.table, u.column, merged, u.column, r.BuildParam(1))
+		params := []interface{}{r.Array(tags), into}
+		if u.versioned {
+			// the contents are saved as a new version with its revision, like an update of the editors
+			query = fmt.Sprintf(`with changed as (
+				update contents set tags = %s, version = coalesce(version, 0) + 1, updated_by = %s, updated_at = %s where tags && %s
+				returning id, lang, version, title, body, published_at, tags, status)
+			insert into content_revisions (id, lang, version, title, body, published_at, tags, status, created_by, created_at)
+			select id, lang, version, title, body, published_at, tags, status, %s, %s from changed`,
+				merged, r.BuildParam(3), r.BuildParam(4), r.BuildParam(1), r.BuildParam(3), r.BuildParam(4))
+			params = append(params, createdBy, now)
+		}
+		res, err := tx.ExecContext(ctx, query, params...)
+		if err != nil {
+			return -1, err
+		}
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return -1, err
+		}
+		total = total + rows
+	}
+	query := fmt.Sprintf("delete from tags where id = any(%s) and id <> %s", r.BuildParam(1), r.BuildParam(2))
+	res, err := tx.ExecContext(ctx, query, r.Array(tags), into)
+	if err != nil {
+		return -1, err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return -1, err
+	}
+	if total+rows == 0 {
+		return 0, nil
+	}
+	query = fmt.Sprintf("insert into tags (id, created_by, created_at) values (%s, %s, %s) on conflict (id) do nothing", r.BuildParam(1), r.BuildParam(2), r.BuildParam(3))
+	if _, err = tx.ExecContext(ctx, query, into, createdBy, now); err != nil {
+		return -1, err
+	}
+	return total + rows, nil
+}
+
+// Rename changes the id of the tag in the registry, so that the description is kept; nothing is changed when the name is already a tag
+func (r *TagAdapter) Rename(ctx context.Context, id string, name string) (int64, error) {
+	query := fmt.Sprintf("update tags set id = %s where id = %s and not exists (select 1 from tags where id = %s)", r.BuildParam(1), r.BuildParam(2), r.BuildParam(1))
+	tx := s.GetTx(ctx, r.DB)
+	res, err := tx.ExecContext(ctx, query, name, id)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *TagAdapter) Unregistered(ctx context.Context, tags []string) ([]string, error) {
+	if len(tags) == 0 {
+		return nil, nil
+	}
+	var registered []Tag
+	query := fmt.Sprintf("select id from tags where id = any(%s)", r.BuildParam(1))
+	err := s.Query(ctx, r.DB, nil, &registered, query, r.Array(tags))
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[string]bool)
+	for _, t := range registered {
+		ids[t.Id] = true
+	}
+	var unregistered []string
+	for _, t := range tags {
+		if !ids[t] {
+			unregistered = append(unregistered, t)
+		}
+	}
+	return unregistered, nil
+}
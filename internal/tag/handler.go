@@ -0,0 +1,153 @@
+package tag
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/core-go/core"
+)
+
+func NewTagHandler(service TagService, logError core.Log, validate core.Validate[*Tag], writeLog core.WriteLog, action *core.ActionConfig) *TagHandler {
+	attributes := core.CreateAttributes(reflect.TypeOf(Tag{}), logError, writeLog, action)
+	return &TagHandler{service: service, Validate: validate, Attributes: attributes}
+}
+
+type TagHandler struct {
+	service  TagService
+	Validate core.Validate[*Tag]
+	*core.Attributes
+}
+
+func (h *TagHandler) GetUsages(w http.ResponseWriter, r *http.Request) {
+	usages, err := h.service.GetUsages(r.Context(), strings.TrimSpace(r.URL.Query().Get("q")))
+	if err != nil {
+		h.Error(r.Context(), fmt.Sprintf("Error to get tag usages: %s", err.Error()))
+		http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+		return
+	}
+	core.JSON(w, http.StatusOK, usages)
+}
+func (h *TagHandler) Load(w http.ResponseWriter, r *http.Request) {
+	id, err := core.GetRequiredString(w, r)
+	if err == nil {
+		tag, err := h.service.Load(r.Context(), id)
+		if err != nil {
+			h.Error(r.Context(), fmt.Sprintf("Error to get tag '%s': %s", id, err.Error()))
+			http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+			return
+		}
+		core.JSON(w, core.IsFound(tag), tag)
+	}
+}
+func (h *TagHandler) Create(w http.ResponseWriter, r *http.Request) {
+	tag, er1 := core.Decode[Tag](w, r)
+	if er1 == nil {
+		tag.Id = strings.TrimSpace(tag.Id)
+		errors, er2 := h.Validate(r.Context(), &tag)
+		if !core.HasError(w, r, errors, er2, h.Error, &tag, h.Log, h.Resource, h.Action.Create) {
+			res, er3 := h.service.Create(r.Context(), &tag)
+			if er3 != nil {
+				h.Error(r.Context(), er3.Error())
+				h.Log(r.Context(), h.Resource, h.Action.Create, false, er3.Error())
+				http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+				return
+			}
+
+			if res > 0 {
+				h.Log(r.Context(), h.Resource, h.Action.Create, true, fmt.Sprintf("create '%s'", tag.Id))
+				core.JSON(w, http.StatusCreated, tag)
+			} else {
+				h.Log(r.Context(), h.Resource, h.Action.Create, false, fmt.Sprintf("conflict '%s'", tag.Id))
+				core.JSON(w, http.StatusConflict, res)
+			}
+		}
+	}
+}
+func (h *TagHandler) Rename(w http.ResponseWriter, r *http.Request) {
+	id, er1 := core.GetRequiredString(w, r, 1)
+	if er1 == nil {
+		req, er2 := core.Decode[RenameRequest](w, r)
+		if er2 == nil {
+			name := strings.TrimSpace(req.Name)
+			if len(name) == 0 {
+				errors := []core.ErrorMessage{{Field: "name", Code: "required"}}
+				core.JSON(w, http.StatusUnprocessableEntity, errors)
+				return
+			}
+			res, err := h.service.Rename(r.Context(), id, name)
+			h.respond(w, r, "rename", fmt.Sprintf("rename '%s' to '%s'", id, name), fmt.Sprintf("not found '%s'", id), res, err)
+		}
+	}
+}
+func (h *TagHandler) Merge(w http.ResponseWriter, r *http.Request) {
+	req, er1 := core.Decode[MergeRequest](w, r)
+	if er1 == nil {
+		tags := Normalize(req.Tags)
+		into := strings.TrimSpace(req.Into)
+		var errors []core.ErrorMessage
+		if len(tags) == 0 {
+			errors = append(errors, core.ErrorMessage{Field: "tags", Code: "required"})
+		}
+		if len(into) == 0 {
+			errors = append(errors, core.ErrorMessage{Field: "into", Code: "required"})
+		}
+		if len(errors) > 0 {
+			core.JSON(w, http.StatusUnprocessableEntity, errors)
+			return
+		}
+		res, err := h.service.Merge(r.Context(), tags, into)
+		h.respond(w, r, "merge", fmt.Sprintf("merge '%s' into '%s'", strings.Join(tags, ","), into), fmt.Sprintf("not found '%s'", strings.Join(tags, ",")), res, err)
+	}
+}
+func (h *TagHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := core.GetRequiredString(w, r)
+	if err == nil {
+		res, err := h.service.Delete(r.Context(), id)
+		if err != nil {
+			h.Error(r.Context(), err.Error())
+			h.Log(r.Context(), h.Resource, h.Action.Delete, false, err.Error())
+			http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+			return
+		}
+
+		if res > 0 {
+			h.Log(r.Context(), h.Resource, h.Action.Delete, true, fmt.Sprintf("%s '%s'", h.Action.Delete, id))
+			core.JSON(w, http.StatusOK, res)
+		} else if res == 0 {
+			h.Log(r.Context(), h.Resource, h.Action.Delete, false, fmt.Sprintf("not found '%s'", id))
+			core.JSON(w, http.StatusNotFound, res)
+		} else {
+			h.Log(r.Context(), h.Resource, h.Action.Delete, false, fmt.Sprintf("in use '%s'", id))
+			core.JSON(w, http.StatusConflict, res)
+		}
+	}
+}
+func (h *TagHandler) DeleteUnused(w http.ResponseWriter, r *http.Request) {
+	res, err := h.service.DeleteUnused(r.Context())
+	if err != nil {
+		h.Error(r.Context(), err.Error())
+		h.Log(r.Context(), h.Resource, h.Action.Delete, false, err.Error())
+		http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+		return
+	}
+	h.Log(r.Context(), h.Resource, h.Action.Delete, true, fmt.Sprintf("delete %d unused tags", res))
+	core.JSON(w, http.StatusOK, res)
+}
+
+func (h *TagHandler) respond(w http.ResponseWriter, r *http.Request, action string, success string, notFound string, res int64, err error) {
+	if err != nil {
+		h.Error(r.Context(), err.Error())
+		h.Log(r.Context(), h.Resource, action, false, err.Error())
+		http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+		return
+	}
+	if res > 0 {
+		h.Log(r.Context(), h.Resource, action, true, success)
+		core.JSON(w, http.StatusOK, res)
+	} else {
+		h.Log(r.Context(), h.Resource, action, false, notFound)
+		core.JSON(w, http.StatusNotFound, res)
+	}
+}
@@ -0,0 +1,13 @@
+package tag
+
+import "context"
+
+type TagRepository interface {
+	Load(ctx context.Context, id string) (*Tag, error)
+	Create(ctx context.Context, tag *Tag) (int64, error)
+	Delete(ctx context.Context, id string) (int64, error)
+	DeleteUnused(ctx context.Context) (int64, error)
+	GetUsages(ctx context.Context, q string) ([]TagUsage, error)
+	Merge(ctx context.Context, tags []string, into string, createdBy string) (int64, error)
+	Rename(ctx context.Context, id string, name string) (int64, error)
+}
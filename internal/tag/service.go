@@ -0,0 +1,82 @@
+package tag
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/core-go/core/tx"
+)
+
+type TagService interface {
+	Load(ctx context.Context, id string) (*Tag, error)
+	Create(ctx context.Context, tag *Tag) (int64, error)
+	Delete(ctx context.Context, id string) (int64, error)
+	DeleteUnused(ctx context.Context) (int64, error)
+	GetUsages(ctx context.Context, q string) ([]TagUsage, error)
+	Rename(ctx context.Context, id string, name string) (int64, error)
+	Merge(ctx context.Context, tags []string, into string) (int64, error)
+}
+
+func NewTagService(db *sql.DB, repository TagRepository, userKey string) *TagUseCase {
+	return &TagUseCase{db: db, repository: repository, userKey: userKey}
+}
+
+type TagUseCase struct {
+	db         *sql.DB
+	repository TagRepository
+	userKey    string
+}
+
+func (s *TagUseCase) Load(ctx context.Context, id string) (*Tag, error) {
+	return s.repository.Load(ctx, id)
+}
+func (s *TagUseCase) Create(ctx context.Context, tag *Tag) (int64, error) {
+	now := time.Now()
+	tag.CreatedAt = &now
+	if userId, ok := ctx.Value(s.userKey).(string); ok && len(userId) > 0 {
+		tag.CreatedBy = &userId
+	}
+	return tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
+		res, err := s.repository.Create(ctx, tag)
+		if err != nil || res > 0 {
+			return res, err
+		}
+		return -1, nil
+	})
+}
+func (s *TagUseCase) Delete(ctx context.Context, id string) (int64, error) {
+	return tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
+		return s.repository.Delete(ctx, id)
+	})
+}
+func (s *TagUseCase) DeleteUnused(ctx context.Context) (int64, error) {
+	return tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
+		return s.repository.DeleteUnused(ctx)
+	})
+}
+func (s *TagUseCase) GetUsages(ctx context.Context, q string) ([]TagUsage, error) {
+	return s.repository.GetUsages(ctx, q)
+}
+
+// Rename updates the tag in place, then replaces it in all modules like a merge; a rename to an existing tag is a merge
+func (s *TagUseCase) Rename(ctx context.Context, id string, name string) (int64, error) {
+	userId, _ := ctx.Value(s.userKey).(string)
+	return tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
+		renamed, err := s.repository.Rename(ctx, id, name)
+		if err != nil {
+			return -1, err
+		}
+		res, err := s.repository.Merge(ctx, []string{id}, name, userId)
+		if err != nil || res < 0 {
+			return res, err
+		}
+		return renamed + res, nil
+	})
+}
+func (s *TagUseCase) Merge(ctx context.Context, tags []string, into string) (int64, error) {
+	userId, _ := ctx.Value(s.userKey).(string)
+	return tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
+		return s.repository.Merge(ctx, tags, into, userId)
+	})
+}
@@ -0,0 +1,38 @@
+package tag
+
+import (
+	"context"
+	"time"
+)
+
+type Config struct {
+	Restrict bool `yaml:"restrict" mapstructure:"restrict" json:"restrict,omitempty"`
+}
+
+type Tag struct {
+	Id          string     `json:"id" gorm:"primary_key;column:id" bson:"_id" dynamodbav:"id,omitempty" firestore:"-" validate:"required,max=120"`
+	Description *string    `json:"description,omitempty" gorm:"column:description" bson:"description,omitempty" dynamodbav:"description,omitempty" firestore:"description,omitempty" validate:"omitempty,max=400"`
+	CreatedBy   *string    `json:"createdBy,omitempty" gorm:"column:created_by" bson:"createdBy,omitempty" dynamodbav:"createdBy,omitempty" firestore:"createdBy,omitempty"`
+	CreatedAt   *time.Time `json:"createdAt,omitempty" gorm:"column:created_at" bson:"createdAt,omitempty" dynamodbav:"createdAt,omitempty" firestore:"createdAt,omitempty"`
+}
+
+// TagUsage is a tag with the number of articles, contents and jobs using it; a tag can be used without being registered
+type TagUsage struct {
+	Id         string `json:"id" gorm:"column:id"`
+	Registered bool   `json:"registered" gorm:"column:registered"`
+	Article    int64  `json:"article" gorm:"column:article"`
+	Content    int64  `json:"content" gorm:"column:content"`
+	Job        int64  `json:"job" gorm:"column:job"`
+}
+
+type RenameRequest struct {
+	Name string `json:"name"`
+}
+
+type MergeRequest struct {
+	Tags []string `json:"tags"`
+	Into string   `json:"into"`
+}
+
+// Check returns the tags which are not registered
+type Check func(ctx context.Context, tags []string) ([]string, error)
@@ -0,0 +1,30 @@
+package tag
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/core-go/core"
+	b "github.com/core-go/core/builder"
+	v "github.com/core-go/core/validator"
+)
+
+type TagTransport interface {
+	GetUsages(w http.ResponseWriter, r *http.Request)
+	Load(w http.ResponseWriter, r *http.Request)
+	Create(w http.ResponseWriter, r *http.Request)
+	Rename(w http.ResponseWriter, r *http.Request)
+	Merge(w http.ResponseWriter, r *http.Request)
+	Delete(w http.ResponseWriter, r *http.Request)
+	DeleteUnused(w http.ResponseWriter, r *http.Request)
+}
+
+func NewTagTransport(db *sql.DB, tagRepository TagRepository, logError core.Log, tracking b.TrackingConfig, writeLog core.WriteLog, action *core.ActionConfig) (TagTransport, error) {
+	validator, err := v.NewValidator[*Tag]()
+	if err != nil {
+		return nil, err
+	}
+	tagService := NewTagService(db, tagRepository, tracking.User)
+	tagHandler := NewTagHandler(tagService, logError, validator.Validate, writeLog, action)
+	return tagHandler, nil
+}
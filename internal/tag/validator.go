@@ -0,0 +1,40 @@
+package tag
+
+import (
+	"context"
+	"strings"
+
+	"github.com/core-go/core"
+)
+
+// NewValidator rejects the tags which are not registered; when check is nil, the tags are not restricted
+func NewValidator[T any](check Check, get func(*T) []string, validate core.Validate[*T]) core.Validate[*T] {
+	if check == nil {
+		return validate
+	}
+	return func(ctx context.Context, model *T) ([]core.ErrorMessage, error) {
+		errors, err := validate(ctx, model)
+		if err != nil {
+			return errors, err
+		}
+		unregistered, err := check(ctx, get(model))
+		if err != nil {
+			return errors, err
+		}
+		if len(unregistered) > 0 {
+			errors = append(errors, core.ErrorMessage{Field: "tags", Code: "registered", Param: strings.Join(unregistered, ",")})
+		}
+		return errors, nil
+	}
+}
+
+// Normalize trims the tags and removes the empty ones
+func Normalize(tags []string) []string {
+	var normalized []string
+	for _, t := range tags {
+		if t = strings.TrimSpace(t); len(t) > 0 {
+			normalized = append(normalized, t)
+		}
+	}
+	return normalized
+}
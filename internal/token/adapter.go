@@ -0,0 +1,134 @@
+package token
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"time"
+
+	s "github.com/core-go/sql"
+)
+
+func NewTokenAdapter(db *sql.DB) (*TokenAdapter, error) {
+	parameters, err := s.CreateParameters(reflect.TypeOf(RefreshToken{}), db)
+	if err != nil {
+		return nil, err
+	}
+	userParameters, err := s.CreateParameters(reflect.TypeOf(TokenUser{}), db)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenAdapter{DB: db, Parameters: parameters, userMap: userParameters.Map}, nil
+}
+
+type TokenAdapter struct {
+	DB *sql.DB
+	*s.Parameters
+	userMap map[string]int
+}
+
+func (r *TokenAdapter) Create(ctx context.Context, token *RefreshToken) (int64, error) {
+	query := fmt.Sprintf("insert into refresh_tokens (id, user_id, session_id, token, expires_at, created_at) values (%s, %s, %s, %s, %s, %s)",
+		r.BuildParam(1), r.BuildParam(2), r.BuildParam(3), r.BuildParam(4), r.BuildParam(5), r.BuildParam(6))
+	tx := s.GetTx(ctx, r.DB)
+	res, err := tx.ExecContext(ctx, query, token.Id, token.UserId, token.SessionId, token.Token, token.ExpiresAt, token.CreatedAt)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *TokenAdapter) Load(ctx context.Context, token string) (*RefreshToken, error) {
+	var tokens []RefreshToken
+	query := fmt.Sprintf("select %s from refresh_tokens where token = %s limit 1", r.Fields, r.BuildParam(1))
+	err := s.Query(ctx, r.DB, r.Map, &tokens, query, token)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) > 0 {
+		return &tokens[0], nil
+	}
+	return nil, nil
+}
+
+// Rotate revokes the token only if it is not revoked yet, so that two requests with the same refresh token cannot both get a new one
+func (r *TokenAdapter) Rotate(ctx context.Context, id string, replacedBy string, revokedAt time.Time) (int64, error) {
+	query := fmt.Sprintf("update refresh_tokens set revoked_at = %s, replaced_by = %s where id = %s and revoked_at is null",
+		r.BuildParam(1), r.BuildParam(2), r.BuildParam(3))
+	tx := s.GetTx(ctx, r.DB)
+	res, err := tx.ExecContext(ctx, query, revokedAt, replacedBy, id)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *TokenAdapter) Revoke(ctx context.Context, userId string, token string, revokedAt time.Time) (int64, error) {
+	query := fmt.Sprintf("update refresh_tokens set revoked_at = %s where user_id = %s and token = %s and revoked_at is null",
+		r.BuildParam(1), r.BuildParam(2), r.BuildParam(3))
+	res, err := r.DB.ExecContext(ctx, query, revokedAt, userId, token)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+// RevokeAll joins the transaction of the context if any, so that it can be committed with the change of the password or of the roles
+func (r *TokenAdapter) RevokeAll(ctx context.Context, userId string, revokedAt time.Time) (int64, error) {
+	query := fmt.Sprintf("update refresh_tokens set revoked_at = %s where user_id = %s and revoked_at is null", r.BuildParam(1), r.BuildParam(2))
+	tx := s.GetTx(ctx, r.DB)
+	res, err := tx.ExecContext(ctx, query, revokedAt, userId)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *TokenAdapter) LoadUser(ctx context.Context, userId string) (*TokenUser, error) {
+	var users []TokenUser
+	query := fmt.Sprintf("select user_id, username, email, phone, language, status from users where user_id = %s limit 1", r.BuildParam(1))
+	err := s.Query(ctx, r.DB, r.userMap, &users, query, userId)
+	if err != nil {
+		return nil, err
+	}
+	if len(users) > 0 {
+		return &users[0], nil
+	}
+	return nil, nil
+}
+
+// RevokeAccessToken keeps the hash of the access token until it expires, the expired ones are deleted at the same time
+func (r *TokenAdapter) RevokeAccessToken(ctx context.Context, userId string, token string, expiresAt time.Time) (int64, error) {
+	_, err := r.DB.ExecContext(ctx, fmt.Sprintf("delete from revoked_tokens where expires_at < %s", r.BuildParam(1)), time.Now())
+	if err != nil {
+		return -1, err
+	}
+	query := fmt.Sprintf("insert into revoked_tokens (token, user_id, expires_at) values (%s, %s, %s) on conflict (token) do nothing",
+		r.BuildParam(1), r.BuildParam(2), r.BuildParam(3))
+	res, err := r.DB.ExecContext(ctx, query, token, userId, expiresAt)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *TokenAdapter) RevokeAccessTokens(ctx context.Context, userId string, revokedAt time.Time) (int64, error) {
+	query := fmt.Sprintf(`insert into token_revocations (user_id, revoked_at) values (%s, %s)
+		on conflict (user_id) do update set revoked_at = excluded.revoked_at`, r.BuildParam(1), r.BuildParam(2))
+	res, err := r.DB.ExecContext(ctx, query, userId, revokedAt)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+// IsRevoked compares with the time of the last logout of all devices by second, because the issued time of the token has no fraction of second
+func (r *TokenAdapter) IsRevoked(ctx context.Context, userId string, token string, issuedAt time.Time) (bool, error) {
+	var revoked bool
+	query := fmt.Sprintf(`select exists (select 1 from revoked_tokens where token = %s)
+		or exists (select 1 from token_revocations where user_id = %s and date_trunc('second', revoked_at) >= %s)`,
+		r.BuildParam(1), r.BuildParam(2), r.BuildParam(3))
+	err := r.DB.QueryRowContext(ctx, query, token, userId, issuedAt).Scan(&revoked)
+	return revoked, err
+}
@@ -0,0 +1,49 @@
+package token
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/core-go/core"
+)
+
+type Check func(id string, token string, issuedAt time.Time) string
+
+// Chain returns the reason of the first check which refuses the token
+func Chain(checks ...Check) Check {
+	return func(id string, token string, issuedAt time.Time) string {
+		for _, check := range checks {
+			if reason := check(id, token, issuedAt); len(reason) > 0 {
+				return reason
+			}
+		}
+		return ""
+	}
+}
+
+func NewRevocationChecker(service TokenService, logError core.Log) *RevocationChecker {
+	return &RevocationChecker{service: service, logError: logError}
+}
+
+// RevocationChecker refuses the access tokens after the logout, and when the revocation cannot be checked
+type RevocationChecker struct {
+	service  TokenService
+	logError core.Log
+}
+
+func (c *RevocationChecker) Check(id string, token string, issuedAt time.Time) string {
+	if len(id) == 0 {
+		return ""
+	}
+	ctx := context.Background()
+	revoked, err := c.service.IsRevoked(ctx, id, token, issuedAt)
+	if err != nil {
+		c.logError(ctx, fmt.Sprintf("Error to check the revocation of the token of '%s': %s", id, err.Error()))
+		return "revocation not checked"
+	}
+	if revoked {
+		return "revoked"
+	}
+	return ""
+}
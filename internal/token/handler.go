@@ -0,0 +1,128 @@
+package token
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	auth "github.com/core-go/authentication"
+	"github.com/core-go/core"
+
+	"go-service/internal/session"
+)
+
+type Authenticate func(ctx context.Context, info auth.AuthInfo) (auth.AuthResult, error)
+
+func NewTokenHandler(authenticate Authenticate, service TokenService, status auth.Status, userKey string, logError core.Log, writeLog core.WriteLog) *TokenHandler {
+	if writeLog == nil {
+		writeLog = func(ctx context.Context, resource string, action string, success bool, desc string) error { return nil }
+	}
+	return &TokenHandler{authenticate: authenticate, service: service, status: status, userKey: userKey, Error: logError, Log: writeLog, Resource: "authentication"}
+}
+
+type TokenHandler struct {
+	authenticate Authenticate
+	service      TokenService
+	status       auth.Status
+	userKey      string
+	Error        core.Log
+	Log          core.WriteLog
+	Resource     string
+}
+
+// Authenticate is the login of AuthenticationHandler, which also returns a refresh token when the login succeeds
+func (h *TokenHandler) Authenticate(w http.ResponseWriter, r *http.Request) {
+	var info auth.AuthInfo
+	if err := json.NewDecoder(r.Body).Decode(&info); err != nil {
+		http.Error(w, "cannot decode authentication info", http.StatusBadRequest)
+		return
+	}
+	ctx := context.WithValue(r.Context(), "ip", core.GetRemoteIp(r))
+	result, err := h.authenticate(ctx, info)
+	if err != nil {
+		h.Error(ctx, err.Error())
+		h.Log(ctx, h.Resource, "authenticate", false, err.Error())
+		if result.Status == h.status.Timeout {
+			core.JSON(w, http.StatusGatewayTimeout, "timeout")
+		} else {
+			result.Status = h.status.Error
+			core.JSON(w, http.StatusInternalServerError, result)
+		}
+		return
+	}
+	res := AuthResult{AuthResult: result}
+	if result.User != nil && len(result.User.Id) > 0 && (result.Status == h.status.Success || result.Status == h.status.SuccessAndReactivated) {
+		ctx = context.WithValue(ctx, h.userKey, result.User.Id)
+		tokens, err := h.service.Issue(ctx, *result.User, core.GetRemoteIp(r), r.UserAgent())
+		if err != nil {
+			h.Error(ctx, fmt.Sprintf("Error to issue the refresh token of '%s': %s", result.User.Id, err.Error()))
+			h.Log(ctx, h.Resource, "authenticate", false, err.Error())
+			http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+			return
+		}
+		result.User.Token = tokens.Token
+		result.User.TokenExpiredTime = &tokens.TokenExpiredTime
+		res.RefreshToken = tokens.RefreshToken
+		res.RefreshTokenExpiredTime = &tokens.RefreshTokenExpiredTime
+	}
+	h.Log(ctx, h.Resource, "authenticate", true, "")
+	core.JSON(w, http.StatusOK, res)
+}
+
+func (h *TokenHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	req, err := core.Decode[RefreshRequest](w, r)
+	if err == nil {
+		ctx := context.WithValue(r.Context(), "ip", core.GetRemoteIp(r))
+		result, err := h.service.Refresh(ctx, req.RefreshToken)
+		if err != nil {
+			h.Error(ctx, "Error to refresh the token: "+err.Error())
+			http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+			return
+		}
+		if result == nil {
+			http.Error(w, "invalid refresh token", http.StatusUnauthorized)
+			return
+		}
+		core.JSON(w, http.StatusOK, result)
+	}
+}
+
+// Logout revokes the access token of the request and the refresh token in the body if any
+func (h *TokenHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	var req RefreshRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	ctx := r.Context()
+	userId, _ := ctx.Value(h.userKey).(string)
+	accessToken, _ := ctx.Value("token").(string)
+	issuedAt, _ := ctx.Value("issuedAt").(time.Time)
+	sessionId, _ := ctx.Value(session.Key).(string)
+	if err := h.service.Logout(ctx, userId, sessionId, accessToken, issuedAt, req.RefreshToken); err != nil {
+		h.Error(ctx, fmt.Sprintf("Error to logout '%s': %s", userId, err.Error()))
+		h.Log(ctx, h.Resource, "logout", false, err.Error())
+		http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+		return
+	}
+	h.Log(ctx, h.Resource, "logout", true, "")
+	core.JSON(w, http.StatusOK, true)
+}
+
+// LogoutAll revokes all the refresh tokens of the user and all the access tokens issued before
+func (h *TokenHandler) LogoutAll(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId, _ := ctx.Value(h.userKey).(string)
+	if err := h.service.LogoutAll(ctx, userId); err != nil {
+		h.Error(ctx, fmt.Sprintf("Error to logout all the devices of '%s': %s", userId, err.Error()))
+		h.Log(ctx, h.Resource, "logout-all", false, err.Error())
+		http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+		return
+	}
+	h.Log(ctx, h.Resource, "logout-all", true, "")
+	core.JSON(w, http.StatusOK, true)
+}
@@ -0,0 +1,18 @@
+package token
+
+import (
+	"context"
+	"time"
+)
+
+type TokenRepository interface {
+	Create(ctx context.Context, token *RefreshToken) (int64, error)
+	Load(ctx context.Context, token string) (*RefreshToken, error)
+	Rotate(ctx context.Context, id string, replacedBy string, revokedAt time.Time) (int64, error)
+	Revoke(ctx context.Context, userId string, token string, revokedAt time.Time) (int64, error)
+	RevokeAll(ctx context.Context, userId string, revokedAt time.Time) (int64, error)
+	LoadUser(ctx context.Context, userId string) (*TokenUser, error)
+	RevokeAccessToken(ctx context.Context, userId string, token string, expiresAt time.Time) (int64, error)
+	RevokeAccessTokens(ctx context.Context, userId string, revokedAt time.Time) (int64, error)
+	IsRevoked(ctx context.Context, userId string, token string, issuedAt time.Time) (bool, error)
+}
@@ -0,0 +1,208 @@
+package token
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	auth "github.com/core-go/authentication"
+	"github.com/core-go/core/tx"
+
+	"go-service/internal/session"
+)
+
+type TokenService interface {
+	Issue(ctx context.Context, user auth.UserAccount, ip string, userAgent string) (*TokenResult, error)
+	Refresh(ctx context.Context, refreshToken string) (*TokenResult, error)
+	Logout(ctx context.Context, userId string, sessionId string, accessToken string, issuedAt time.Time, refreshToken string) error
+	LogoutAll(ctx context.Context, userId string) error
+	RevokeAll(ctx context.Context, userId string) (int64, error)
+	IsRevoked(ctx context.Context, userId string, accessToken string, issuedAt time.Time) (bool, error)
+}
+
+func NewTokenService(db *sql.DB, repository TokenRepository, sessions session.SessionService, generateId func(context.Context) (string, error),
+	generateToken func(interface{}, string, int64) (string, error), tokenConfig auth.TokenConfig, payloadConfig auth.PayloadConfig,
+	status auth.UserStatusConfig, conf Config) *TokenUseCase {
+	return &TokenUseCase{db: db, repository: repository, sessions: sessions, generateId: generateId, generateToken: generateToken, tokenConfig: tokenConfig, payloadConfig: payloadConfig, status: status, expires: conf.Expires}
+}
+
+type TokenUseCase struct {
+	db            *sql.DB
+	repository    TokenRepository
+	sessions      session.SessionService
+	generateId    func(context.Context) (string, error)
+	generateToken func(interface{}, string, int64) (string, error)
+	tokenConfig   auth.TokenConfig
+	payloadConfig auth.PayloadConfig
+	status        auth.UserStatusConfig
+	expires       int64
+}
+
+// Issue starts a session for the login, with its refresh token, and issues the access token again with the id of the session
+func (s *TokenUseCase) Issue(ctx context.Context, user auth.UserAccount, ip string, userAgent string) (*TokenResult, error) {
+	u, err := s.repository.LoadUser(ctx, user.Id)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return nil, fmt.Errorf("user '%s' not found", user.Id)
+	}
+	id, err := s.generateId(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var sessionId, refreshToken string
+	var refreshExpiredTime time.Time
+	_, err = tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
+		sessionId, err = s.sessions.Start(ctx, user.Id, ip, userAgent, time.Now().Add(time.Duration(s.expires)*time.Second))
+		if err != nil {
+			return -1, err
+		}
+		refreshToken, refreshExpiredTime, err = s.create(ctx, id, user.Id, sessionId)
+		if err != nil {
+			return -1, err
+		}
+		return 1, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.issue(ctx, u, sessionId, refreshToken, refreshExpiredTime)
+}
+
+// Refresh rotates the refresh token. If a token which was already rotated or revoked is used again, it may have been stolen,
+// so all the refresh tokens of the user are revoked.
+func (s *TokenUseCase) Refresh(ctx context.Context, refreshToken string) (*TokenResult, error) {
+	if len(refreshToken) == 0 {
+		return nil, nil
+	}
+	token, err := s.repository.Load(ctx, Hash(refreshToken))
+	if err != nil || token == nil {
+		return nil, err
+	}
+	var sessionId string
+	if token.SessionId != nil {
+		sessionId = *token.SessionId
+		sess, err := s.sessions.Load(ctx, sessionId)
+		if err != nil || sess == nil || sess.RevokedAt != nil {
+			return nil, err
+		}
+	}
+	now := time.Now()
+	if token.RevokedAt != nil {
+		_, err = s.repository.RevokeAll(ctx, token.UserId, now)
+		return nil, err
+	}
+	if token.ExpiresAt.Before(now) {
+		return nil, nil
+	}
+	user, err := s.repository.LoadUser(ctx, token.UserId)
+	if err != nil || user == nil || user.Status == s.status.Suspended || user.Status == s.status.Disable {
+		return nil, err
+	}
+	id, err := s.generateId(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var refreshed string
+	var refreshExpiredTime time.Time
+	res, err := tx.Execute(ctx, s.db, func(ctx context.Context) (int64, error) {
+		res, err := s.repository.Rotate(ctx, token.Id, id, now)
+		if err != nil || res <= 0 {
+			return res, err
+		}
+		refreshed, refreshExpiredTime, err = s.create(ctx, id, token.UserId, sessionId)
+		if err != nil {
+			return -1, err
+		}
+		if len(sessionId) > 0 {
+			return s.sessions.Touch(ctx, sessionId, &refreshExpiredTime)
+		}
+		return 1, nil
+	})
+	if err != nil || res <= 0 {
+		return nil, err
+	}
+	return s.issue(ctx, user, sessionId, refreshed, refreshExpiredTime)
+}
+
+// Logout revokes the session, the refresh token of the client and the access token of the request, which is kept until it expires
+func (s *TokenUseCase) Logout(ctx context.Context, userId string, sessionId string, accessToken string, issuedAt time.Time, refreshToken string) error {
+	now := time.Now()
+	if len(sessionId) > 0 {
+		if _, err := s.sessions.Revoke(ctx, userId, sessionId); err != nil {
+			return err
+		}
+	}
+	if len(refreshToken) > 0 {
+		if _, err := s.repository.Revoke(ctx, userId, Hash(refreshToken), now); err != nil {
+			return err
+		}
+	}
+	expiresAt := issuedAt.Add(time.Duration(s.tokenConfig.Expires) * time.Millisecond)
+	_, err := s.repository.RevokeAccessToken(ctx, userId, Hash(accessToken), expiresAt)
+	return err
+}
+
+func (s *TokenUseCase) LogoutAll(ctx context.Context, userId string) error {
+	if _, err := s.sessions.RevokeAll(ctx, userId); err != nil {
+		return err
+	}
+	_, err := s.repository.RevokeAccessTokens(ctx, userId, time.Now())
+	return err
+}
+
+func (s *TokenUseCase) RevokeAll(ctx context.Context, userId string) (int64, error) {
+	return s.repository.RevokeAll(ctx, userId, time.Now())
+}
+
+func (s *TokenUseCase) IsRevoked(ctx context.Context, userId string, accessToken string, issuedAt time.Time) (bool, error) {
+	return s.repository.IsRevoked(ctx, userId, Hash(accessToken), issuedAt)
+}
+
+// issue builds the claims from the user like the login, so that the access tokens of the session and of the refresh have the same claims
+func (s *TokenUseCase) issue(ctx context.Context, user *TokenUser, sessionId string, refreshToken string, refreshExpiredTime time.Time) (*TokenResult, error) {
+	payload := auth.ToPayload(ctx, &auth.UserInfo{Id: user.UserId, Username: user.Username, Email: user.Email, Phone: user.Phone, Language: user.Language}, s.payloadConfig)
+	if len(sessionId) > 0 {
+		payload[session.Key] = sessionId
+	}
+	accessToken, err := s.generateToken(payload, s.tokenConfig.Secret, s.tokenConfig.Expires)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenResult{
+		Token:                   accessToken,
+		TokenExpiredTime:        time.Now().Add(time.Duration(s.tokenConfig.Expires) * time.Millisecond),
+		RefreshToken:            refreshToken,
+		RefreshTokenExpiredTime: refreshExpiredTime,
+	}, nil
+}
+
+func (s *TokenUseCase) create(ctx context.Context, id string, userId string, sessionId string) (string, time.Time, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", time.Time{}, err
+	}
+	refreshToken := base64.RawURLEncoding.EncodeToString(b)
+	now := time.Now()
+	expiresAt := now.Add(time.Duration(s.expires) * time.Second)
+	token := &RefreshToken{Id: id, UserId: userId, Token: Hash(refreshToken), ExpiresAt: expiresAt, CreatedAt: now}
+	if len(sessionId) > 0 {
+		token.SessionId = &sessionId
+	}
+	_, err := s.repository.Create(ctx, token)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return refreshToken, expiresAt, nil
+}
+
+func Hash(token string) string {
+	h := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,52 @@
+package token
+
+import (
+	"time"
+
+	auth "github.com/core-go/authentication"
+)
+
+type Config struct {
+	Expires                int64 `yaml:"expires" mapstructure:"expires" json:"expires,omitempty"`
+	RevokeOnPasswordChange bool  `yaml:"revoke_on_password_change" mapstructure:"revoke_on_password_change" json:"revokeOnPasswordChange,omitempty"`
+	RevokeOnRoleChange     bool  `yaml:"revoke_on_role_change" mapstructure:"revoke_on_role_change" json:"revokeOnRoleChange,omitempty"`
+}
+
+type RefreshToken struct {
+	Id         string     `json:"id" gorm:"column:id;primary_key"`
+	UserId     string     `json:"userId" gorm:"column:user_id"`
+	SessionId  *string    `json:"sessionId,omitempty" gorm:"column:session_id"`
+	Token      string     `json:"-" gorm:"column:token"`
+	ExpiresAt  time.Time  `json:"expiresAt" gorm:"column:expires_at"`
+	CreatedAt  time.Time  `json:"createdAt" gorm:"column:created_at"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty" gorm:"column:revoked_at"`
+	ReplacedBy *string    `json:"replacedBy,omitempty" gorm:"column:replaced_by"`
+}
+
+// TokenUser has the fields of the user for the claims of the access token, like the login
+type TokenUser struct {
+	UserId   string  `json:"userId" gorm:"column:user_id;primary_key"`
+	Username string  `json:"username" gorm:"column:username"`
+	Email    *string `json:"email" gorm:"column:email"`
+	Phone    *string `json:"phone" gorm:"column:phone"`
+	Language *string `json:"language" gorm:"column:language"`
+	Status   string  `json:"status" gorm:"column:status"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
+// AuthResult is the result of the login, with the refresh token to get the next access tokens
+type AuthResult struct {
+	auth.AuthResult
+	RefreshToken            string     `json:"refreshToken,omitempty"`
+	RefreshTokenExpiredTime *time.Time `json:"refreshTokenExpiredTime,omitempty"`
+}
+
+type TokenResult struct {
+	Token                   string    `json:"token"`
+	TokenExpiredTime        time.Time `json:"tokenExpiredTime"`
+	RefreshToken            string    `json:"refreshToken"`
+	RefreshTokenExpiredTime time.Time `json:"refreshTokenExpiredTime"`
+}
@@ -0,0 +1,32 @@
+package token
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	auth "github.com/core-go/authentication"
+	as "github.com/core-go/authentication/sql"
+	"github.com/core-go/core"
+
+	"go-service/internal/session"
+)
+
+type TokenTransport interface {
+	Authenticate(w http.ResponseWriter, r *http.Request)
+	Refresh(w http.ResponseWriter, r *http.Request)
+	Logout(w http.ResponseWriter, r *http.Request)
+	LogoutAll(w http.ResponseWriter, r *http.Request)
+}
+
+func NewTokenTransport(db *sql.DB, authenticate Authenticate, sessions session.SessionService, generateId func(context.Context) (string, error),
+	generateToken func(interface{}, string, int64) (string, error), authConfig as.SqlAuthConfig, conf Config, userKey string,
+	logError core.Log, writeLog core.WriteLog) (TokenTransport, TokenService, error) {
+	tokenRepository, err := NewTokenAdapter(db)
+	if err != nil {
+		return nil, nil, err
+	}
+	tokenService := NewTokenService(db, tokenRepository, sessions, generateId, generateToken, authConfig.Token, authConfig.Payload, authConfig.UserStatus, conf)
+	tokenHandler := NewTokenHandler(authenticate, tokenService, auth.InitStatus(authConfig.Status), userKey, logError, writeLog)
+	return tokenHandler, tokenService, nil
+}
@@ -0,0 +1,123 @@
+package twofactor
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"time"
+
+	s "github.com/core-go/sql"
+)
+
+func NewTwoFactorAdapter(db *sql.DB, toArray s.Array) (*TwoFactorAdapter, error) {
+	parameters, err := s.CreateParameters(reflect.TypeOf(TwoFactor{}), db)
+	if err != nil {
+		return nil, err
+	}
+	return &TwoFactorAdapter{DB: db, Parameters: parameters, Array: toArray}, nil
+}
+
+type TwoFactorAdapter struct {
+	DB *sql.DB
+	*s.Parameters
+	Array s.Array
+}
+
+func (r *TwoFactorAdapter) Load(ctx context.Context, userId string) (*TwoFactor, error) {
+	var factors []TwoFactor
+	query := fmt.Sprintf(`select u.user_id, u.username, t.secret, coalesce(t.enabled, false) as enabled, t.recovery_codes, t.last_step, t.fail_count, t.fail_time, t.enabled_at
+		from users u left join user_two_factors t on u.user_id = t.user_id where u.user_id = %s limit 1`, r.BuildParam(1))
+	err := s.QueryWithArray(ctx, r.DB, r.Map, &factors, r.Array, query, userId)
+	if err != nil {
+		return nil, err
+	}
+	if len(factors) > 0 {
+		return &factors[0], nil
+	}
+	return nil, nil
+}
+
+// IsRequired is true if one of the roles of the user requires two-factor authentication
+func (r *TwoFactorAdapter) IsRequired(ctx context.Context, userId string) (bool, error) {
+	var required bool
+	query := fmt.Sprintf(`select exists (select 1 from user_roles ur inner join roles r on ur.role_id = r.role_id
+		where ur.user_id = %s and r.two_factor = true and r.status = 'A')`, r.BuildParam(1))
+	err := r.DB.QueryRowContext(ctx, query, userId).Scan(&required)
+	return required, err
+}
+
+// SaveSecret saves the secret of an enrolment which is not verified yet, it does not replace the secret of an enabled two-factor
+func (r *TwoFactorAdapter) SaveSecret(ctx context.Context, userId string, secret string, createdAt time.Time) (int64, error) {
+	query := fmt.Sprintf(`insert into user_two_factors (user_id, secret, enabled, created_at) values (%s, %s, false, %s)
+		on conflict (user_id) do update set secret = excluded.secret, created_at = excluded.created_at where user_two_factors.enabled = false`,
+		r.BuildParam(1), r.BuildParam(2), r.BuildParam(3))
+	res, err := r.DB.ExecContext(ctx, query, userId, secret, createdAt)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *TwoFactorAdapter) Enable(ctx context.Context, userId string, step int64, recoveryCodes []string, enabledAt time.Time) (int64, error) {
+	query := fmt.Sprintf(`update user_two_factors set enabled = true, last_step = %s, recovery_codes = %s, fail_count = 0, enabled_at = %s
+		where user_id = %s and enabled = false`, r.BuildParam(1), r.BuildParam(2), r.BuildParam(3), r.BuildParam(4))
+	res, err := r.DB.ExecContext(ctx, query, step, r.Array(recoveryCodes), enabledAt, userId)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+// Attempt counts the code as failed before it is checked, and returns 0 if the user is locked, so that parallel codes cannot pass the max failed codes.
+// After the locked time, the failed codes are counted again from 1.
+func (r *TwoFactorAdapter) Attempt(ctx context.Context, userId string, maxFailed int, attemptTime time.Time, lockedSince time.Time) (int64, error) {
+	query := fmt.Sprintf(`update user_two_factors set fail_count = case when coalesce(fail_count, 0) >= %s then 1 else coalesce(fail_count, 0) + 1 end, fail_time = %s
+		where user_id = %s and (coalesce(fail_count, 0) < %s or fail_time is null or fail_time <= %s)`,
+		r.BuildParam(1), r.BuildParam(2), r.BuildParam(3), r.BuildParam(4), r.BuildParam(5))
+	res, err := r.DB.ExecContext(ctx, query, maxFailed, attemptTime, userId, maxFailed, lockedSince)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+// PassStep saves the time step of the code and clears the failed codes, it returns 0 if the step or a later one was already used
+func (r *TwoFactorAdapter) PassStep(ctx context.Context, userId string, step int64) (int64, error) {
+	query := fmt.Sprintf(`update user_two_factors set last_step = %s, fail_count = 0
+		where user_id = %s and enabled = true and (last_step is null or last_step < %s)`, r.BuildParam(1), r.BuildParam(2), r.BuildParam(3))
+	res, err := r.DB.ExecContext(ctx, query, step, userId, step)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+// PassRecoveryCode removes the recovery code and clears the failed codes, it returns 0 if the recovery code is not found or was already used
+func (r *TwoFactorAdapter) PassRecoveryCode(ctx context.Context, userId string, hash string) (int64, error) {
+	query := fmt.Sprintf(`update user_two_factors set recovery_codes = array_remove(recovery_codes, %s::varchar), fail_count = 0
+		where user_id = %s and enabled = true and %s = any(recovery_codes)`, r.BuildParam(1), r.BuildParam(2), r.BuildParam(3))
+	res, err := r.DB.ExecContext(ctx, query, hash, userId, hash)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *TwoFactorAdapter) UpdateRecoveryCodes(ctx context.Context, userId string, recoveryCodes []string) (int64, error) {
+	query := fmt.Sprintf("update user_two_factors set recovery_codes = %s where user_id = %s and enabled = true", r.BuildParam(1), r.BuildParam(2))
+	res, err := r.DB.ExecContext(ctx, query, r.Array(recoveryCodes), userId)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *TwoFactorAdapter) Delete(ctx context.Context, userId string) (int64, error) {
+	query := fmt.Sprintf("delete from user_two_factors where user_id = %s", r.BuildParam(1))
+	res, err := r.DB.ExecContext(ctx, query, userId)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
@@ -0,0 +1,65 @@
+package twofactor
+
+import (
+	"context"
+
+	auth "github.com/core-go/authentication"
+)
+
+type Authenticate func(ctx context.Context, info auth.AuthInfo) (auth.AuthResult, error)
+
+func NewAuthenticator(authenticate Authenticate, service TwoFactorService, status auth.Status) *Authenticator {
+	return &Authenticator{authenticate: authenticate, service: service, status: status}
+}
+
+type Authenticator struct {
+	authenticate Authenticate
+	service      TwoFactorService
+	status       auth.Status
+}
+
+// Authenticate is the two-step login. The first step checks the password and returns TwoFactorRequired if the user enabled the two-factor,
+// or if a role of the user requires it. The client sends the username and the password again with step 1 and the code as passcode.
+// If the two-factor is required but not enrolled yet, the first step enrols a secret and returns the provisioning URI as the message,
+// and the code of the second step verifies it.
+func (a *Authenticator) Authenticate(ctx context.Context, info auth.AuthInfo) (auth.AuthResult, error) {
+	step, passcode := info.Step, info.Passcode
+	info.Step, info.Passcode = 0, ""
+	result, err := a.authenticate(ctx, info)
+	if err != nil || result.User == nil || result.Status != a.status.Success && result.Status != a.status.SuccessAndReactivated {
+		return result, err
+	}
+	userId := result.User.Id
+	factor, required, err := a.service.Required(ctx, userId)
+	if err != nil || factor == nil {
+		return auth.AuthResult{Status: a.status.Error}, err
+	}
+	if !factor.Enabled && !required {
+		return result, nil
+	}
+	if step <= 0 || len(passcode) == 0 {
+		challenge := auth.AuthResult{Status: a.status.TwoFactorRequired}
+		if !factor.Enabled {
+			enrolment, _, err := a.service.Enrol(ctx, userId)
+			if err != nil {
+				return auth.AuthResult{Status: a.status.Error}, err
+			}
+			if enrolment != nil {
+				challenge.Message = enrolment.URI
+			}
+		}
+		return challenge, nil
+	}
+	if factor.Enabled {
+		valid, err := a.service.Check(ctx, userId, passcode)
+		if err != nil || !valid {
+			return auth.AuthResult{Status: a.status.WrongPassword}, err
+		}
+		return result, nil
+	}
+	_, res, err := a.service.Verify(ctx, userId, passcode)
+	if err != nil || res != Success {
+		return auth.AuthResult{Status: a.status.WrongPassword}, err
+	}
+	return result, nil
+}
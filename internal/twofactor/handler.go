@@ -0,0 +1,127 @@
+package twofactor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/core-go/core"
+)
+
+func NewTwoFactorHandler(service TwoFactorService, userKey string, logError core.Log, writeLog core.WriteLog, action *core.ActionConfig) *TwoFactorHandler {
+	attributes := core.CreateAttributes(reflect.TypeOf(TwoFactor{}), logError, writeLog, action)
+	return &TwoFactorHandler{service: service, userKey: userKey, Attributes: attributes}
+}
+
+type TwoFactorHandler struct {
+	service TwoFactorService
+	userKey string
+	*core.Attributes
+}
+
+func (h *TwoFactorHandler) Load(w http.ResponseWriter, r *http.Request) {
+	userId, _ := r.Context().Value(h.userKey).(string)
+	status, err := h.service.Load(r.Context(), userId)
+	if err != nil {
+		h.Error(r.Context(), fmt.Sprintf("Error to get the two-factor of '%s': %s", userId, err.Error()))
+		http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+		return
+	}
+	core.JSON(w, core.IsFound(status), status)
+}
+
+func (h *TwoFactorHandler) Enrol(w http.ResponseWriter, r *http.Request) {
+	userId, _ := r.Context().Value(h.userKey).(string)
+	enrolment, res, err := h.service.Enrol(r.Context(), userId)
+	if err != nil {
+		h.Error(r.Context(), fmt.Sprintf("Error to enrol the two-factor of '%s': %s", userId, err.Error()))
+		h.Log(r.Context(), h.Resource, "enrol", false, err.Error())
+		http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+		return
+	}
+	switch res {
+	case Success:
+		h.Log(r.Context(), h.Resource, "enrol", true, userId)
+		core.JSON(w, http.StatusOK, enrolment)
+	case Conflict:
+		core.JSON(w, http.StatusConflict, res)
+	default:
+		core.JSON(w, http.StatusNotFound, res)
+	}
+}
+
+// Verify returns the recovery codes, which the user must save, because they are not shown again
+func (h *TwoFactorHandler) Verify(w http.ResponseWriter, r *http.Request) {
+	h.recover(w, r, "verify", h.service.Verify)
+}
+
+func (h *TwoFactorHandler) Regenerate(w http.ResponseWriter, r *http.Request) {
+	h.recover(w, r, "regenerate", h.service.Regenerate)
+}
+
+func (h *TwoFactorHandler) Disable(w http.ResponseWriter, r *http.Request) {
+	req, er1 := core.Decode[CodeRequest](w, r)
+	if er1 == nil {
+		userId, _ := r.Context().Value(h.userKey).(string)
+		res, er2 := h.service.Disable(r.Context(), userId, req.Code)
+		if er2 != nil {
+			h.Error(r.Context(), fmt.Sprintf("Error to disable the two-factor of '%s': %s", userId, er2.Error()))
+			h.Log(r.Context(), h.Resource, "disable", false, er2.Error())
+			http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+			return
+		}
+		switch res {
+		case Invalid:
+			core.JSON(w, http.StatusUnprocessableEntity, []core.ErrorMessage{{Field: "code", Code: "invalid"}})
+		case Conflict:
+			core.JSON(w, http.StatusConflict, res)
+		default:
+			h.Log(r.Context(), h.Resource, "disable", true, userId)
+			core.JSON(w, http.StatusOK, res)
+		}
+	}
+}
+
+// Reset is for an admin, to remove the two-factor of a user who lost the phone and the recovery codes
+func (h *TwoFactorHandler) Reset(w http.ResponseWriter, r *http.Request) {
+	id, err := core.GetRequiredString(w, r, 1)
+	if err == nil {
+		res, err := h.service.Reset(r.Context(), id)
+		if err != nil {
+			h.Error(r.Context(), fmt.Sprintf("Error to reset the two-factor of '%s': %s", id, err.Error()))
+			h.Log(r.Context(), h.Resource, "reset", false, err.Error())
+			http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+			return
+		}
+		h.Log(r.Context(), h.Resource, "reset", res > 0, id)
+		if res > 0 {
+			core.JSON(w, http.StatusOK, res)
+		} else {
+			core.JSON(w, http.StatusNotFound, res)
+		}
+	}
+}
+
+func (h *TwoFactorHandler) recover(w http.ResponseWriter, r *http.Request, action string, generate func(ctx context.Context, userId string, code string) ([]string, int64, error)) {
+	req, er1 := core.Decode[CodeRequest](w, r)
+	if er1 == nil {
+		userId, _ := r.Context().Value(h.userKey).(string)
+		codes, res, er2 := generate(r.Context(), userId, req.Code)
+		if er2 != nil {
+			h.Error(r.Context(), fmt.Sprintf("Error to %s the two-factor of '%s': %s", action, userId, er2.Error()))
+			h.Log(r.Context(), h.Resource, action, false, er2.Error())
+			http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+			return
+		}
+		switch res {
+		case Success:
+			h.Log(r.Context(), h.Resource, action, true, userId)
+			core.JSON(w, http.StatusOK, Recovery{RecoveryCodes: codes})
+		case Conflict:
+			core.JSON(w, http.StatusConflict, res)
+		default:
+			core.JSON(w, http.StatusUnprocessableEntity, []core.ErrorMessage{{Field: "code", Code: "invalid"}})
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package twofactor
+
+import (
+	"context"
+	"time"
+)
+
+type TwoFactorRepository interface {
+	Load(ctx context.Context, userId string) (*TwoFactor, error)
+	IsRequired(ctx context.Context, userId string) (bool, error)
+	SaveSecret(ctx context.Context, userId string, secret string, createdAt time.Time) (int64, error)
+	Enable(ctx context.Context, userId string, step int64, recoveryCodes []string, enabledAt time.Time) (int64, error)
+	Attempt(ctx context.Context, userId string, maxFailed int, attemptTime time.Time, lockedSince time.Time) (int64, error)
+	PassStep(ctx context.Context, userId string, step int64) (int64, error)
+	PassRecoveryCode(ctx context.Context, userId string, hash string) (int64, error)
+	UpdateRecoveryCodes(ctx context.Context, userId string, recoveryCodes []string) (int64, error)
+	Delete(ctx context.Context, userId string) (int64, error)
+}
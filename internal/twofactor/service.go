@@ -0,0 +1,202 @@
+package twofactor
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"time"
+
+	"go-service/pkg/totp"
+)
+
+type TwoFactorService interface {
+	Load(ctx context.Context, userId string) (*Status, error)
+	Required(ctx context.Context, userId string) (*TwoFactor, bool, error)
+	Enrol(ctx context.Context, userId string) (*Enrolment, int64, error)
+	Verify(ctx context.Context, userId string, code string) ([]string, int64, error)
+	Check(ctx context.Context, userId string, code string) (bool, error)
+	Regenerate(ctx context.Context, userId string, code string) ([]string, int64, error)
+	Disable(ctx context.Context, userId string, code string) (int64, error)
+	Reset(ctx context.Context, userId string) (int64, error)
+}
+
+func NewTwoFactorService(repository TwoFactorRepository, conf Config) *TwoFactorUseCase {
+	if conf.RecoveryCodes <= 0 {
+		conf.RecoveryCodes = 10
+	}
+	if conf.MaxFailed <= 0 {
+		conf.MaxFailed = 5
+	}
+	if conf.LockedMinutes <= 0 {
+		conf.LockedMinutes = 15
+	}
+	return &TwoFactorUseCase{repository: repository, conf: conf}
+}
+
+type TwoFactorUseCase struct {
+	repository TwoFactorRepository
+	conf       Config
+}
+
+func (s *TwoFactorUseCase) Load(ctx context.Context, userId string) (*Status, error) {
+	factor, required, err := s.Required(ctx, userId)
+	if err != nil || factor == nil {
+		return nil, err
+	}
+	return &Status{Enabled: factor.Enabled, Required: required, RecoveryCodes: len(factor.RecoveryCodes), EnabledAt: factor.EnabledAt}, nil
+}
+
+// Required returns the two-factor of the user, and whether a role of the user requires it
+func (s *TwoFactorUseCase) Required(ctx context.Context, userId string) (*TwoFactor, bool, error) {
+	factor, err := s.repository.Load(ctx, userId)
+	if err != nil || factor == nil {
+		return nil, false, err
+	}
+	required, err := s.repository.IsRequired(ctx, userId)
+	return factor, required, err
+}
+
+// Enrol creates a new secret, which is used only after it is verified with a code, so that a wrong scan does not lock the user out
+func (s *TwoFactorUseCase) Enrol(ctx context.Context, userId string) (*Enrolment, int64, error) {
+	factor, err := s.repository.Load(ctx, userId)
+	if err != nil || factor == nil {
+		return nil, Invalid, err
+	}
+	if factor.Enabled {
+		return nil, Conflict, nil
+	}
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, Invalid, err
+	}
+	res, err := s.repository.SaveSecret(ctx, userId, secret, time.Now())
+	if err != nil || res <= 0 {
+		return nil, Conflict, err
+	}
+	return &Enrolment{Secret: secret, URI: totp.URI(s.conf.Issuer, factor.Username, secret)}, Success, nil
+}
+
+// Verify enables the two-factor with the first code of the enrolled secret, and returns the recovery codes, which are shown only once
+func (s *TwoFactorUseCase) Verify(ctx context.Context, userId string, code string) ([]string, int64, error) {
+	factor, err := s.repository.Load(ctx, userId)
+	if err != nil || factor == nil || factor.Secret == nil {
+		return nil, Invalid, err
+	}
+	if factor.Enabled {
+		return nil, Conflict, nil
+	}
+	if ok, err := s.attempt(ctx, userId); err != nil || !ok {
+		return nil, Invalid, err
+	}
+	step, valid, err := totp.Validate(*factor.Secret, code, time.Now(), s.conf.Skew)
+	if err != nil || !valid {
+		return nil, Invalid, err
+	}
+	codes, hashes, err := s.generateRecoveryCodes()
+	if err != nil {
+		return nil, Invalid, err
+	}
+	res, err := s.repository.Enable(ctx, userId, step, hashes, time.Now())
+	if err != nil || res <= 0 {
+		return nil, Conflict, err
+	}
+	return codes, Success, nil
+}
+
+// Check accepts a code of the authenticator app, which was not used yet, or one of the recovery codes, which is then removed.
+// The code is accepted only if the database still has its step unused or the recovery code, so that a parallel request cannot use it again.
+func (s *TwoFactorUseCase) Check(ctx context.Context, userId string, code string) (bool, error) {
+	factor, err := s.repository.Load(ctx, userId)
+	if err != nil || factor == nil || !factor.Enabled || factor.Secret == nil {
+		return false, err
+	}
+	if ok, err := s.attempt(ctx, userId); err != nil || !ok {
+		return false, err
+	}
+	step, valid, err := totp.Validate(*factor.Secret, code, time.Now(), s.conf.Skew)
+	if err != nil {
+		return false, err
+	}
+	if valid {
+		res, err := s.repository.PassStep(ctx, userId, step)
+		if err != nil || res > 0 {
+			return res > 0, err
+		}
+	}
+	res, err := s.repository.PassRecoveryCode(ctx, userId, hashCode(code))
+	return res > 0, err
+}
+
+func (s *TwoFactorUseCase) Regenerate(ctx context.Context, userId string, code string) ([]string, int64, error) {
+	valid, err := s.Check(ctx, userId, code)
+	if err != nil || !valid {
+		return nil, Invalid, err
+	}
+	codes, hashes, err := s.generateRecoveryCodes()
+	if err != nil {
+		return nil, Invalid, err
+	}
+	res, err := s.repository.UpdateRecoveryCodes(ctx, userId, hashes)
+	if err != nil || res <= 0 {
+		return nil, Invalid, err
+	}
+	return codes, Success, nil
+}
+
+// Disable is refused if a role of the user requires the two-factor
+func (s *TwoFactorUseCase) Disable(ctx context.Context, userId string, code string) (int64, error) {
+	required, err := s.repository.IsRequired(ctx, userId)
+	if err != nil {
+		return Invalid, err
+	}
+	if required {
+		return Conflict, nil
+	}
+	valid, err := s.Check(ctx, userId, code)
+	if err != nil || !valid {
+		return Invalid, err
+	}
+	return s.repository.Delete(ctx, userId)
+}
+
+// Reset is for an admin, when the user lost the phone and the recovery codes. The user enrols again at the next login if a role requires it.
+func (s *TwoFactorUseCase) Reset(ctx context.Context, userId string) (int64, error) {
+	return s.repository.Delete(ctx, userId)
+}
+
+// attempt counts the code as failed until it passes, it returns false if the user is locked
+func (s *TwoFactorUseCase) attempt(ctx context.Context, userId string) (bool, error) {
+	now := time.Now()
+	res, err := s.repository.Attempt(ctx, userId, s.conf.MaxFailed, now, now.Add(-time.Duration(s.conf.LockedMinutes)*time.Minute))
+	return res > 0, err
+}
+
+const alphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+
+func (s *TwoFactorUseCase) generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, s.conf.RecoveryCodes)
+	hashes := make([]string, s.conf.RecoveryCodes)
+	max := big.NewInt(int64(len(alphabet)))
+	for i := range codes {
+		b := make([]byte, 10)
+		for j := range b {
+			n, err := rand.Int(rand.Reader, max)
+			if err != nil {
+				return nil, nil, err
+			}
+			b[j] = alphabet[n.Int64()]
+		}
+		codes[i] = string(b[:5]) + "-" + string(b[5:])
+		hashes[i] = hashCode(codes[i])
+	}
+	return codes, hashes, nil
+}
+
+func hashCode(code string) string {
+	normalized := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	h := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(h[:])
+}
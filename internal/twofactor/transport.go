@@ -0,0 +1,28 @@
+package twofactor
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/core-go/core"
+	"github.com/lib/pq"
+)
+
+type TwoFactorTransport interface {
+	Load(w http.ResponseWriter, r *http.Request)
+	Enrol(w http.ResponseWriter, r *http.Request)
+	Verify(w http.ResponseWriter, r *http.Request)
+	Regenerate(w http.ResponseWriter, r *http.Request)
+	Disable(w http.ResponseWriter, r *http.Request)
+	Reset(w http.ResponseWriter, r *http.Request)
+}
+
+func NewTwoFactorTransport(db *sql.DB, conf Config, userKey string, logError core.Log, writeLog core.WriteLog, action *core.ActionConfig) (TwoFactorTransport, TwoFactorService, error) {
+	twoFactorRepository, err := NewTwoFactorAdapter(db, pq.Array)
+	if err != nil {
+		return nil, nil, err
+	}
+	twoFactorService := NewTwoFactorService(twoFactorRepository, conf)
+	twoFactorHandler := NewTwoFactorHandler(twoFactorService, userKey, logError, writeLog, action)
+	return twoFactorHandler, twoFactorService, nil
+}
@@ -0,0 +1,49 @@
+package twofactor
+
+import "time"
+
+const (
+	Success  = 1
+	Invalid  = 0
+	Conflict = -1
+)
+
+type Config struct {
+	Issuer        string `yaml:"issuer" mapstructure:"issuer" json:"issuer,omitempty"`
+	Skew          int    `yaml:"skew" mapstructure:"skew" json:"skew,omitempty"`
+	RecoveryCodes int    `yaml:"recovery_codes" mapstructure:"recovery_codes" json:"recoveryCodes,omitempty"`
+	MaxFailed     int    `yaml:"max_failed" mapstructure:"max_failed" json:"maxFailed,omitempty"`
+	LockedMinutes int    `yaml:"locked_minutes" mapstructure:"locked_minutes" json:"lockedMinutes,omitempty"`
+}
+
+type TwoFactor struct {
+	UserId        string     `json:"userId" gorm:"column:user_id;primary_key"`
+	Username      string     `json:"username,omitempty" gorm:"column:username"`
+	Secret        *string    `json:"-" gorm:"column:secret"`
+	Enabled       bool       `json:"enabled" gorm:"column:enabled"`
+	RecoveryCodes []string   `json:"-" gorm:"column:recovery_codes"`
+	LastStep      *int64     `json:"-" gorm:"column:last_step"`
+	FailCount     *int       `json:"-" gorm:"column:fail_count"`
+	FailTime      *time.Time `json:"-" gorm:"column:fail_time"`
+	EnabledAt     *time.Time `json:"enabledAt,omitempty" gorm:"column:enabled_at"`
+}
+
+type Status struct {
+	Enabled       bool       `json:"enabled"`
+	Required      bool       `json:"required"`
+	RecoveryCodes int        `json:"recoveryCodes"`
+	EnabledAt     *time.Time `json:"enabledAt,omitempty"`
+}
+
+type Enrolment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+type CodeRequest struct {
+	Code string `json:"code"`
+}
+
+type Recovery struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/core-go/core"
 	"github.com/core-go/core/builder"
 	search "github.com/core-go/search/handler"
+
+	"go-service/pkg/export"
 )
 
 func NewUserHandler(
@@ -16,6 +18,7 @@ func NewUserHandler(
 	logError core.Log,
 	validate core.Validate[*User],
 	tracking builder.TrackingConfig,
+	exporter *export.Exporter[*UserFilter],
 	writeLog core.WriteLog,
 	action *core.ActionConfig,
 ) *UserHandler {
@@ -23,12 +26,13 @@ func NewUserHandler(
 	builder := builder.NewBuilderByConfig[User](nil, tracking)
 	attributes := core.CreateAttributes(userType, logError, writeLog, action)
 	searchHandler := search.NewSearchHandler[User, *UserFilter](find, logError, nil)
-	return &UserHandler{SearchHandler: searchHandler, service: userService, validate: validate, builder: builder, Attributes: attributes}
+	return &UserHandler{SearchHandler: searchHandler, service: userService, validate: validate, builder: builder, Attributes: attributes, Exporter: exporter}
 }
 
 type UserHandler struct {
 	service UserRepository
 	*search.SearchHandler[User, *UserFilter]
+	*export.Exporter[*UserFilter]
 	*core.Attributes
 	validate core.Validate[*User]
 	builder  core.Builder[User]
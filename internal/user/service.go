@@ -1,6 +1,9 @@
 package user
 
-import "context"
+import (
+	"context"
+	"reflect"
+)
 
 type UserService interface {
 	Load(ctx context.Context, id string) (*User, error)
@@ -11,12 +14,14 @@ type UserService interface {
 	GetUserByRole(ctx context.Context, roleId string) ([]User, error)
 }
 
-func NewUserService(repository UserRepository) UserService {
-	return &UserUseCase{repository: repository}
+// NewUserService revokes the refresh tokens of the user when the roles change, if revoke is not nil
+func NewUserService(repository UserRepository, revoke func(context.Context, string) (int64, error)) UserService {
+	return &UserUseCase{repository: repository, revoke: revoke}
 }
 
 type UserUseCase struct {
 	repository UserRepository
+	revoke     func(context.Context, string) (int64, error)
 }
 
 func (s *UserUseCase) Load(ctx context.Context, id string) (*User, error) {
@@ -26,14 +31,49 @@ func (s *UserUseCase) Create(ctx context.Context, user *User) (int64, error) {
 	return s.repository.Create(ctx, user)
 }
 func (s *UserUseCase) Update(ctx context.Context, user *User) (int64, error) {
-	return s.repository.Update(ctx, user)
+	if s.revoke == nil {
+		return s.repository.Update(ctx, user)
+	}
+	existing, err := s.repository.Load(ctx, user.UserId)
+	if err != nil {
+		return -1, err
+	}
+	res, err := s.repository.Update(ctx, user)
+	if err != nil || res <= 0 || existing == nil || sameRoles(existing.Roles, user.Roles) {
+		return res, err
+	}
+	_, err = s.revoke(ctx, user.UserId)
+	return res, err
 }
 func (s *UserUseCase) Patch(ctx context.Context, user map[string]interface{}) (int64, error) {
-	return s.repository.Patch(ctx, user)
+	res, err := s.repository.Patch(ctx, user)
+	if err != nil || res <= 0 || s.revoke == nil {
+		return res, err
+	}
+	if _, ok := user["roles"]; ok {
+		userId, _ := user["userId"].(string)
+		_, err = s.revoke(ctx, userId)
+	}
+	return res, err
 }
 func (s *UserUseCase) Delete(ctx context.Context, id string) (int64, error) {
 	return s.repository.Delete(ctx, id)
 }
 func (s *UserUseCase) GetUserByRole(ctx context.Context, roleId string) ([]User, error) {
 	return s.repository.GetUserByRole(ctx, roleId)
-}
\ No newline at end of file
+}
+
+func sameRoles(a []string, b []string) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	x := make(map[string]bool)
+	for _, role := range a {
+		x[role] = true
+	}
+	y := make(map[string]bool)
+	for _, role := range b {
+		y[role] = true
+	}
+	return reflect.DeepEqual(x, y)
+}
@@ -1,6 +1,7 @@
 package user
 
 import (
+	"context"
 	"database/sql"
 	"github.com/core-go/core/unique"
 	"net/http"
@@ -9,11 +10,14 @@ import (
 	"github.com/core-go/core"
 	"github.com/core-go/core/builder"
 	v "github.com/core-go/core/validator"
+	"github.com/core-go/search"
 	"github.com/core-go/search/convert"
 	q "github.com/core-go/sql"
 	"github.com/core-go/sql/query"
 	"github.com/core-go/sql/template"
 	tb "github.com/core-go/sql/template/builder"
+
+	"go-service/pkg/export"
 )
 
 type UserTransport interface {
@@ -23,10 +27,11 @@ type UserTransport interface {
 	Update(w http.ResponseWriter, r *http.Request)
 	Patch(w http.ResponseWriter, r *http.Request)
 	Delete(w http.ResponseWriter, r *http.Request)
+	Export(w http.ResponseWriter, r *http.Request)
 	GetUserByRole(w http.ResponseWriter, r *http.Request)
 }
 
-func NewUserTransport(db *sql.DB, logError core.Log, templates map[string]*template.Template, tracking builder.TrackingConfig, writeLog core.WriteLog, action *core.ActionConfig) (UserTransport, error) {
+func NewUserTransport(db *sql.DB, logError core.Log, templates map[string]*template.Template, tracking builder.TrackingConfig, revoke func(context.Context, string) (int64, error), writeLog core.WriteLog, action *core.ActionConfig) (UserTransport, error) {
 	validator, err := v.NewValidator[*User]()
 	if err != nil {
 		return nil, err
@@ -50,7 +55,8 @@ func NewUserTransport(db *sql.DB, logError core.Log, templates map[string]*templ
 	if er7 != nil {
 		return nil, er7
 	}
-	userService := NewUserService(userRepository)
-	userHandler := NewUserHandler(userSearchBuilder.Search, userService, logError, userValidator.Validate, tracking, writeLog, action)
+	userService := NewUserService(userRepository, revoke)
+	userExporter := export.NewExporter[User, *UserFilter](db, "user", func() *UserFilter { return &UserFilter{Filter: &search.Filter{}} }, queryUser, logError, writeLog)
+	userHandler := NewUserHandler(userSearchBuilder.Search, userService, logError, userValidator.Validate, tracking, userExporter, writeLog, action)
 	return userHandler, nil
 }
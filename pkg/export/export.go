@@ -0,0 +1,289 @@
+package export
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/core-go/core"
+	"github.com/core-go/search"
+)
+
+const (
+	CSV             = "csv"
+	XLSX            = "xlsx"
+	ContentTypeCSV  = "text/csv"
+	ContentTypeXLSX = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	flushSize       = 200
+)
+
+// Format returns the format of the export, from the parameter format or the Accept header, or "" if the client asks for JSON
+func Format(r *http.Request) string {
+	switch strings.ToLower(r.URL.Query().Get("format")) {
+	case CSV:
+		return CSV
+	case XLSX:
+		return XLSX
+	}
+	accept := r.Header.Get("Accept")
+	if strings.Contains(accept, ContentTypeXLSX) {
+		return XLSX
+	}
+	if strings.Contains(accept, ContentTypeCSV) {
+		return CSV
+	}
+	return ""
+}
+
+// Or serves the export if the client accepts CSV or XLSX, else the search
+func Or(search http.HandlerFunc, export http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if len(Format(r)) > 0 {
+			export(w, r)
+		} else {
+			search(w, r)
+		}
+	}
+}
+
+type Column struct {
+	Json   string
+	Column string
+}
+
+// Columns maps the json names of the model to the columns, in the order of the fields
+func Columns(modelType reflect.Type) []Column {
+	var columns []Column
+	for i := 0; i < modelType.NumField(); i++ {
+		field := modelType.Field(i)
+		tag := field.Tag.Get("gorm")
+		column := ""
+		for _, s := range strings.Split(tag, ";") {
+			if strings.HasPrefix(s, "column:") {
+				column = strings.TrimPrefix(s, "column:")
+			}
+		}
+		if len(column) == 0 {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if len(name) == 0 || name == "-" {
+			continue
+		}
+		columns = append(columns, Column{Json: name, Column: strings.ToLower(column)})
+	}
+	return columns
+}
+
+// NewExporter exports the rows of the search query of a module, with the same filter as the search
+func NewExporter[T any, F any](db *sql.DB, resource string, newFilter func() F, buildQuery func(F) (string, []interface{}), logError core.Log, writeLog core.WriteLog) *Exporter[F] {
+	filterType := reflect.TypeOf(newFilter())
+	if filterType.Kind() == reflect.Ptr {
+		filterType = filterType.Elem()
+	}
+	paramIndex, filterIndex := search.BuildAttributes(filterType)
+	columns := Columns(reflect.TypeOf((*T)(nil)).Elem())
+	return &Exporter[F]{db: db, resource: resource, columns: columns, newFilter: newFilter, buildQuery: buildQuery, paramIndex: paramIndex, filterIndex: filterIndex, logError: logError, writeLog: writeLog}
+}
+
+type Exporter[F any] struct {
+	db          *sql.DB
+	resource    string
+	columns     []Column
+	newFilter   func() F
+	buildQuery  func(F) (string, []interface{})
+	paramIndex  map[string]int
+	filterIndex int
+	logError    core.Log
+	writeLog    core.WriteLog
+}
+
+// Export streams the rows as CSV, or as XLSX. The parameter fields chooses and orders the columns by their json names.
+func (e *Exporter[F]) Export(w http.ResponseWriter, r *http.Request) {
+	format := Format(r)
+	if len(format) == 0 {
+		format = CSV
+	}
+	filter := e.newFilter()
+	if err := search.Decode(r, filter, e.paramIndex, e.filterIndex); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var fields []string
+	if f := search.GetFilter(filter); f != nil {
+		fields = f.Fields
+		// the query selects all columns, because the columns of the export are picked from the rows
+		f.Fields = nil
+		f.Limit = 0
+	}
+	columns, unknown := e.choose(fields)
+	if len(unknown) > 0 {
+		core.JSON(w, http.StatusUnprocessableEntity, []core.ErrorMessage{{Field: "fields", Code: "field", Param: unknown}})
+		return
+	}
+	ctx := r.Context()
+	desc := e.describe(format, fields, filter)
+	query, params := e.buildQuery(filter)
+	rows, err := e.db.QueryContext(ctx, query, params...)
+	if err != nil {
+		e.fail(ctx, desc, err)
+		http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+	names, err := rows.Columns()
+	if err != nil {
+		e.fail(ctx, desc, err)
+		http.Error(w, core.InternalServerError, http.StatusInternalServerError)
+		return
+	}
+	indexes := make([]int, len(columns))
+	for i, column := range columns {
+		indexes[i] = -1
+		for j, name := range names {
+			if strings.ToLower(name) == column.Column {
+				indexes[i] = j
+				break
+			}
+		}
+	}
+
+	fileName := fmt.Sprintf("%s-%s.%s", e.resource, time.Now().Format("20060102-150405"), format)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+	var writer Writer
+	if format == XLSX {
+		w.Header().Set("Content-Type", ContentTypeXLSX)
+		writer, err = NewXLSXWriter(w, e.resource)
+		if err != nil {
+			e.fail(ctx, desc, err)
+			return
+		}
+	} else {
+		w.Header().Set("Content-Type", ContentTypeCSV+"; charset=utf-8")
+		writer = NewCSVWriter(w)
+	}
+	flusher, _ := w.(http.Flusher)
+	header := make([]interface{}, len(columns))
+	for i, column := range columns {
+		header[i] = column.Json
+	}
+	if err = writer.Write(header); err != nil {
+		e.fail(ctx, desc, err)
+		return
+	}
+	values := make([]interface{}, len(names))
+	pointers := make([]interface{}, len(names))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	record := make([]interface{}, len(columns))
+	var count int64
+	for rows.Next() {
+		if err = rows.Scan(pointers...); err != nil {
+			e.fail(ctx, desc, err)
+			return
+		}
+		for i, index := range indexes {
+			if index < 0 {
+				record[i] = nil
+			} else {
+				record[i] = normalize(values[index])
+			}
+		}
+		if err = writer.Write(record); err != nil {
+			e.fail(ctx, desc, err)
+			return
+		}
+		count++
+		if csvWriter, ok := writer.(*CSVWriter); ok && flusher != nil && count%flushSize == 0 {
+			csvWriter.writer.Flush()
+			flusher.Flush()
+		}
+	}
+	if err = rows.Err(); err != nil {
+		e.fail(ctx, desc, err)
+		return
+	}
+	if err = writer.Close(); err != nil {
+		e.fail(ctx, desc, err)
+		return
+	}
+	if e.writeLog != nil {
+		e.writeLog(ctx, e.resource, "export", true, fmt.Sprintf("export %d rows %s", count, desc))
+	}
+}
+
+func (e *Exporter[F]) choose(fields []string) ([]Column, string) {
+	if len(fields) == 0 {
+		return e.columns, ""
+	}
+	var columns []Column
+	for _, field := range fields {
+		field = strings.TrimSpace(field)
+		found := false
+		for _, column := range e.columns {
+			if column.Json == field {
+				columns = append(columns, column)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, field
+		}
+	}
+	return columns, ""
+}
+
+func (e *Exporter[F]) describe(format string, fields []string, filter F) string {
+	data, _ := json.Marshal(filter)
+	return fmt.Sprintf("as %s with fields [%s] and filter %s", format, strings.Join(fields, ","), string(data))
+}
+
+// fail logs the error. When the rows have been written partly, the status cannot be changed, so the client gets a truncated file.
+func (e *Exporter[F]) fail(ctx context.Context, desc string, err error) {
+	e.logError(ctx, fmt.Sprintf("Error to export %s %s: %s", e.resource, desc, err.Error()))
+	if e.writeLog != nil {
+		e.writeLog(ctx, e.resource, "export", false, err.Error())
+	}
+}
+
+func normalize(v interface{}) interface{} {
+	switch n := v.(type) {
+	case []byte:
+		return string(n)
+	case int32:
+		return int64(n)
+	case int:
+		return int64(n)
+	case float32:
+		return float64(n)
+	}
+	return v
+}
+
+func ToString(v interface{}) string {
+	switch n := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return n
+	case []byte:
+		return string(n)
+	case time.Time:
+		return n.Format(time.RFC3339)
+	case int64:
+		return strconv.FormatInt(n, 10)
+	case float64:
+		return strconv.FormatFloat(n, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(n)
+	}
+	return fmt.Sprint(v)
+}
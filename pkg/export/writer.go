@@ -0,0 +1,130 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+type Writer interface {
+	Write(values []interface{}) error
+	Close() error
+}
+
+func NewCSVWriter(w io.Writer) *CSVWriter {
+	return &CSVWriter{writer: csv.NewWriter(w)}
+}
+
+type CSVWriter struct {
+	writer *csv.Writer
+	record []string
+}
+
+func (c *CSVWriter) Write(values []interface{}) error {
+	c.record = c.record[:0]
+	for _, v := range values {
+		c.record = append(c.record, escapeFormula(ToString(v)))
+	}
+	return c.writer.Write(c.record)
+}
+func (c *CSVWriter) Close() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+// escapeFormula prefixes a cell, which a spreadsheet would run as a formula, with a quote; the numbers are kept as they are
+func escapeFormula(s string) string {
+	if len(s) == 0 || !strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return s
+	}
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return s
+	}
+	return "'" + s
+}
+
+const (
+	contentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/></Types>`
+	rootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`
+	workbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets></workbook>`
+	workbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/></Relationships>`
+	sheetStart = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	sheetEnd = `</sheetData></worksheet>`
+)
+
+// NewXLSXWriter writes a workbook with one sheet. The sheet is the last entry of the zip, so the rows are streamed without being kept in memory.
+func NewXLSXWriter(w io.Writer, sheet string) (*XLSXWriter, error) {
+	z := zip.NewWriter(w)
+	files := []struct{ name, content string }{
+		{"[Content_Types].xml", contentTypes},
+		{"_rels/.rels", rootRels},
+		{"xl/workbook.xml", fmt.Sprintf(workbook, escape(sheet))},
+		{"xl/_rels/workbook.xml.rels", workbookRels},
+	}
+	for _, f := range files {
+		fw, err := z.Create(f.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = io.WriteString(fw, f.content); err != nil {
+			return nil, err
+		}
+	}
+	fw, err := z.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	b := bufio.NewWriter(fw)
+	if _, err = b.WriteString(sheetStart); err != nil {
+		return nil, err
+	}
+	return &XLSXWriter{zip: z, writer: b}, nil
+}
+
+type XLSXWriter struct {
+	zip    *zip.Writer
+	writer *bufio.Writer
+}
+
+func (x *XLSXWriter) Write(values []interface{}) error {
+	x.writer.WriteString("<row>")
+	for _, v := range values {
+		switch n := v.(type) {
+		case nil:
+			x.writer.WriteString("<c/>")
+		case int64:
+			x.writer.WriteString(`<c t="n"><v>` + strconv.FormatInt(n, 10) + `</v></c>`)
+		case float64:
+			x.writer.WriteString(`<c t="n"><v>` + strconv.FormatFloat(n, 'f', -1, 64) + `</v></c>`)
+		default:
+			x.writer.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">` + escape(ToString(v)) + `</t></is></c>`)
+		}
+	}
+	_, err := x.writer.WriteString("</row>")
+	return err
+}
+func (x *XLSXWriter) Close() error {
+	if _, err := x.writer.WriteString(sheetEnd); err != nil {
+		return err
+	}
+	if err := x.writer.Flush(); err != nil {
+		return err
+	}
+	return x.zip.Close()
+}
+
+func escape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,21 @@
+package export
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCSVWriterEscapesFormulas(t *testing.T) {
+	var b strings.Builder
+	w := NewCSVWriter(&b)
+	if err := w.Write([]interface{}{"=HYPERLINK(\"http://x\")", "+1+cmd", "-2+3", "@SUM(A1)", "\tx", "title", -5, "-1.5", ""}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	expected := "\"'=HYPERLINK(\"\"http://x\"\")\",'+1+cmd,'-2+3,'@SUM(A1),'\tx,title,-5,-1.5,\n"
+	if b.String() != expected {
+		t.Errorf("got %q, expected %q", b.String(), expected)
+	}
+}
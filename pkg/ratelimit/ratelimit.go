@@ -0,0 +1,57 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+type Config struct {
+	Limit  int   `yaml:"limit" mapstructure:"limit" json:"limit,omitempty"`
+	Window int64 `yaml:"window" mapstructure:"window" json:"window,omitempty"`
+}
+
+type counter struct {
+	count int
+	start time.Time
+}
+
+// Limiter counts the requests of each key, such as the client IP, in a fixed window of seconds. It is kept in memory, so the limit is per instance.
+func NewLimiter(conf Config) *Limiter {
+	return &Limiter{limit: conf.Limit, window: time.Duration(conf.Window) * time.Second, counters: make(map[string]*counter)}
+}
+
+type Limiter struct {
+	limit    int
+	window   time.Duration
+	mu       sync.Mutex
+	counters map[string]*counter
+	cleaned  time.Time
+}
+
+// Allow counts the request, and returns false with the time to wait if the key has reached the limit. A limiter without limit allows everything.
+func (l *Limiter) Allow(key string) (bool, time.Duration) {
+	if l == nil || l.limit <= 0 || l.window <= 0 {
+		return true, 0
+	}
+	now := time.Now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if now.Sub(l.cleaned) > l.window {
+		for k, c := range l.counters {
+			if now.Sub(c.start) >= l.window {
+				delete(l.counters, k)
+			}
+		}
+		l.cleaned = now
+	}
+	c, ok := l.counters[key]
+	if !ok || now.Sub(c.start) >= l.window {
+		l.counters[key] = &counter{count: 1, start: now}
+		return true, 0
+	}
+	if c.count >= l.limit {
+		return false, c.start.Add(l.window).Sub(now)
+	}
+	c.count++
+	return true, 0
+}
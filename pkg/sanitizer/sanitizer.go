@@ -0,0 +1,156 @@
+package sanitizer
+
+import (
+	"io"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+type Config struct {
+	Strict     bool     `yaml:"strict" mapstructure:"strict" json:"strict,omitempty"`
+	Tags       []string `yaml:"tags" mapstructure:"tags" json:"tags,omitempty"`
+	Attributes []string `yaml:"attributes" mapstructure:"attributes" json:"attributes,omitempty"`
+	Protocols  []string `yaml:"protocols" mapstructure:"protocols" json:"protocols,omitempty"`
+}
+
+type Removed struct {
+	Tag       string `json:"tag"`
+	Attribute string `json:"attribute,omitempty"`
+	Count     int    `json:"count"`
+}
+
+// the content of these tags is removed together with the tag, unless the tag is allowed;
+// the RCDATA and RAWTEXT tags are here too, because their content is tokenized as text and may contain markup
+var dropContent = map[string]bool{"script": true, "style": true, "iframe": true, "object": true, "embed": true, "noscript": true, "template": true,
+	"textarea": true, "title": true, "xmp": true, "noembed": true, "noframes": true, "plaintext": true}
+
+var urlAttributes = map[string]bool{"href": true, "src": true, "action": true, "formaction": true, "poster": true, "cite": true, "background": true, "xlink:href": true}
+
+func NewSanitizer(conf Config) *Sanitizer {
+	return &Sanitizer{
+		Strict:     conf.Strict,
+		tags:       toSet(conf.Tags),
+		attributes: toSet(conf.Attributes),
+		protocols:  toSet(conf.Protocols),
+	}
+}
+
+type Sanitizer struct {
+	Strict     bool
+	tags       map[string]bool
+	attributes map[string]bool
+	protocols  map[string]bool
+}
+
+// Sanitize keeps the allowed tags and attributes, and returns what was removed. Tags which are kept are written as they were submitted,
+// the text is escaped again so that it cannot become markup.
+func (s *Sanitizer) Sanitize(text string) (string, []Removed) {
+	var b strings.Builder
+	report := &report{}
+	z := html.NewTokenizer(strings.NewReader(text))
+	skip := ""
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			if z.Err() == io.EOF {
+				break
+			}
+			return b.String(), report.items
+		}
+		raw := string(z.Raw())
+		token := z.Token()
+		if len(skip) > 0 {
+			if tt == html.EndTagToken && token.Data == skip {
+				skip = ""
+			}
+			continue
+		}
+		switch tt {
+		case html.TextToken:
+			b.WriteString(html.EscapeString(token.Data))
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if !s.tags[token.Data] {
+				report.add(token.Data, "")
+				if tt == html.StartTagToken && dropContent[token.Data] {
+					skip = token.Data
+				}
+				continue
+			}
+			attrs := make([]html.Attribute, 0, len(token.Attr))
+			for _, attr := range token.Attr {
+				if s.isAllowed(attr) {
+					attrs = append(attrs, attr)
+				} else {
+					report.add(token.Data, attr.Key)
+				}
+			}
+			if len(attrs) == len(token.Attr) {
+				b.WriteString(raw)
+			} else {
+				token.Attr = attrs
+				b.WriteString(token.String())
+			}
+		case html.EndTagToken:
+			if s.tags[token.Data] {
+				b.WriteString(raw)
+			}
+		case html.CommentToken:
+			report.add("!--", "")
+		case html.DoctypeToken:
+			report.add("!doctype", "")
+		}
+	}
+	return b.String(), report.items
+}
+
+func (s *Sanitizer) isAllowed(attr html.Attribute) bool {
+	key := attr.Key
+	if len(attr.Namespace) > 0 {
+		key = attr.Namespace + ":" + key
+	}
+	if strings.HasPrefix(key, "on") || !s.attributes[key] {
+		return false
+	}
+	if urlAttributes[key] {
+		return s.isAllowedUrl(attr.Val)
+	}
+	return true
+}
+
+func (s *Sanitizer) isAllowedUrl(url string) bool {
+	// browsers ignore control characters and spaces inside the scheme, such as "java\tscript:"
+	url = strings.Map(func(r rune) rune {
+		if r <= ' ' {
+			return -1
+		}
+		return r
+	}, url)
+	i := strings.IndexAny(url, ":/?#")
+	if i < 0 || url[i] != ':' {
+		return true
+	}
+	return s.protocols[strings.ToLower(url[:i])]
+}
+
+type report struct {
+	items []Removed
+}
+
+func (r *report) add(tag string, attribute string) {
+	for i := range r.items {
+		if r.items[i].Tag == tag && r.items[i].Attribute == attribute {
+			r.items[i].Count++
+			return
+		}
+	}
+	r.items = append(r.items, Removed{Tag: tag, Attribute: attribute, Count: 1})
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[strings.ToLower(strings.TrimSpace(v))] = true
+	}
+	return set
+}
@@ -0,0 +1,38 @@
+package sanitizer
+
+import "testing"
+
+func TestSanitize(t *testing.T) {
+	s := NewSanitizer(Config{
+		Tags:       []string{"p", "b", "a", "img"},
+		Attributes: []string{"href", "src", "alt"},
+		Protocols:  []string{"http", "https", "mailto"},
+	})
+	tests := []struct {
+		name string
+		in   string
+		out  string
+	}{
+		{"allowed", `<p>Hello <b>world</b></p>`, `<p>Hello <b>world</b></p>`},
+		{"text entities", `<p>a &amp; b &lt; c</p>`, `<p>a &amp; b &lt; c</p>`},
+		{"script", `<p>x</p><script>alert(1)</script>`, `<p>x</p>`},
+		{"event attribute", `<img src="a.png" onerror="alert(1)">`, `<img src="a.png">`},
+		{"javascript url", `<a href="java	script:alert(1)">x</a>`, `<a>x</a>`},
+		{"textarea", `<textarea><img src=x onerror=alert(1)></textarea>`, ``},
+		{"title", `<title><img src=x onerror=alert(1)></title>`, ``},
+		{"xmp", `<xmp><img src=x onerror=alert(1)></xmp>`, ``},
+		{"noembed", `<noembed><img src=x onerror=alert(1)></noembed>`, ``},
+		{"noframes", `<noframes><img src=x onerror=alert(1)></noframes>`, ``},
+		{"plaintext", `<plaintext><img src=x onerror=alert(1)>`, ``},
+		{"escaped markup in text", `<p>&lt;img src=x onerror=alert(1)&gt;</p>`, `<p>&lt;img src=x onerror=alert(1)&gt;</p>`},
+		{"unknown tag keeps text", `<div>hi</div>`, `hi`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			out, _ := s.Sanitize(tc.in)
+			if out != tc.out {
+				t.Errorf("Sanitize(%q) = %q, want %q", tc.in, out, tc.out)
+			}
+		})
+	}
+}
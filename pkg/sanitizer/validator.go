@@ -0,0 +1,46 @@
+package sanitizer
+
+import (
+	"context"
+	"strings"
+
+	"github.com/core-go/core"
+)
+
+// NewValidator sanitizes the html field before validating the model.
+// In strict mode, the html is not changed and the save is rejected with a validation error instead.
+func NewValidator[T any](sanitizer *Sanitizer, field string, get func(*T) (*string, *[]Removed), validate core.Validate[*T]) core.Validate[*T] {
+	return func(ctx context.Context, model *T) ([]core.ErrorMessage, error) {
+		text, report := get(model)
+		*report = nil
+		var errors []core.ErrorMessage
+		if text != nil && len(*text) > 0 {
+			sanitized, removed := sanitizer.Sanitize(*text)
+			if len(removed) > 0 {
+				if sanitizer.Strict {
+					errors = append(errors, core.ErrorMessage{Field: field, Code: "html", Param: Join(removed)})
+				} else {
+					*text = sanitized
+					*report = removed
+				}
+			}
+		}
+		errs, err := validate(ctx, model)
+		if err != nil {
+			return errs, err
+		}
+		return append(errs, errors...), nil
+	}
+}
+
+func Join(removed []Removed) string {
+	names := make([]string, len(removed))
+	for i, r := range removed {
+		if len(r.Attribute) > 0 {
+			names[i] = r.Tag + "@" + r.Attribute
+		} else {
+			names[i] = r.Tag
+		}
+	}
+	return strings.Join(names, ",")
+}
@@ -0,0 +1,68 @@
+package sender
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/core-go/core"
+)
+
+const (
+	TypeLog  = "log"
+	TypeFile = "file"
+)
+
+type Config struct {
+	Type string `yaml:"type" mapstructure:"type" json:"type,omitempty"`
+	File string `yaml:"file" mapstructure:"file" json:"file,omitempty"`
+}
+
+// Send delivers a message to an email address. The log and file senders stand in for a mail server.
+type Send func(ctx context.Context, to string, subject string, body string) error
+
+func NewSender(conf Config, logInfo core.Log) Send {
+	if conf.Type == TypeFile && len(conf.File) > 0 {
+		return NewFileSender(conf.File).Send
+	}
+	return NewLogSender(logInfo).Send
+}
+
+func NewLogSender(logInfo core.Log) *LogSender {
+	return &LogSender{logInfo: logInfo}
+}
+
+type LogSender struct {
+	logInfo core.Log
+}
+
+func (s *LogSender) Send(ctx context.Context, to string, subject string, body string) error {
+	s.logInfo(ctx, fmt.Sprintf("send '%s' to '%s': %s", subject, to, body))
+	return nil
+}
+
+func NewFileSender(file string) *FileSender {
+	return &FileSender{file: file}
+}
+
+// FileSender appends the messages to a file, so that they can be read in development
+type FileSender struct {
+	file string
+	mu   sync.Mutex
+}
+
+func (s *FileSender) Send(ctx context.Context, to string, subject string, body string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := os.OpenFile(s.file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(f, "%s\nTo: %s\nSubject: %s\n\n%s\n\n", time.Now().Format(time.RFC3339), to, subject, body)
+	if er2 := f.Close(); err == nil {
+		err = er2
+	}
+	return err
+}
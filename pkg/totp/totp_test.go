@@ -0,0 +1,62 @@
+package totp
+
+import (
+	"testing"
+	"time"
+)
+
+// the secret of the SHA1 test vectors of RFC 6238, which is "12345678901234567890" in base32
+const secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// the codes of RFC 6238 have 8 digits, these are their last 6 digits
+var vectors = []struct {
+	time int64
+	code string
+}{
+	{59, "287082"},
+	{1111111109, "081804"},
+	{1111111111, "050471"},
+	{1234567890, "005924"},
+	{2000000000, "279037"},
+	{20000000000, "353130"},
+}
+
+func TestCode(t *testing.T) {
+	for _, v := range vectors {
+		code, err := Code(secret, Step(time.Unix(v.time, 0)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if code != v.code {
+			t.Errorf("time %d: expected %s, got %s", v.time, v.code, code)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	at := time.Unix(1111111111, 0)
+	step, valid, err := Validate(secret, "050471", at, 1)
+	if err != nil || !valid || step != Step(at) {
+		t.Errorf("expected step %d, got %d %v %v", Step(at), step, valid, err)
+	}
+	step, valid, _ = Validate(secret, "081804", at, 1)
+	if !valid || step != Step(at)-1 {
+		t.Errorf("expected the previous step %d, got %d %v", Step(at)-1, step, valid)
+	}
+	if _, valid, _ = Validate(secret, "081804", at, 0); valid {
+		t.Error("expected the code of the previous step to be refused without skew")
+	}
+	if _, valid, _ = Validate(secret, "000000", at, 1); valid {
+		t.Error("expected a wrong code to be refused")
+	}
+	if _, valid, _ = Validate(secret, "50471", at, 1); valid {
+		t.Error("expected a short code to be refused")
+	}
+}
+
+func TestCodeWithLowerCaseSecret(t *testing.T) {
+	code, err := Code("gezdgnbvgy3tqojqgezdgnbvgy3tqojq", Step(time.Unix(59, 0)))
+	if err != nil || code != "287082" {
+		t.Errorf("expected 287082, got %s %v", code, err)
+	}
+}
@@ -0,0 +1,31 @@
+create table job_applications (
+  id varchar(40) primary key,
+  job_id varchar(40) not null references jobs(id) on delete cascade,
+  name varchar(120) not null,
+  email varchar(120) not null,
+  phone varchar(45),
+  cover_letter varchar(4000),
+  resume_name varchar(255) not null,
+  resume_path varchar(500) not null,
+  resume_type varchar(100) not null,
+  resume_size bigint not null,
+  stage varchar(20) not null default 'new',
+  applied_at timestamptz not null,
+  updated_by varchar(40),
+  updated_at timestamptz
+);
+create unique index job_applications_email on job_applications (job_id, lower(email));
+create index job_applications_stage on job_applications (job_id, stage);
+
+create table job_application_stages (
+  application_id varchar(40) not null references job_applications(id) on delete cascade,
+  from_stage varchar(20),
+  to_stage varchar(20) not null,
+  note varchar(1000),
+  changed_by varchar(40),
+  changed_at timestamptz not null
+);
+create index job_application_stages_application on job_application_stages (application_id, changed_at);
+
+alter table jobs alter column applicant_count set default 0;
+update jobs j set applicant_count = (select count(*) from job_applications a where a.job_id = j.id);
@@ -1,6 +1,7 @@
 create table articles (
   id varchar(80) primary key,
   title varchar(255) not null,
+  slug varchar(200),
   description varchar(1200) not null,
   content varchar(9500),
   published_at timestamptz,
@@ -636,3 +637,26 @@ insert into articles (id,title,description,content,published_at,tags,thumbnail,h
   With sustainability at the heart of its operation, the Environmental Policy reflects FPT''s consistent strategy of aligning company growth with
   social responsibility, while also delivering a greater impact for its customers, accompanying them in their green transformation journey.
 </p>','2024-05-30 17:25:05.967+07','{}','https://fptsoftware.com/-/media/project/fpt-software/fso/newsroom/news---press-release/fpt-issues-first-ever-environmental-policy.webp','https://fptsoftware.com/-/media/project/fpt-software/fso/newsroom/news---press-release/fpt-issues-first-ever-environmental-policy.webp','A');
+
+-- the slugs are normalized like Slugify: lower case, without diacritics, 'đ' as 'd', the words joined with '-', at most 200 characters;
+-- the articles with the same slug get a sequence from 2, like the slugs generated for the new articles
+update articles a set slug = d.slug from (
+  select id, case when n = 1 then base else trim(trailing '-' from left(base, 199 - length(n::text))) || '-' || n end as slug
+  from (
+    select id, base, row_number() over (partition by base order by id) as n
+    from (
+      select id, coalesce(nullif(trim(trailing '-' from left(trim(both '-' from regexp_replace(
+        translate(regexp_replace(normalize(lower(title), NFD), '[\u0300-\u036f]', '', 'g'), 'đ', 'd'),
+        '[^a-z0-9]+', '-', 'g')), 200)), ''), lower(id)) as base
+      from articles
+    ) s
+  ) n
+) d where a.id = d.id;
+alter table articles alter column slug set not null;
+create unique index articles_slug on articles (slug);
+
+create table article_redirects (
+  slug varchar(200) primary key,
+  article_id varchar(80) not null references articles (id) on delete cascade,
+  created_at timestamptz
+);
@@ -802,3 +802,20 @@ insert into contents (id,lang,title,body,published_at,tags,status,version) value
     </ul>
   </div>
 </div>',null,'{}','A',1);
+
+create table content_revisions (
+  id varchar(80) not null,
+  lang varchar(10) not null,
+  version integer not null,
+  title varchar(255) not null,
+  body varchar(9500),
+  published_at timestamptz,
+  tags character varying[],
+  status char(1),
+  created_by varchar(40),
+  created_at timestamptz,
+  primary key (id, lang, version)
+);
+
+insert into content_revisions (id, lang, version, title, body, published_at, tags, status, created_by, created_at)
+select id, lang, version, title, body, published_at, tags, status, created_by, coalesce(updated_at, created_at, now()) from contents;
@@ -36,6 +36,7 @@ create table users (
   language varchar(5),
   dateformat varchar(12),
   max_password_age integer,
+  auth_source varchar(10) not null default 'local',
   created_by varchar(40),
   created_at timestamptz,
   updated_by varchar(40),
@@ -54,13 +55,59 @@ create table passwords (
 create table passcodes (
   id varchar(40) primary key,
   code varchar(500) not null,
-  expired_at timestamptz not null
+  expired_at timestamptz not null,
+  attempts integer not null default 0
+);
+create table refresh_tokens (
+  id varchar(40) primary key,
+  user_id varchar(40) not null,
+  session_id varchar(40),
+  token varchar(64) not null unique,
+  expires_at timestamptz not null,
+  created_at timestamptz not null,
+  revoked_at timestamptz,
+  replaced_by varchar(40)
+);
+create index refresh_tokens_user_id on refresh_tokens (user_id);
+create index refresh_tokens_session_id on refresh_tokens (session_id);
+create table sessions (
+  id varchar(40) primary key,
+  user_id varchar(40) not null,
+  ip varchar(45),
+  user_agent varchar(500),
+  created_at timestamptz not null,
+  last_seen_at timestamptz not null,
+  expires_at timestamptz not null,
+  revoked_at timestamptz,
+  revoked_by varchar(40)
+);
+create index sessions_user_id on sessions (user_id);
+create table revoked_tokens (
+  token varchar(64) primary key,
+  user_id varchar(40),
+  expires_at timestamptz not null
+);
+create table token_revocations (
+  user_id varchar(40) primary key,
+  revoked_at timestamptz not null
+);
+create table user_two_factors (
+  user_id varchar(40) primary key,
+  secret varchar(64),
+  enabled boolean not null,
+  recovery_codes character varying[],
+  last_step bigint,
+  fail_count integer,
+  fail_time timestamptz,
+  enabled_at timestamptz,
+  created_at timestamptz
 );
 create table roles (
   role_id varchar(40) primary key,
   role_name varchar(255) not null,
   status char(1) not null,
   remark varchar(255),
+  two_factor boolean,
   created_by varchar(40),
   created_at timestamptz,
   updated_by varchar(40),
@@ -86,7 +133,39 @@ create table audit_logs (
   action varchar(255),
   time timestamptz,
   status varchar(255),
-  remark varchar(255)
+  remark varchar(255),
+  api_key_id varchar(40)
+);
+create table service_accounts (
+  id varchar(40) primary key,
+  name varchar(255) not null,
+  description varchar(400),
+  status char(1) not null,
+  created_by varchar(40),
+  created_at timestamptz,
+  updated_by varchar(40),
+  updated_at timestamptz
+);
+create table api_keys (
+  id varchar(40) primary key,
+  account_id varchar(40) not null,
+  name varchar(255) not null,
+  prefix varchar(8) not null,
+  hash varchar(64) not null unique,
+  ips character varying[],
+  expires_at timestamptz,
+  last_used_at timestamptz,
+  created_by varchar(40),
+  created_at timestamptz not null,
+  revoked_by varchar(40),
+  revoked_at timestamptz
+);
+create index api_keys_account_id on api_keys (account_id);
+create table api_key_modules (
+  key_id varchar(40) not null,
+  module_id varchar(40) not null,
+  permissions int not null,
+  primary key (key_id, module_id)
 );
 insert into modules (module_id,module_name,status,path,resource_key,icon,sequence,actions,parent) values ('admin','Admin','A','/admin','admin','contacts',2,7,'');
 insert into modules (module_id,module_name,status,path,resource_key,icon,sequence,actions,parent) values ('setup','Setup','A','/setup','setup','settings',3,7,'');
@@ -94,12 +173,17 @@ insert into modules (module_id,module_name,status,path,resource_key,icon,sequenc
 insert into modules (module_id,module_name,status,path,resource_key,icon,sequence,actions,parent) values ('user','User Management','A','/users','user','person',1,7,'admin');
 insert into modules (module_id,module_name,status,path,resource_key,icon,sequence,actions,parent) values ('role','Role Management','A','/roles','role','credit_card',2,7,'admin');
 insert into modules (module_id,module_name,status,path,resource_key,icon,sequence,actions,parent) values ('audit_log','Audit Log','A','/audit-logs','audit_log','zoom_in',4,1,'admin');
+insert into modules (module_id,module_name,status,path,resource_key,icon,sequence,actions,parent) values ('service_account','Service Account','A','/service-accounts','service_account','vpn_key',5,7,'admin');
 
 insert into modules (module_id,module_name,status,path,resource_key,icon,sequence,actions,parent) values ('category','Category','A','/categories','category','menu',1,7,'setup');
 insert into modules (module_id,module_name,status,path,resource_key,icon,sequence,actions,parent) values ('content','Content','A','/contents','content','public',2,7,'setup');
-insert into modules (module_id,module_name,status,path,resource_key,icon,sequence,actions,parent) values ('article','Article','A','/articles','article','public',3,7,'setup');
+insert into modules (module_id,module_name,status,path,resource_key,icon,sequence,actions,parent) values ('article','Article','A','/articles','article','public',3,15,'setup');
 insert into modules (module_id,module_name,status,path,resource_key,icon,sequence,actions,parent) values ('job','Job','A','/jobs','jobs','local_atm',4,7,'setup');
 insert into modules (module_id,module_name,status,path,resource_key,icon,sequence,actions,parent) values ('contact','Contact','A','/contacts','contact','public',5,7,'setup');
+insert into modules (module_id,module_name,status,path,resource_key,icon,sequence,actions,parent) values ('media','Media','A','/media','media','perm_media',6,7,'setup');
+insert into modules (module_id,module_name,status,path,resource_key,icon,sequence,actions,parent) values ('tag','Tag','A','/tags','tag','local_offer',7,7,'setup');
+insert into modules (module_id,module_name,status,path,resource_key,icon,sequence,actions,parent) values ('company','Company','A','/companies','company','business',8,7,'setup');
+insert into modules (module_id,module_name,status,path,resource_key,icon,sequence,actions,parent) values ('application','Application','A','/applications','application','assignment_ind',9,7,'setup');
 
 insert into roles (role_id, role_name, status, remark) values ('admin','Admin','A','Admin');
 insert into roles (role_id, role_name, status, remark) values ('call_center','Call Center','A','Call Center');
@@ -170,17 +254,23 @@ insert into role_modules(role_id, module_id, permissions) values ('admin', 'setu
 insert into role_modules(role_id, module_id, permissions) values ('admin', 'user', 7);
 insert into role_modules(role_id, module_id, permissions) values ('admin', 'role', 7);
 insert into role_modules(role_id, module_id, permissions) values ('admin', 'audit_log', 7);
+insert into role_modules(role_id, module_id, permissions) values ('admin', 'service_account', 7);
 
 insert into role_modules(role_id, module_id, permissions) values ('it_support', 'admin', 7);
 insert into role_modules(role_id, module_id, permissions) values ('it_support', 'user', 7);
 insert into role_modules(role_id, module_id, permissions) values ('it_support', 'role', 7);
 insert into role_modules(role_id, module_id, permissions) values ('it_support', 'audit_log', 7);
+insert into role_modules(role_id, module_id, permissions) values ('it_support', 'service_account', 7);
 insert into role_modules(role_id, module_id, permissions) values ('it_support', 'setup', 7);
 insert into role_modules(role_id, module_id, permissions) values ('it_support', 'category', 7);
 insert into role_modules(role_id, module_id, permissions) values ('it_support', 'content', 7);
-insert into role_modules(role_id, module_id, permissions) values ('it_support', 'article', 7);
+insert into role_modules(role_id, module_id, permissions) values ('it_support', 'article', 15);
 insert into role_modules(role_id, module_id, permissions) values ('it_support', 'job', 7);
 insert into role_modules(role_id, module_id, permissions) values ('it_support', 'contact', 7);
+insert into role_modules(role_id, module_id, permissions) values ('it_support', 'media', 7);
+insert into role_modules(role_id, module_id, permissions) values ('it_support', 'tag', 7);
+insert into role_modules(role_id, module_id, permissions) values ('it_support', 'company', 7);
+insert into role_modules(role_id, module_id, permissions) values ('it_support', 'application', 7);
 
 insert into audit_logs (id, resource, user_id, ip, "action", "time", status, remark) values('6xydt3Qap', 'authentication', '00005', '188.239.138.226', 'authenticate', '2023-07-02 21:00:06.811', 'success', '');
 insert into audit_logs (id, resource, user_id, ip, "action", "time", status, remark) values('gRAIVh1tM', 'term', '00005', '188.239.138.226', 'patch', '2023-07-03 12:09:51.659', 'success', '');
@@ -316,7 +406,10 @@ create table companies (
 insert into companies (id,name,description,slogan,image_url,cover_url,sequence) values
 	 ('fpt-automotive','FPT Automotive','With two decades of experience in the Automotive industry, FPT Software''s automotive technology subsidiary, FPT Automotive was launched in 2023 with a mission to drive the advancement of software-defined vehicles and shape the new mobility era.
 
-Our team of automotive experts is equipped and experienced to accompany car manufacturers and suppliers in advancing the mobility ecosystem, having enabled the world''s leading automakers, OEMs, Tier-1 suppliers, and semiconductor companies to innovate, optimize and maintain a competitive edge in the automotive industry. This support is crucial for navigating challenges such as industry volatility, disrupted supply chains, and rapidly evolving market demands.','Moving into the fast lane of smart, software-defined mobility.','https://fptsoftware.com/-/media/project/fpt-software/fso/industries/automotive/automotive-lp_banner-3_mobile.png','https://fptsoftware.com/-/media/project/fpt-software/fso/industries/automotive/automotive-lp_banner-3.png',1);
+Our team of automotive experts is equipped and experienced to accompany car manufacturers and suppliers in advancing the mobility ecosystem, having enabled the world''s leading automakers, OEMs, Tier-1 suppliers, and semiconductor companies to innovate, optimize and maintain a competitive edge in the automotive industry. This support is crucial for navigating challenges such as industry volatility, disrupted supply chains, and rapidly evolving market demands.','Moving into the fast lane of smart, software-defined mobility.','https://fptsoftware.com/-/media/project/fpt-software/fso/industries/automotive/automotive-lp_banner-3_mobile.png','https://fptsoftware.com/-/media/project/fpt-software/fso/industries/automotive/automotive-lp_banner-3.png',1),
+	 ('Flourish','Flourish Software','Flourish Software is a cannabis supply chain and retail software company, which helps the businesses of the industry with compliance and operations.',NULL,NULL,NULL,2),
+	 ('NetBird','NetBird','A team from Germany developing an open-source zero-trust network security platform, which is easy to use and affordable for teams of all sizes and budgets.',NULL,NULL,NULL,3),
+	 ('Triple A','Triple-A','Triple-A is a global payment institution licensed in the United States, Europe, and Singapore, enabling businesses worldwide to pay and get paid in both local and digital currencies.',NULL,NULL,NULL,4);
 
 create table if not exists contacts (
   id varchar(40) primary key,
@@ -329,8 +422,36 @@ create table if not exists contacts (
   message varchar(1000),
   submitted_at timestamptz,
   contacted_by varchar(120),
-  contacted_at timestamptz
+  contacted_at timestamptz,
+  assigned_to varchar(40),
+  assigned_at timestamptz,
+  duplicate_of varchar(40),
+  merged_into varchar(40)
+);
+create index contacts_email on contacts (lower(email), submitted_at);
+create index contacts_phone on contacts (phone, submitted_at);
+create index contacts_open on contacts (submitted_at) where contacted_at is null;
+
+create table if not exists contact_notes (
+  id varchar(40) primary key,
+  contact_id varchar(40) not null references contacts(id) on delete cascade,
+  note varchar(2000) not null,
+  created_by varchar(40),
+  created_at timestamptz not null
+);
+create index contact_notes_contact on contact_notes (contact_id, created_at);
+
+create table if not exists contact_merges (
+  id varchar(40) primary key,
+  primary_id varchar(40) not null,
+  merged_ids varchar(40)[] not null,
+  note_ids varchar(40)[],
+  merged_by varchar(40),
+  merged_at timestamptz not null,
+  undone_by varchar(40),
+  undone_at timestamptz
 );
+create index contact_merges_primary on contact_merges (primary_id, merged_at);
 
 insert into contacts (id,"name",country,company,job_title,email,phone,message,submitted_at) values
   ('E7UBXeHrp','Duc Nguyen','Vietnam','TMA','Manager','duc.n@tma.com.vn','93 334-7686','I want to hire 8 developers','2024-10-20 18:59:47.820962+07'),
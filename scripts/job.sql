@@ -13,6 +13,7 @@ create table jobs (
   min_salary bigint,
   max_salary bigint,
   company_id character varying(40),
+  status char(1),
   created_by varchar(40),
   created_at timestamptz,
   updated_by varchar(40),
@@ -625,3 +626,11 @@ insert into jobs (id,title,description,original_link,published_at,expired_at,pos
     depending on experience and region.
   </li>
 </ul>','https://www.flourishsoftware.com/careers/backend-engineer-go-remote','2025-01-08 15:34:44.395+07',NULL,'GO Backend Engineer',3,'Remote',1,'{}',75000,120000,'Flourish');
+
+update jobs set status = 'A';
+
+create index jobs_skills on jobs using gin (skills);
+
+-- run after data.sql, which creates the companies of the jobs
+alter table jobs add constraint jobs_company_id foreign key (company_id) references companies (id) on delete set null;
+create index jobs_company_id on jobs (company_id);
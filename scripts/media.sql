@@ -0,0 +1,24 @@
+create table media (
+  id varchar(40) primary key,
+  name varchar(255) not null,
+  url varchar(500) not null,
+  path varchar(500) not null,
+  mime_type varchar(100) not null,
+  size bigint not null,
+  width integer,
+  height integer,
+  alt varchar(255),
+  uploaded_by varchar(40),
+  uploaded_at timestamptz
+);
+
+create table media_variants (
+  media_id varchar(40) not null,
+  name varchar(40) not null,
+  url varchar(500) not null,
+  path varchar(500) not null,
+  width integer,
+  height integer,
+  size bigint,
+  primary key (media_id, name)
+);
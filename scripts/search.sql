@@ -0,0 +1,54 @@
+-- run after article.sql, content.sql and job.sql
+-- the languages of the contents are mapped to the text search configurations of postgres 13;
+-- postgres has no configuration for vietnamese, so 'vi' and the other languages are searched by words with 'simple'
+create or replace function search_config(lang varchar) returns regconfig as $$
+  select case lang
+    when 'ar' then 'arabic'::regconfig
+    when 'da' then 'danish'::regconfig
+    when 'de' then 'german'::regconfig
+    when 'el' then 'greek'::regconfig
+    when 'en' then 'english'::regconfig
+    when 'es' then 'spanish'::regconfig
+    when 'fi' then 'finnish'::regconfig
+    when 'fr' then 'french'::regconfig
+    when 'ga' then 'irish'::regconfig
+    when 'hu' then 'hungarian'::regconfig
+    when 'id' then 'indonesian'::regconfig
+    when 'it' then 'italian'::regconfig
+    when 'lt' then 'lithuanian'::regconfig
+    when 'ne' then 'nepali'::regconfig
+    when 'nl' then 'dutch'::regconfig
+    when 'no' then 'norwegian'::regconfig
+    when 'pt' then 'portuguese'::regconfig
+    when 'ro' then 'romanian'::regconfig
+    when 'ru' then 'russian'::regconfig
+    when 'sv' then 'swedish'::regconfig
+    when 'ta' then 'tamil'::regconfig
+    when 'tr' then 'turkish'::regconfig
+    when 'vi' then 'simple'::regconfig
+    else 'simple'::regconfig
+  end
+$$ language sql immutable;
+
+create or replace function strip_html(html text) returns text as $$
+  select regexp_replace(coalesce(html, ''), '<[^>]*>', ' ', 'g')
+$$ language sql immutable;
+
+alter table articles add column search_vector tsvector generated always as (
+  setweight(to_tsvector(search_config('en'), coalesce(title, '')), 'A') ||
+  setweight(to_tsvector(search_config('en'), coalesce(description, '')), 'B') ||
+  setweight(to_tsvector(search_config('en'), strip_html(content)), 'C')
+) stored;
+create index articles_search_vector on articles using gin (search_vector);
+
+alter table contents add column search_vector tsvector generated always as (
+  setweight(to_tsvector(search_config(lang), coalesce(title, '')), 'A') ||
+  setweight(to_tsvector(search_config(lang), strip_html(body)), 'C')
+) stored;
+create index contents_search_vector on contents using gin (search_vector);
+
+alter table jobs add column search_vector tsvector generated always as (
+  setweight(to_tsvector(search_config('en'), coalesce(title, '')), 'A') ||
+  setweight(to_tsvector(search_config('en'), strip_html(description)), 'C')
+) stored;
+create index jobs_search_vector on jobs using gin (search_vector);
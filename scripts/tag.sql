@@ -0,0 +1,13 @@
+create table tags (
+  id varchar(120) primary key,
+  description varchar(400),
+  created_by varchar(40),
+  created_at timestamptz
+);
+
+insert into tags (id, created_by, created_at)
+select distinct t, 'system', now() from (
+  select unnest(tags) as t from articles
+  union select unnest(tags) from contents
+  union select unnest(skills) from jobs
+) u where t is not null and t <> '';